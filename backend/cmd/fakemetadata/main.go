@@ -0,0 +1,22 @@
+// Command fakemetadata serves a fake TMDb API from built-in fixture movies,
+// for working on metadata lookups offline. Point the API at it with
+// TMDB_API_URL=http://localhost:8090 and
+// TMDB_IMAGE_URL=http://localhost:8090/images; any TMDB_API_TOKEN works.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/metadata"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8090", "address to listen on")
+	rate := flag.Int("rate", 0, "requests per second before answering 429, 0 for no limit")
+	flag.Parse()
+
+	log.Printf("fake TMDb API listening on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, metadata.NewFakeTMDb(*rate)))
+}
@@ -7,8 +7,12 @@ import (
 
 	"github.com/AfomiaTadesse/Afomia_M/backend/config"
 	"github.com/AfomiaTadesse/Afomia_M/backend/controller"
+	"github.com/AfomiaTadesse/Afomia_M/backend/graph"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
+	"github.com/AfomiaTadesse/Afomia_M/backend/metadata"
 	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
 	"github.com/AfomiaTadesse/Afomia_M/backend/router"
+	"github.com/AfomiaTadesse/Afomia_M/backend/storage"
 	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -29,17 +33,140 @@ func main() {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db)
 	movieRepo := repository.NewMovieRepository(db)
+	reviewRepo := repository.NewReviewRepository(db)
+	watchlistRepo := repository.NewWatchlistRepository(db)
+	diaryRepo := repository.NewDiaryRepository(db)
+	listRepo := repository.NewListRepository(db)
+	collectionRepo := repository.NewCollectionRepository(db)
+	likeRepo := repository.NewLikeRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	followRepo := repository.NewFollowRepository(db)
+	activityRepo := repository.NewActivityRepository(db)
+	similarRepo := repository.NewSimilarityRepository(db)
+	ratingModelRepo := repository.NewRatingModelRepository(db)
+	trendingRepo := repository.NewTrendingRepository(db)
+	importJobRepo := repository.NewImportJobRepository(db)
+	userDataRepo := repository.NewUserDataRepository(db)
+	dataExportRepo := repository.NewDataExportRepository(db)
+	erasureRepo := repository.NewErasureRepository(db)
+	redirectRepo := repository.NewMovieRedirectRepository(db)
+	movieReferenceRepo := repository.NewMovieReferenceRepository(db)
+
+	// Uploaded images live outside the database
+	var blobs storage.BlobStore
+	switch cfg.BlobStore {
+	case "s3":
+		blobs = storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	case "fs":
+		blobs = storage.NewFileStore(cfg.BlobDir)
+	default:
+		log.Fatalf("unknown BLOB_STORE %q, expected fs or s3", cfg.BlobStore)
+	}
+
+	// Movie metadata lookups are optional
+	var metadataProvider metadata.Provider
+	switch cfg.MetadataProvider {
+	case "tmdb":
+		metadataProvider = metadata.NewTMDbClient(metadata.TMDbConfig{
+			BaseURL:           cfg.TMDbAPIURL,
+			ImageURL:          cfg.TMDbImageURL,
+			Token:             cfg.TMDbAPIToken,
+			RequestsPerSecond: cfg.TMDbRequestsPerSecond,
+			CacheTTL:          cfg.MetadataCacheTTL,
+		})
+	case "":
+	default:
+		log.Fatalf("unknown METADATA_PROVIDER %q, expected tmdb or nothing", cfg.MetadataProvider)
+	}
+
+	locales := i18n.NewLocales(cfg.DefaultLocale, cfg.SupportedLocales)
 
 	// Initialize use cases
+	permissions := usecase.NewPermissionService(collectionRepo)
+	activities := usecase.NewActivityRecorder(activityRepo)
+	similarity := usecase.NewSimilarityIndexer(movieRepo, similarRepo, cfg.SimilarRefreshInterval)
+	events := usecase.NewEventWriter(trendingRepo, cfg.EventFlushInterval)
+	trending := usecase.NewTrendingAggregator(trendingRepo, cfg.TrendingRefreshInterval)
 	userUsecase := usecase.NewUserUsecase(userRepo, cfg.JWTSecret, time.Hour)
-	movieUsecase := usecase.NewMovieUsecase(movieRepo)
+	posterUsecase := usecase.NewPosterUsecase(movieRepo, blobs, permissions)
+	movieUsecase := usecase.NewMovieUsecase(movieRepo, listRepo, movieReferenceRepo, permissions, activities, similarity, events, redirectRepo, posterUsecase, locales)
+	reviewUsecase := usecase.NewReviewUsecase(reviewRepo, movieRepo, activities, events)
+	watchlistUsecase := usecase.NewWatchlistUsecase(watchlistRepo, movieRepo)
+	diaryUsecase := usecase.NewDiaryUsecase(diaryRepo, movieRepo)
+	listUsecase := usecase.NewListUsecase(listRepo, movieRepo, activities, events)
+	collectionUsecase := usecase.NewCollectionUsecase(collectionRepo, movieRepo, userRepo)
+	likeUsecase := usecase.NewLikeUsecase(likeRepo, movieRepo, events)
+	commentFilter := usecase.NewKeywordFilter(cfg.CommentBlockedWords, cfg.CommentFlaggedWords)
+	commentUsecase := usecase.NewCommentUsecase(commentRepo, movieRepo, userRepo, commentFilter)
+	followUsecase := usecase.NewFollowUsecase(followRepo, userRepo)
+	feedUsecase := usecase.NewFeedUsecase(activityRepo, followRepo, movieRepo, listRepo, reviewRepo, userRepo)
+	trendingUsecase := usecase.NewTrendingUsecase(trendingRepo, movieRepo)
+	importUsecase := usecase.NewImportUsecase(importJobRepo, movieRepo, reviewRepo, diaryRepo, watchlistRepo, listRepo, similarity)
+	exportUsecase := usecase.NewExportUsecase(movieRepo, reviewRepo, listRepo, diaryRepo)
+	accountUsecase := usecase.NewAccountUsecase(userRepo, userDataRepo, dataExportRepo, erasureRepo, cfg.DataExportDir, cfg.DataExportTTL, cfg.ErasureGracePeriod)
+	metadataUsecase := usecase.NewMetadataUsecase(movieRepo, metadataProvider, posterUsecase, permissions, similarity)
+	duplicateUsecase := usecase.NewDuplicateUsecase(userRepo, movieRepo, reviewRepo, likeRepo, redirectRepo, movieReferenceRepo, similarity, posterUsecase)
+	accountSweeper := usecase.NewAccountSweeper(userDataRepo, dataExportRepo, erasureRepo, reviewRepo, movieRepo, cfg.DataExportDir, cfg.AccountSweepInterval)
+	recommendationUsecase := usecase.NewRecommendationUsecase(similarRepo, ratingModelRepo, movieRepo, reviewRepo, diaryRepo, likeRepo)
 
 	// Initialize controllers
 	userCtrl := controller.NewUserController(userUsecase)
 	movieCtrl := controller.NewMovieController(movieUsecase)
+	reviewCtrl := controller.NewReviewController(reviewUsecase)
+	watchlistCtrl := controller.NewWatchlistController(watchlistUsecase)
+	diaryCtrl := controller.NewDiaryController(diaryUsecase)
+	listCtrl := controller.NewListController(listUsecase)
+	collectionCtrl := controller.NewCollectionController(collectionUsecase)
+	likeCtrl := controller.NewLikeController(likeUsecase)
+	commentCtrl := controller.NewCommentController(commentUsecase)
+	followCtrl := controller.NewFollowController(followUsecase)
+	feedCtrl := controller.NewFeedController(feedUsecase)
+	recommendationCtrl := controller.NewRecommendationController(recommendationUsecase)
+	trendingCtrl := controller.NewTrendingController(trendingUsecase)
+	importCtrl := controller.NewImportController(importUsecase)
+	exportCtrl := controller.NewExportController(exportUsecase)
+	accountCtrl := controller.NewAccountController(accountUsecase)
+	adminCtrl := controller.NewAdminController(duplicateUsecase)
+	posterCtrl := controller.NewPosterController(posterUsecase, cfg.PosterMaxSize)
+	metadataCtrl := controller.NewMetadataController(metadataUsecase)
+
+	schema, err := graph.NewSchema(movieUsecase, userUsecase, reviewUsecase, listUsecase, movieRepo, userRepo, reviewRepo, graph.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	})
+	if err != nil {
+		log.Fatalf("failed to build the GraphQL schema: %v", err)
+	}
+	graphqlCtrl := controller.NewGraphQLController(schema, cfg.MaxRequestBodySize)
+
+	// Imports and data exports run in this process, so any still marked running were cut short
+	if failed, err := importJobRepo.FailRunning(context.Background(), "Interrupted by a server restart"); err != nil {
+		log.Printf("failed to clean up import jobs: %v", err)
+	} else if failed > 0 {
+		log.Printf("marked %d interrupted import jobs as failed", failed)
+	}
+	if failed, err := dataExportRepo.FailRunning(context.Background(), "Interrupted by a server restart"); err != nil {
+		log.Printf("failed to clean up data exports: %v", err)
+	} else if failed > 0 {
+		log.Printf("marked %d interrupted data exports as failed", failed)
+	}
+
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go similarity.Run(jobsCtx)
+	go events.Run(jobsCtx)
+	go trending.Run(jobsCtx)
+	go accountSweeper.Run(jobsCtx)
 
-	// Setup router with both controllers
-	r := router.SetupRouter(userCtrl, movieCtrl,cfg.JWTSecret)
+	// Setup router with all controllers
+	r := router.SetupRouter(userCtrl, movieCtrl, reviewCtrl, watchlistCtrl, diaryCtrl, listCtrl, collectionCtrl, likeCtrl, commentCtrl, followCtrl, feedCtrl, recommendationCtrl, trendingCtrl, importCtrl, exportCtrl, accountCtrl, adminCtrl, posterCtrl, metadataCtrl, graphqlCtrl, locales, cfg.JWTSecret, cfg.ValidateResponses, cfg.MaxRequestBodySize)
 
 	// Start server
 	if err := r.Run(":" + cfg.Port); err != nil {
@@ -0,0 +1,52 @@
+// Command openapi writes the OpenAPI document the API serves at
+// /openapi.json to openapi/openapi.json, where it is kept under version
+// control. With -check it writes nothing and fails when the committed
+// document no longer matches the routes and domain types, as when one
+// changed without the document being regenerated. The openapi package's
+// tests make the same check.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/openapi"
+	"github.com/AfomiaTadesse/Afomia_M/backend/router"
+	"github.com/gin-gonic/gin"
+)
+
+func main() {
+	out := flag.String("o", "openapi/openapi.json", "file to write the document to, or to check it against")
+	check := flag.Bool("check", false, "fail if the file is out of date instead of writing it")
+	flag.Parse()
+
+	gin.SetMode(gin.ReleaseMode)
+
+	// Only the routes are needed, so no controller is set up
+	engine := router.SetupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", false, 0)
+	doc, err := openapi.Generate(engine.Routes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	data, err := doc.JSON()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *check {
+		committed, err := os.ReadFile(*out)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !bytes.Equal(committed, data) {
+			log.Fatalf("%s is out of date with the routes or domain types; run go run ./cmd/openapi and commit the result", *out)
+		}
+		return
+	}
+
+	if err := os.WriteFile(*out, data, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,48 @@
+// Command recommender rebuilds the collaborative filtering model behind
+// GET /users/me/recommendations. Run it periodically, for example nightly
+// from cron; the API keeps serving the previous model while it runs.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/config"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func main() {
+	if err := run(config.Load()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run builds the model, returning rather than exiting on failure so that
+// the database connection is always closed.
+func run(cfg *config.Config) error {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(cfg.MongoURI))
+	if err != nil {
+		return err
+	}
+	defer client.Disconnect(context.Background())
+
+	db := client.Database("movie_collection")
+
+	builder := usecase.NewRatingModelBuilder(
+		repository.NewReviewRepository(db),
+		repository.NewRatingModelRepository(db),
+		cfg.RecommendationMinSupport,
+	)
+
+	started := time.Now()
+	movies, err := builder.Build(context.Background())
+	if err != nil {
+		return err
+	}
+	log.Printf("built recommendation model for %d movies in %s", movies, time.Since(started).Round(time.Millisecond))
+	return nil
+}
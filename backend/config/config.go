@@ -4,12 +4,77 @@ import (
 	"github.com/joho/godotenv"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	MongoURI  string
 	JWTSecret string
 	Port      string
+
+	// Comment content filter word lists
+	CommentBlockedWords []string
+	CommentFlaggedWords []string
+
+	// How often the similar-movies index is checked for changes to rebuild
+	SimilarRefreshInterval time.Duration
+
+	// Movie pairs rated by fewer users in common are left out of the
+	// collaborative filtering model
+	RecommendationMinSupport int
+
+	// How often buffered movie events are written, and how often the
+	// trending rankings are recomputed from them
+	EventFlushInterval      time.Duration
+	TrendingRefreshInterval time.Duration
+
+	// Where account data archives are written and how long they are kept
+	DataExportDir string
+	DataExportTTL time.Duration
+
+	// How long a requested account erasure waits, so it can be cancelled,
+	// and how often due erasures and expired archives are swept
+	ErasureGracePeriod   time.Duration
+	AccountSweepInterval time.Duration
+
+	// Where uploaded images are stored: "fs" keeps them under BlobDir,
+	// "s3" in an S3-compatible bucket
+	BlobStore     string
+	BlobDir       string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	PosterMaxSize int64
+
+	// Where movie metadata is looked up: "tmdb" for a TMDb-compatible API,
+	// empty for nowhere
+	MetadataProvider      string
+	TMDbAPIURL            string
+	TMDbImageURL          string
+	TMDbAPIToken          string
+	TMDbRequestsPerSecond int
+	MetadataCacheTTL      time.Duration
+
+	// Languages movie titles and descriptions can be written in, as ISO
+	// 639-1 codes, and the one used when a request asks for none of them
+	DefaultLocale    string
+	SupportedLocales []string
+
+	// Whether successful responses are checked against the OpenAPI
+	// document as well as requests; meant for development
+	ValidateResponses bool
+
+	// The largest JSON request body read, in bytes
+	MaxRequestBodySize int64
+
+	// Limits on GraphQL queries: how deeply fields may nest, and their
+	// estimated cost, with lists counting once per item asked for
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
 }
 
 func Load() *Config {
@@ -21,6 +86,45 @@ func Load() *Config {
 		MongoURI:  getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		JWTSecret: getEnv("JWT_SECRET", "default-secret-key"), 
 		Port:      getEnv("PORT", "8080"),
+
+		CommentBlockedWords: getEnvList("COMMENT_BLOCKED_WORDS"),
+		CommentFlaggedWords: getEnvList("COMMENT_FLAGGED_WORDS"),
+
+		SimilarRefreshInterval:   getEnvDuration("SIMILAR_REFRESH_INTERVAL", 5*time.Minute),
+		RecommendationMinSupport: getEnvInt("RECOMMENDATION_MIN_SUPPORT", 3),
+		EventFlushInterval:       getEnvDuration("EVENT_FLUSH_INTERVAL", 10*time.Second),
+		TrendingRefreshInterval:  getEnvDuration("TRENDING_REFRESH_INTERVAL", 10*time.Minute),
+
+		DataExportDir:        getEnv("DATA_EXPORT_DIR", "data/exports"),
+		DataExportTTL:        getEnvDuration("DATA_EXPORT_TTL", 7*24*time.Hour),
+		ErasureGracePeriod:   getEnvDuration("ERASURE_GRACE_PERIOD", 30*24*time.Hour),
+		AccountSweepInterval: getEnvDuration("ACCOUNT_SWEEP_INTERVAL", time.Hour),
+
+		BlobStore:     getEnv("BLOB_STORE", "fs"),
+		BlobDir:       getEnv("BLOB_DIR", "data/blobs"),
+		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:      getEnv("S3_BUCKET", ""),
+		S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
+		PosterMaxSize: int64(getEnvInt("POSTER_MAX_SIZE", 10<<20)),
+
+		MetadataProvider:      getEnv("METADATA_PROVIDER", ""),
+		TMDbAPIURL:            getEnv("TMDB_API_URL", "https://api.themoviedb.org/3"),
+		TMDbImageURL:          getEnv("TMDB_IMAGE_URL", "https://image.tmdb.org/t/p/original"),
+		TMDbAPIToken:          getEnv("TMDB_API_TOKEN", ""),
+		TMDbRequestsPerSecond: getEnvInt("TMDB_REQUESTS_PER_SECOND", 20),
+		MetadataCacheTTL:      getEnvDuration("METADATA_CACHE_TTL", 24*time.Hour),
+
+		DefaultLocale:    getEnv("DEFAULT_LOCALE", "en"),
+		SupportedLocales: strings.Split(getEnv("SUPPORTED_LOCALES", "en,am,fr"), ","),
+
+		ValidateResponses: getEnvBool("VALIDATE_RESPONSES", false),
+
+		MaxRequestBodySize: int64(getEnvInt("MAX_REQUEST_BODY_SIZE", 1<<20)),
+
+		GraphQLMaxDepth:      getEnvInt("GRAPHQL_MAX_DEPTH", 10),
+		GraphQLMaxComplexity: getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000),
 	}
 }
 
@@ -29,4 +133,58 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvList reads a comma-separated list, returning nil when unset.
+func getEnvList(key string) []string {
+	value := getEnv(key, "")
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// getEnvDuration reads a duration such as "90s" or "5m", falling back to
+// defaultValue when unset or invalid.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvBool reads a boolean such as "true" or "1", falling back to
+// defaultValue when unset or invalid.
+func getEnvBool(key string, defaultValue bool) bool {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid %s %q, using %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+// getEnvInt reads a positive integer, falling back to defaultValue when
+// unset or invalid.
+func getEnvInt(key string, defaultValue int) int {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
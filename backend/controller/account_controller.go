@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AccountController struct {
+	accountUsecase usecase.AccountUsecase
+}
+
+func NewAccountController(accountUsecase usecase.AccountUsecase) *AccountController {
+	return &AccountController{accountUsecase: accountUsecase}
+}
+
+// RequestDataExport starts building an archive of all the caller's data.
+func (ctrl *AccountController) RequestDataExport(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.accountUsecase.RequestDataExport(userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusAccepted, response)
+}
+
+func (ctrl *AccountController) GetDataExport(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.accountUsecase.GetDataExport(c.Param("exportId"), userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *AccountController) DownloadDataExport(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	path, err := ctrl.accountUsecase.GetDataExportFile(c.Param("exportId"), userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.FileAttachment(path, "account-data.zip")
+}
+
+// RequestErasure schedules erasure of the caller's account.
+func (ctrl *AccountController) RequestErasure(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.accountUsecase.RequestErasure(userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusAccepted, response)
+}
+
+func (ctrl *AccountController) GetErasure(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.accountUsecase.GetErasure(userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *AccountController) CancelErasure(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.accountUsecase.CancelErasure(userID.(string))
+	respond(c, response, err)
+}
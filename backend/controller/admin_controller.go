@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type AdminController struct {
+	duplicateUsecase usecase.DuplicateUsecase
+}
+
+func NewAdminController(duplicateUsecase usecase.DuplicateUsecase) *AdminController {
+	return &AdminController{duplicateUsecase: duplicateUsecase}
+}
+
+func (ctrl *AdminController) GetDuplicates(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.duplicateUsecase.FindDuplicates(userID.(string), limit)
+	respond(c, response, err)
+}
+
+func (ctrl *AdminController) MergeMovies(c *gin.Context) {
+	var req domain.MergeMoviesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.duplicateUsecase.MergeMovies(userID.(string), &req)
+	respond(c, response, err)
+}
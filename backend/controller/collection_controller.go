@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CollectionController struct {
+	collectionUsecase usecase.CollectionUsecase
+}
+
+func NewCollectionController(collectionUsecase usecase.CollectionUsecase) *CollectionController {
+	return &CollectionController{collectionUsecase: collectionUsecase}
+}
+
+func (ctrl *CollectionController) CreateCollection(c *gin.Context) {
+	var req domain.CreateCollectionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.collectionUsecase.CreateCollection(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *CollectionController) GetMyCollections(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.GetMyCollections(userID.(string), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *CollectionController) GetCollection(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.GetCollection(id, userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *CollectionController) GetCollectionMovies(c *gin.Context) {
+	id := c.Param("id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.GetCollectionMovies(id, userID.(string), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *CollectionController) UpdateCollection(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.UpdateCollectionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.UpdateCollection(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) DeleteCollection(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.DeleteCollection(id, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) InviteMember(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.InviteMemberRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.InviteMember(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) UpdateMemberRole(c *gin.Context) {
+	id := c.Param("id")
+	memberID := c.Param("userId")
+
+	var req domain.UpdateMemberRoleRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.UpdateMemberRole(id, memberID, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) RemoveMember(c *gin.Context) {
+	id := c.Param("id")
+	memberID := c.Param("userId")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.RemoveMember(id, memberID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) CreateInviteLink(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.CreateInviteLinkRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.CreateInviteLink(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) RevokeInviteLink(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.RevokeInviteLink(id, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *CollectionController) JoinByInvite(c *gin.Context) {
+	token := c.Param("token")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.collectionUsecase.JoinByInvite(token, userID.(string))
+	respond(c, response, err)
+}
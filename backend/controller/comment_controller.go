@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type CommentController struct {
+	commentUsecase usecase.CommentUsecase
+}
+
+func NewCommentController(commentUsecase usecase.CommentUsecase) *CommentController {
+	return &CommentController{commentUsecase: commentUsecase}
+}
+
+func (ctrl *CommentController) CreateComment(c *gin.Context) {
+	var req domain.CreateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+	req.MovieID = c.Param("id")
+
+	response, err := ctrl.commentUsecase.CreateComment(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *CommentController) UpdateComment(c *gin.Context) {
+	movieID := c.Param("id")
+	commentID := c.Param("commentId")
+
+	var req domain.UpdateCommentRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.commentUsecase.UpdateComment(movieID, commentID, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *CommentController) DeleteComment(c *gin.Context) {
+	movieID := c.Param("id")
+	commentID := c.Param("commentId")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.commentUsecase.DeleteComment(movieID, commentID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *CommentController) GetThreads(c *gin.Context) {
+	movieID := c.Param("id")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := ctrl.commentUsecase.GetThreads(movieID, c.Query("cursor"), limit)
+	ctrl.respondCursor(c, response, err)
+}
+
+func (ctrl *CommentController) GetReplies(c *gin.Context) {
+	movieID := c.Param("id")
+	commentID := c.Param("commentId")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	response, err := ctrl.commentUsecase.GetReplies(movieID, commentID, c.Query("cursor"), limit)
+	ctrl.respondCursor(c, response, err)
+}
+
+func (ctrl *CommentController) respondCursor(c *gin.Context, response *domain.CursorResponse, err error) {
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
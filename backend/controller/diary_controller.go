@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type DiaryController struct {
+	diaryUsecase usecase.DiaryUsecase
+}
+
+func NewDiaryController(diaryUsecase usecase.DiaryUsecase) *DiaryController {
+	return &DiaryController{diaryUsecase: diaryUsecase}
+}
+
+func (ctrl *DiaryController) LogViewing(c *gin.Context) {
+	var req domain.LogViewingRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.diaryUsecase.LogViewing(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *DiaryController) DeleteEntry(c *gin.Context) {
+	id := c.Param("entryId")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.diaryUsecase.DeleteEntry(id, userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *DiaryController) GetDiary(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.diaryUsecase.GetDiary(userID.(string), c.Query("from"), c.Query("to"), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+// ExportDiary downloads the diary as CSV (default) or JSON.
+func (ctrl *DiaryController) ExportDiary(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		c.Error(domain.Validation(domain.CodeUnsupportedFormat, "format must be csv or json"))
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	rows, err := ctrl.diaryUsecase.ExportDiary(userID.(string), c.Query("from"), c.Query("to"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="diary.`+format+`"`)
+
+	if format == "json" {
+		writeJSON(c, http.StatusOK, rows)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"Date", "Title", "MovieID", "Rewatch", "Rating"})
+	for _, row := range rows {
+		rating := ""
+		if row.Rating > 0 {
+			rating = strconv.FormatFloat(row.Rating, 'f', 1, 64)
+		}
+		writer.Write([]string{row.WatchedOn, row.Title, row.MovieID, strconv.FormatBool(row.Rewatch), rating})
+	}
+	writer.Flush()
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ExportController struct {
+	exportUsecase usecase.ExportUsecase
+}
+
+func NewExportController(exportUsecase usecase.ExportUsecase) *ExportController {
+	return &ExportController{exportUsecase: exportUsecase}
+}
+
+// exportContentTypes maps each export format to its content type and file
+// extension. CSV exports of more than the movies are zipped instead.
+var exportContentTypes = map[string][2]string{
+	domain.ExportFormatCSV:    {"text/csv", "csv"},
+	domain.ExportFormatJSON:   {"application/json", "json"},
+	domain.ExportFormatNDJSON: {"application/x-ndjson", "ndjson"},
+}
+
+// Export streams the caller's movies, and optionally reviews, lists and
+// diary, as CSV, JSON or NDJSON.
+func (ctrl *ExportController) Export(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	req := &domain.ExportRequest{
+		Format:  c.DefaultQuery("format", domain.ExportFormatCSV),
+		Include: splitQueryList(c.Query("include")),
+		UserID:  userID.(string),
+	}
+	if err := ctrl.exportUsecase.ValidateExport(req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	contentType := exportContentTypes[req.Format]
+	if req.Zipped() {
+		contentType = [2]string{"application/zip", "zip"}
+	}
+	c.Header("Content-Type", contentType[0])
+	c.Header("Content-Disposition", `attachment; filename="export.`+contentType[1]+`"`)
+	c.Status(http.StatusOK)
+
+	// Headers are already sent, so a failure here can only cut the body
+	// short; the truncated file will not parse on the client.
+	if err := ctrl.exportUsecase.Export(c.Request.Context(), req, c.Writer); err != nil {
+		log.Printf("export for user %s failed: %v", req.UserID, err)
+		c.Abort()
+	}
+}
+
+// splitQueryList splits a comma-separated query parameter, dropping blanks.
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
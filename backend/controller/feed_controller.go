@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type FeedController struct {
+	feedUsecase usecase.FeedUsecase
+}
+
+func NewFeedController(feedUsecase usecase.FeedUsecase) *FeedController {
+	return &FeedController{feedUsecase: feedUsecase}
+}
+
+func (ctrl *FeedController) GetFeed(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.feedUsecase.GetFeed(userID.(string), c.Query("cursor"), limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
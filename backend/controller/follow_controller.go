@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type FollowController struct {
+	followUsecase usecase.FollowUsecase
+}
+
+func NewFollowController(followUsecase usecase.FollowUsecase) *FollowController {
+	return &FollowController{followUsecase: followUsecase}
+}
+
+func (ctrl *FollowController) Follow(c *gin.Context) {
+	followeeID := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.followUsecase.Follow(followeeID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *FollowController) Unfollow(c *gin.Context) {
+	followeeID := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.followUsecase.Unfollow(followeeID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *FollowController) GetFollowers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	response, err := ctrl.followUsecase.GetFollowers(ctrl.targetUserID(c), page, size)
+	ctrl.respondPage(c, response, err)
+}
+
+func (ctrl *FollowController) GetFollowing(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	response, err := ctrl.followUsecase.GetFollowing(ctrl.targetUserID(c), page, size)
+	ctrl.respondPage(c, response, err)
+}
+
+// targetUserID resolves the :id path parameter, where "me" means the caller.
+func (ctrl *FollowController) targetUserID(c *gin.Context) string {
+	id := c.Param("id")
+	if id == "" || id == "me" {
+		userID, _ := c.Get("userID")
+		return userID.(string)
+	}
+	return id
+}
+
+func (ctrl *FollowController) respondPage(c *gin.Context, response *domain.PaginatedResponse, err error) {
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/graph"
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLController struct {
+	schema      *graph.Schema
+	maxBodySize int64
+}
+
+// NewGraphQLController refuses queries over maxBodySize bytes, as the API
+// validator does for the rest of the API; zero leaves them unbounded.
+func NewGraphQLController(schema *graph.Schema, maxBodySize int64) *GraphQLController {
+	return &GraphQLController{schema: schema, maxBodySize: maxBodySize}
+}
+
+// Query runs a GraphQL query for the signed-in user. Errors in the query
+// and from its fields are part of the result, as GraphQL clients expect,
+// so the response is 200 OK unless the request itself is not one.
+func (ctrl *GraphQLController) Query(c *gin.Context) {
+	if ctrl.maxBodySize > 0 && c.Request.Body != nil {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, ctrl.maxBodySize)
+	}
+	var req domain.GraphQLRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	viewer := graph.Viewer{
+		UserID:  c.GetString("userID"),
+		Locales: c.GetStringSlice("locales"),
+		TraceID: c.GetString("traceID"),
+	}
+	c.JSON(http.StatusOK, ctrl.schema.Execute(c.Request.Context(), viewer, &req))
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+func TestGraphQLQueryBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	query := `{"query": "{ movies { items { id title } } }"}`
+	c.Request = httptest.NewRequest("POST", "/graphql", strings.NewReader(query))
+
+	NewGraphQLController(nil, int64(len(query)-1)).Query(c)
+
+	var err *domain.Error
+	if len(c.Errors) != 1 || !errors.As(c.Errors[0].Err, &err) || err.Kind != domain.ErrTooLarge || err.Code != domain.CodeRequestTooLarge {
+		t.Fatalf("errors = %v, want one %s error", c.Errors, domain.CodeRequestTooLarge)
+	}
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportSize caps the size of an uploaded import file.
+const maxImportSize = 20 << 20
+
+// uploadOverhead allows for the multipart headers and other form fields
+// sent with an uploaded file.
+const uploadOverhead = 1 << 20
+
+type ImportController struct {
+	importUsecase usecase.ImportUsecase
+}
+
+func NewImportController(importUsecase usecase.ImportUsecase) *ImportController {
+	return &ImportController{importUsecase: importUsecase}
+}
+
+// StartImport takes a multipart upload with the file in "file" and the
+// optional form fields "format", "mapping" (a JSON object) and "dryRun".
+// The format defaults to the file's extension.
+func (ctrl *ImportController) StartImport(c *gin.Context) {
+	data, filename, ok := readUpload(c, "file", maxImportSize)
+	if !ok {
+		return
+	}
+
+	req := domain.ImportMoviesRequest{
+		Format: strings.ToLower(c.PostForm("format")),
+		Data:   data,
+	}
+	if req.Format == "" {
+		req.Format = importFormatFromName(filename)
+	}
+	if mapping := c.PostForm("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &req.Mapping); err != nil {
+			c.Error(domain.Validation(domain.CodeInvalidMapping, "Invalid mapping", usecase.ValidationDetails(err)...))
+			return
+		}
+	}
+	req.DryRun, _ = strconv.ParseBool(c.DefaultPostForm("dryRun", "false"))
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.importUsecase.StartImport(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusAccepted, response)
+}
+
+// ImportLetterboxd takes a Letterboxd export ZIP in "file", with an
+// optional "dryRun" form field.
+func (ctrl *ImportController) ImportLetterboxd(c *gin.Context) {
+	ctrl.startExternalImport(c, domain.ImportFormatLetterboxd)
+}
+
+// ImportIMDb takes an IMDb ratings or watchlist CSV export in "file", with
+// an optional "dryRun" form field.
+func (ctrl *ImportController) ImportIMDb(c *gin.Context) {
+	ctrl.startExternalImport(c, domain.ImportFormatIMDb)
+}
+
+func (ctrl *ImportController) startExternalImport(c *gin.Context, format string) {
+	data, _, ok := readUpload(c, "file", maxImportSize)
+	if !ok {
+		return
+	}
+
+	req := domain.ImportMoviesRequest{
+		Format: format,
+		Data:   data,
+	}
+	req.DryRun, _ = strconv.ParseBool(c.DefaultPostForm("dryRun", "false"))
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.importUsecase.StartImport(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusAccepted, response)
+}
+
+func (ctrl *ImportController) GetImportJob(c *gin.Context) {
+	id := c.Param("jobId")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.importUsecase.GetImportJob(id, userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+// readUpload reads a multipart file field, recording an error and
+// returning false when it is missing or larger than maxSize bytes. The
+// body is cut off once it could no longer fit such a file, so that an
+// oversized upload is not spooled in full before it is refused.
+func readUpload(c *gin.Context, field string, maxSize int64) ([]byte, string, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize+uploadOverhead)
+	header, err := c.FormFile(field)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(domain.TooLarge(domain.CodeFileTooLarge, "File is too large"))
+			return nil, "", false
+		}
+		c.Error(domain.Validation(domain.CodeFileRequired, "A file is required", domain.ErrorDetails(err)...))
+		return nil, "", false
+	}
+	if header.Size > maxSize {
+		c.Error(domain.TooLarge(domain.CodeFileTooLarge, "File is too large"))
+		return nil, "", false
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.Error(domain.Validation(domain.CodeFileUnreadable, "Could not read the file", domain.ErrorDetails(err)...))
+		return nil, "", false
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize))
+	if err != nil {
+		c.Error(domain.Validation(domain.CodeFileUnreadable, "Could not read the file", domain.ErrorDetails(err)...))
+		return nil, "", false
+	}
+	return data, header.Filename, true
+}
+
+func importFormatFromName(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return domain.ImportFormatCSV
+	case ".json":
+		return domain.ImportFormatJSON
+	case ".ndjson", ".jsonl":
+		return domain.ImportFormatNDJSON
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type LikeController struct {
+	likeUsecase usecase.LikeUsecase
+}
+
+func NewLikeController(likeUsecase usecase.LikeUsecase) *LikeController {
+	return &LikeController{likeUsecase: likeUsecase}
+}
+
+func (ctrl *LikeController) LikeMovie(c *gin.Context) {
+	movieID := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.likeUsecase.LikeMovie(movieID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *LikeController) UnlikeMovie(c *gin.Context) {
+	movieID := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.likeUsecase.UnlikeMovie(movieID, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *LikeController) GetLikedMovies(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.likeUsecase.GetLikedMovies(userID.(string), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
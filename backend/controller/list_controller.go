@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ListController struct {
+	listUsecase usecase.ListUsecase
+}
+
+func NewListController(listUsecase usecase.ListUsecase) *ListController {
+	return &ListController{listUsecase: listUsecase}
+}
+
+func (ctrl *ListController) CreateList(c *gin.Context) {
+	var req domain.CreateListRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.listUsecase.CreateList(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *ListController) GetMyLists(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.GetMyLists(userID.(string), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *ListController) GetList(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.GetList(id, userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+// GetSharedList serves the read-only view behind a list's share link. It
+// does not require authentication.
+func (ctrl *ListController) GetSharedList(c *gin.Context) {
+	token := c.Param("token")
+
+	response, err := ctrl.listUsecase.GetSharedList(token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *ListController) UpdateList(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.UpdateListRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.UpdateList(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *ListController) DeleteList(c *gin.Context) {
+	id := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.DeleteList(id, userID.(string))
+	respond(c, response, err)
+}
+
+func (ctrl *ListController) AddEntries(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.AddListEntriesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.AddEntries(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *ListController) RemoveEntries(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.RemoveListEntriesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.RemoveEntries(id, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *ListController) UpdateEntry(c *gin.Context) {
+	id := c.Param("id")
+	movieID := c.Param("movieId")
+
+	var req domain.UpdateListEntryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.UpdateEntry(id, movieID, userID.(string), &req)
+	respond(c, response, err)
+}
+
+func (ctrl *ListController) ReorderList(c *gin.Context) {
+	id := c.Param("id")
+
+	var req domain.ReorderListRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.listUsecase.ReorderList(id, userID.(string), &req)
+	respond(c, response, err)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"reflect"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+var movieType = reflect.TypeOf(domain.Movie{})
+
+// writeJSON writes a response localized for the request.
+func writeJSON(c *gin.Context, status int, response interface{}) {
+	c.JSON(status, localize(c, response))
+}
+
+// localize rewrites every movie in a response for the locales the request
+// asks for, wherever the movie sits: a single movie, a page of them or a
+// movie inside a list, feed item or recommendation. A response passed by
+// value is localized as a copy, which is returned.
+func localize(c *gin.Context, response interface{}) interface{} {
+	chain := c.GetStringSlice("locales")
+	if len(chain) == 0 || response == nil {
+		return response
+	}
+
+	v := reflect.ValueOf(response)
+	if v.Kind() != reflect.Pointer {
+		copied := reflect.New(v.Type())
+		copied.Elem().Set(v)
+		localizeValue(copied, chain)
+		return copied.Elem().Interface()
+	}
+	localizeValue(v, chain)
+	return response
+}
+
+func localizeValue(v reflect.Value, chain []string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			localizeValue(v.Elem(), chain)
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Pointer || !v.CanSet() {
+			localizeValue(elem, chain)
+			return
+		}
+		// Values held in an interface cannot be changed in place
+		copied := reflect.New(elem.Type()).Elem()
+		copied.Set(elem)
+		localizeValue(copied, chain)
+		v.Set(copied)
+	case reflect.Struct:
+		if v.Type() == movieType {
+			if v.CanAddr() {
+				v.Addr().Interface().(*domain.Movie).Localize(chain)
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				localizeValue(v.Field(i), chain)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			localizeValue(v.Index(i), chain)
+		}
+	}
+}
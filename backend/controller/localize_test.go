@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+func frenchContext() *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set("locales", []string{"fr", "en"})
+	return c
+}
+
+func bilingualMovie() domain.Movie {
+	return domain.Movie{Title: "The Lion King", Translations: []domain.MovieTranslation{{Locale: "fr", Title: "Le Roi lion"}}}
+}
+
+func TestLocalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func() interface{}
+		titles   func(response interface{}) []string
+	}{
+		{
+			name: "a movie in a response",
+			response: func() interface{} {
+				movie := bilingualMovie()
+				return &domain.BaseResponse{Object: &movie}
+			},
+			titles: func(response interface{}) []string {
+				return []string{response.(*domain.BaseResponse).Object.(*domain.Movie).Title}
+			},
+		},
+		{
+			name: "a page of movies held by value",
+			response: func() interface{} {
+				return &domain.BaseResponse{Object: []domain.Movie{bilingualMovie(), bilingualMovie()}}
+			},
+			titles: func(response interface{}) []string {
+				movies := response.(*domain.BaseResponse).Object.([]domain.Movie)
+				return []string{movies[0].Title, movies[1].Title}
+			},
+		},
+		{
+			name: "movies nested in other results",
+			response: func() interface{} {
+				return &domain.BaseResponse{Object: []domain.Recommendation{{Movie: bilingualMovie()}}}
+			},
+			titles: func(response interface{}) []string {
+				return []string{response.(*domain.BaseResponse).Object.([]domain.Recommendation)[0].Movie.Title}
+			},
+		},
+		{
+			name: "a response passed by value is localized as a copy",
+			response: func() interface{} {
+				return domain.ScoredMovie{Movie: bilingualMovie()}
+			},
+			titles: func(response interface{}) []string {
+				return []string{response.(domain.ScoredMovie).Movie.Title}
+			},
+		},
+		{
+			name: "a movie inside an interface value",
+			response: func() interface{} {
+				return &domain.BaseResponse{Object: domain.ScoredMovie{Movie: bilingualMovie()}}
+			},
+			titles: func(response interface{}) []string {
+				return []string{response.(*domain.BaseResponse).Object.(domain.ScoredMovie).Movie.Title}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localized := localize(frenchContext(), tt.response())
+			for i, title := range tt.titles(localized) {
+				if title != "Le Roi lion" {
+					t.Errorf("movie %d title = %q, want the French title", i, title)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalizeWithoutLocales(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	movie := bilingualMovie()
+
+	localize(c, &movie)
+	if movie.Title != "The Lion King" || movie.Locale != "" {
+		t.Errorf("movie = %q: %q, want it untouched", movie.Locale, movie.Title)
+	}
+	if localize(c, nil) != nil {
+		t.Error("localize(nil) is not nil")
+	}
+}
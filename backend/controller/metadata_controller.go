@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type MetadataController struct {
+	metadataUsecase usecase.MetadataUsecase
+}
+
+func NewMetadataController(metadataUsecase usecase.MetadataUsecase) *MetadataController {
+	return &MetadataController{metadataUsecase: metadataUsecase}
+}
+
+// LookupMetadata takes either imdbId, or title with an optional year.
+func (ctrl *MetadataController) LookupMetadata(c *gin.Context) {
+	year := 0
+	if value := c.Query("year"); value != "" {
+		var err error
+		if year, err = strconv.Atoi(value); err != nil {
+			c.Error(domain.Validation(domain.CodeInvalidLookup, "Invalid lookup", domain.NewErrorDetail(domain.CodeFieldNotNumber, "year must be a whole number", "field", "year")))
+			return
+		}
+	}
+
+	response, err := ctrl.metadataUsecase.LookupMetadata(c.Query("title"), year, c.Query("imdbId"))
+	respond(c, response, err)
+}
+
+// RefreshMetadata takes an optional body; without one, missing details
+// are filled in and nothing is overwritten.
+func (ctrl *MetadataController) RefreshMetadata(c *gin.Context) {
+	var req domain.RefreshMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(domain.Validation(domain.CodeInvalidRequestBody, "Invalid request body", usecase.ValidationDetails(err)...))
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.metadataUsecase.RefreshMetadata(c.Param("id"), userID.(string), &req)
+	respond(c, response, err)
+}
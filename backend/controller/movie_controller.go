@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -19,12 +20,7 @@ func NewMovieController(movieUsecase usecase.MovieUsecase) *MovieController {
 
 func (ctrl *MovieController) CreateMovie(c *gin.Context) {
 	var req domain.CreateMovieRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, domain.BaseResponse{
-			Success: false,
-			Message: "Invalid request body",
-			Errors:  []string{err.Error()},
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -33,30 +29,24 @@ func (ctrl *MovieController) CreateMovie(c *gin.Context) {
 
 	response, err := ctrl.movieUsecase.CreateMovie(&req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.BaseResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, response)
+	writeJSON(c, http.StatusCreated, response)
 }
 
 func (ctrl *MovieController) GetMovies(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
 
-	response, err := ctrl.movieUsecase.GetMovies(page, size)
+	response, err := ctrl.movieUsecase.GetMovies(page, size, c.Query("sort"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.PaginatedResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeJSON(c, http.StatusOK, response)
 }
 
 func (ctrl *MovieController) SearchMovies(c *gin.Context) {
@@ -66,41 +56,41 @@ func (ctrl *MovieController) SearchMovies(c *gin.Context) {
 
 	response, err := ctrl.movieUsecase.SearchMovies(title, page, size)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.PaginatedResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeJSON(c, http.StatusOK, response)
 }
 
 func (ctrl *MovieController) GetMovieByID(c *gin.Context) {
 	id := c.Param("id")
 
 	response, err := ctrl.movieUsecase.GetMovieByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Movies merged into another redirect to the movie they became
+		if target, redirectErr := ctrl.movieUsecase.ResolveRedirect(id); redirectErr == nil && target != "" {
+			location := "/api/v1/movies/" + target
+			if query := c.Request.URL.RawQuery; query != "" {
+				location += "?" + query
+			}
+			c.Redirect(http.StatusMovedPermanently, location)
+			return
+		}
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, domain.BaseResponse{
-			Success: false,
-			Message: "Movie not found",
-		})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeJSON(c, http.StatusOK, response)
 }
 
 func (ctrl *MovieController) UpdateMovie(c *gin.Context) {
 	id := c.Param("id")
 	
 	var req domain.UpdateMovieRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, domain.BaseResponse{
-			Success: false,
-			Message: "Invalid request body",
-			Errors:  []string{err.Error()},
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
@@ -108,19 +98,11 @@ func (ctrl *MovieController) UpdateMovie(c *gin.Context) {
 
 	response, err := ctrl.movieUsecase.UpdateMovie(id, userID.(string), &req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.BaseResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
-		return
-	}
-
-	if !response.Success {
-		c.JSON(http.StatusForbidden, response)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeJSON(c, http.StatusOK, response)
 }
 
 func (ctrl *MovieController) DeleteMovie(c *gin.Context) {
@@ -130,17 +112,9 @@ func (ctrl *MovieController) DeleteMovie(c *gin.Context) {
 
 	response, err := ctrl.movieUsecase.DeleteMovie(id, userID.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.BaseResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
-		return
-	}
-
-	if !response.Success {
-		c.JSON(http.StatusForbidden, response)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	writeJSON(c, http.StatusOK, response)
 }
\ No newline at end of file
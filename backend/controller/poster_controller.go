@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/storage"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type PosterController struct {
+	posterUsecase usecase.PosterUsecase
+	maxSize       int64
+}
+
+func NewPosterController(posterUsecase usecase.PosterUsecase, maxSize int64) *PosterController {
+	return &PosterController{
+		posterUsecase: posterUsecase,
+		maxSize:       maxSize,
+	}
+}
+
+// UploadPoster takes a multipart upload with the image in "poster".
+func (ctrl *PosterController) UploadPoster(c *gin.Context) {
+	data, _, ok := readUpload(c, "poster", ctrl.maxSize)
+	if !ok {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.posterUsecase.UploadPoster(c.Param("id"), userID.(string), data)
+	respond(c, response, err)
+}
+
+// GetPoster serves a poster image. Poster URLs change with every upload,
+// so clients may cache them for good.
+func (ctrl *PosterController) GetPoster(c *gin.Context) {
+	dir, file := c.Param("dir"), c.Param("file")
+	etag := `"` + dir + "/" + file + `"`
+
+	blob, err := ctrl.posterUsecase.OpenPoster(dir, file)
+	if err != nil {
+		if errors.Is(err, storage.ErrBlobNotFound) {
+			c.Error(domain.NotFound(domain.CodePosterNotFound, "Poster not found"))
+			return
+		}
+		c.Error(err)
+		return
+	}
+	defer blob.Body.Close()
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", etag)
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, blob.Size, blob.ContentType, blob.Body, nil)
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationController struct {
+	recommendationUsecase usecase.RecommendationUsecase
+}
+
+func NewRecommendationController(recommendationUsecase usecase.RecommendationUsecase) *RecommendationController {
+	return &RecommendationController{recommendationUsecase: recommendationUsecase}
+}
+
+func (ctrl *RecommendationController) GetSimilarMovies(c *gin.Context) {
+	id := c.Param("id")
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	response, err := ctrl.recommendationUsecase.GetSimilarMovies(id, limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *RecommendationController) GetRecommendations(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.recommendationUsecase.GetRecommendations(userID.(string), limit)
+	respond(c, response, err)
+}
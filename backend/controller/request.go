@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON decodes the request body into req, then normalizes and
+// validates it. It records an error and returns false when the body is
+// not valid JSON or breaks any of req's binding rules; every failed rule
+// is reported at once. A body cut off by http.MaxBytesReader is reported
+// as too large.
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := decodeJSON(c, req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(domain.TooLarge(domain.CodeRequestTooLarge, "Request body is too large"))
+			return false
+		}
+		c.Error(domain.Validation(domain.CodeInvalidRequestBody, "Invalid request body", usecase.ValidationDetails(err)...))
+		return false
+	}
+	if err := usecase.ValidateRequest(req); err != nil {
+		c.Error(err)
+		return false
+	}
+	return true
+}
+
+func decodeJSON(c *gin.Context, req interface{}) error {
+	if c.Request.Body == nil {
+		return io.EOF
+	}
+	return json.NewDecoder(c.Request.Body).Decode(req)
+}
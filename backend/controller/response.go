@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// respond writes the result of a usecase call with status 200, or leaves
+// its error for ErrorMiddleware to render. Movies in the response are
+// localized.
+func respond(c *gin.Context, response *domain.BaseResponse, err error) {
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+// NoRoute answers requests for paths no route matches.
+func NoRoute(c *gin.Context) {
+	c.Error(domain.NotFound(domain.CodeRouteNotFound, "No resource at this path"))
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewController struct {
+	reviewUsecase usecase.ReviewUsecase
+}
+
+func NewReviewController(reviewUsecase usecase.ReviewUsecase) *ReviewController {
+	return &ReviewController{reviewUsecase: reviewUsecase}
+}
+
+func (ctrl *ReviewController) CreateReview(c *gin.Context) {
+	var req domain.CreateReviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+	req.MovieID = c.Param("id")
+
+	response, err := ctrl.reviewUsecase.CreateReview(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *ReviewController) GetMovieReviews(c *gin.Context) {
+	movieID := c.Param("id")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	response, err := ctrl.reviewUsecase.GetMovieReviews(movieID, page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *ReviewController) UpdateReview(c *gin.Context) {
+	movieID := c.Param("id")
+
+	var req domain.UpdateReviewRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.reviewUsecase.UpdateReview(movieID, userID.(string), &req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *ReviewController) DeleteReview(c *gin.Context) {
+	movieID := c.Param("id")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.reviewUsecase.DeleteReview(movieID, userID.(string))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
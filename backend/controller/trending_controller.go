@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type TrendingController struct {
+	trendingUsecase usecase.TrendingUsecase
+}
+
+func NewTrendingController(trendingUsecase usecase.TrendingUsecase) *TrendingController {
+	return &TrendingController{trendingUsecase: trendingUsecase}
+}
+
+func (ctrl *TrendingController) GetTrending(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+
+	response, err := ctrl.trendingUsecase.GetTrending(c.Query("window"), limit)
+	respond(c, response, err)
+}
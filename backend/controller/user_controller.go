@@ -18,56 +18,30 @@ func NewUserController(userUsecase usecase.UserUsecase) *UserController {
 
 func (ctrl *UserController) Signup(c *gin.Context) {
 	var req domain.SignupRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, domain.AuthResponse{
-			Success: false,
-			Message: "Invalid request body",
-			Errors:  []string{err.Error()},
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	response, err := ctrl.userUsecase.Signup(&req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.AuthResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
+		c.Error(err)
 		return
 	}
 
-	status := http.StatusOK
-	if !response.Success {
-		status = http.StatusBadRequest
-	}
-
-	c.JSON(status, response)
+	writeJSON(c, http.StatusOK, response)
 }
 
 func (ctrl *UserController) Login(c *gin.Context) {
 	var req domain.LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, domain.AuthResponse{
-			Success: false,
-			Message: "Invalid request body",
-			Errors:  []string{err.Error()},
-		})
+	if !bindJSON(c, &req) {
 		return
 	}
 
 	response, err := ctrl.userUsecase.Login(&req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, domain.AuthResponse{
-			Success: false,
-			Message: "Internal server error",
-		})
+		c.Error(err)
 		return
 	}
 
-	status := http.StatusOK
-	if !response.Success {
-		status = http.StatusUnauthorized
-	}
-
-	c.JSON(status, response)
+	writeJSON(c, http.StatusOK, response)
 }
\ No newline at end of file
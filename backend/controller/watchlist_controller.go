@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+type WatchlistController struct {
+	watchlistUsecase usecase.WatchlistUsecase
+}
+
+func NewWatchlistController(watchlistUsecase usecase.WatchlistUsecase) *WatchlistController {
+	return &WatchlistController{watchlistUsecase: watchlistUsecase}
+}
+
+func (ctrl *WatchlistController) AddToWatchlist(c *gin.Context) {
+	var req domain.AddToWatchlistRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	userID, _ := c.Get("userID")
+	req.UserID = userID.(string)
+
+	response, err := ctrl.watchlistUsecase.AddToWatchlist(&req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusCreated, response)
+}
+
+func (ctrl *WatchlistController) RemoveFromWatchlist(c *gin.Context) {
+	movieID := c.Param("movieId")
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.watchlistUsecase.RemoveFromWatchlist(userID.(string), movieID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
+
+func (ctrl *WatchlistController) GetWatchlist(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
+
+	userID, _ := c.Get("userID")
+
+	response, err := ctrl.watchlistUsecase.GetWatchlist(userID.(string), c.Query("from"), c.Query("to"), page, size)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeJSON(c, http.StatusOK, response)
+}
@@ -1,11 +1,11 @@
 package domain
 
-// BaseResponse is the standard response format
+// BaseResponse is the standard response format. Failed requests are
+// answered with a Problem instead.
 type BaseResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
 	Object  interface{} `json:"object,omitempty"`
-	Errors  []string    `json:"errors,omitempty"`
 }
 
 // PaginatedResponse is for paginated lists
@@ -16,44 +16,319 @@ type PaginatedResponse struct {
 	PageNumber int         `json:"pageNumber"`
 	PageSize   int         `json:"pageSize"`
 	TotalSize  int64       `json:"totalSize"`
-	Errors     []string    `json:"errors,omitempty"`
+}
+
+// MaxPageSize is the most items a paged list returns at once.
+const MaxPageSize = 100
+
+// CursorResponse is for lists paged by an opaque cursor. NextCursor is
+// empty on the last page.
+type CursorResponse struct {
+	Success    bool        `json:"success"`
+	Message    string      `json:"message"`
+	Object     interface{} `json:"object,omitempty"`
+	NextCursor string      `json:"nextCursor,omitempty"`
 }
 
 // AuthResponse for authentication endpoints
 type AuthResponse struct {
-	Success bool     `json:"success"`
-	Message string   `json:"message"`
-	Token   string   `json:"token,omitempty"`
-	Errors  []string `json:"errors,omitempty"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Token   string `json:"token,omitempty"`
 }
 
 // Request DTOs
 type SignupRequest struct {
-	Username string `json:"username" binding:"required"`
-	Email    string `json:"email" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" binding:"required,max=30,alphanum"`
+	Email    string `json:"email" binding:"required,max=254,email"`
+	Password string `json:"password" binding:"required,min=8,max=72,hasupper,haslower,hasspecial" normalize:"-"`
 }
 
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" binding:"required,max=254"`
+	Password string `json:"password" binding:"required" normalize:"-"`
 }
 
 type CreateMovieRequest struct {
-	Title       string   `json:"title" binding:"required"`
-	Description string   `json:"description" binding:"required"`
-	Poster      string   `json:"poster" binding:"required"`
-	Trailer     string   `json:"trailer" binding:"required"`
-	Actors      []string `json:"actors" binding:"required"`
-	Genres      []string `json:"genres" binding:"required"`
-	UserID      string   `json:"-"`
+	Title       string   `json:"title" binding:"required,max=200"`
+	Description string   `json:"description" binding:"required,max=5000"`
+	Trailer     string   `json:"trailer" binding:"required,max=2048,url"`
+	Actors      []string `json:"actors" binding:"required,max=100,unique,dive,required,max=100"`
+	Genres      []string `json:"genres" binding:"required,max=20,unique,dive,required,max=50"`
+	Crew        []string `json:"crew" binding:"max=100,unique,dive,required,max=100"`
+	Year        int      `json:"year" binding:"omitempty,gte=1870,lte=2100"`
+	IMDbID      string   `json:"imdbId" binding:"max=20"`
+	// CollectionID optionally files the movie in a shared collection
+	CollectionID string `json:"collectionId" binding:"omitempty,mongodb"`
+	UserID       string `json:"-"`
+
+	// Locale is the language of title and description; empty means the
+	// default locale. Translations give them in other supported locales.
+	Locale       string             `json:"locale" binding:"max=35"`
+	Translations []MovieTranslation `json:"translations" binding:"omitempty,max=20,unique=Locale,dive"`
 }
 
 type UpdateMovieRequest struct {
-	Title       string   `json:"title" binding:"required"`
-	Description string   `json:"description" binding:"required"`
-	Poster      string   `json:"poster" binding:"required"`
-	Trailer     string   `json:"trailer" binding:"required"`
-	Actors      []string `json:"actors" binding:"required"`
-	Genres      []string `json:"genres" binding:"required"`
-}
\ No newline at end of file
+	Title       string   `json:"title" binding:"required,max=200"`
+	Description string   `json:"description" binding:"required,max=5000"`
+	Trailer     string   `json:"trailer" binding:"required,max=2048,url"`
+	Actors      []string `json:"actors" binding:"required,max=100,unique,dive,required,max=100"`
+	Genres      []string `json:"genres" binding:"required,max=20,unique,dive,required,max=50"`
+	Crew        []string `json:"crew" binding:"max=100,unique,dive,required,max=100"`
+	Year        int      `json:"year" binding:"omitempty,gte=1870,lte=2100"`
+	IMDbID      string   `json:"imdbId" binding:"max=20"`
+	// CollectionID moves the movie into a shared collection; empty keeps the current one
+	CollectionID string `json:"collectionId" binding:"omitempty,mongodb"`
+	// LeaveCollection takes the movie out of its collection
+	LeaveCollection bool `json:"leaveCollection"`
+
+	// Locale is the language of title and description; empty keeps the
+	// current one. Translations, when given, replace the movie's.
+	Locale       string             `json:"locale" binding:"max=35"`
+	Translations []MovieTranslation `json:"translations" binding:"omitempty,max=20,unique=Locale,dive"`
+}
+
+type CreateReviewRequest struct {
+	Rating  float64 `json:"rating" binding:"required,gte=0.5,lte=5,halfstep"`
+	Text    string  `json:"text" binding:"max=5000"`
+	Spoiler bool    `json:"spoiler"`
+	UserID  string  `json:"-"`
+	MovieID string  `json:"-"`
+}
+
+type UpdateReviewRequest struct {
+	Rating  float64 `json:"rating" binding:"required,gte=0.5,lte=5,halfstep"`
+	Text    string  `json:"text" binding:"max=5000"`
+	Spoiler bool    `json:"spoiler"`
+}
+
+type AddToWatchlistRequest struct {
+	MovieID string `json:"movieId" binding:"required,mongodb"`
+	UserID  string `json:"-"`
+}
+
+// LogViewingRequest adds a diary entry. WatchedOn is a YYYY-MM-DD date and
+// defaults to today; Rating is optional.
+type LogViewingRequest struct {
+	MovieID   string   `json:"movieId" binding:"required,mongodb"`
+	WatchedOn string   `json:"watchedOn"`
+	Rewatch   bool     `json:"rewatch"`
+	Rating    *float64 `json:"rating" binding:"omitempty,gte=0.5,lte=5,halfstep"`
+	UserID    string   `json:"-"`
+}
+
+// DiaryExportRow is one line of a diary export.
+type DiaryExportRow struct {
+	WatchedOn string  `json:"watchedOn"`
+	MovieID   string  `json:"movieId"`
+	Title     string  `json:"title"`
+	Rewatch   bool    `json:"rewatch"`
+	Rating    float64 `json:"rating,omitempty"`
+}
+
+type CreateListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+	UserID      string `json:"-"`
+}
+
+type UpdateListRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	Visibility  string `json:"visibility" binding:"required,oneof=private unlisted public"`
+}
+
+type ListEntryInput struct {
+	MovieID string `json:"movieId" binding:"required,mongodb"`
+	Note    string `json:"note" binding:"max=500"`
+}
+
+type AddListEntriesRequest struct {
+	Entries []ListEntryInput `json:"entries" binding:"required,min=1,max=500,unique=MovieID,dive"`
+}
+
+type RemoveListEntriesRequest struct {
+	MovieIDs []string `json:"movieIds" binding:"required,min=1,max=500,unique,dive,mongodb"`
+}
+
+type UpdateListEntryRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// ReorderListRequest gives the complete new order of a list's movies.
+type ReorderListRequest struct {
+	MovieIDs []string `json:"movieIds" binding:"required,unique,dive,mongodb"`
+}
+
+// ListDetailsResponse is a list together with the movies it references, in list order.
+type ListDetailsResponse struct {
+	*MovieList
+	Movies []Movie `json:"movies"`
+}
+
+type CreateCollectionRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+	UserID      string `json:"-"`
+}
+
+type UpdateCollectionRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+type InviteMemberRequest struct {
+	Username string `json:"username" binding:"required,max=30"`
+	Role     string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type CreateInviteLinkRequest struct {
+	Role string `json:"role" binding:"required,oneof=editor viewer"`
+}
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" binding:"required,max=2000"`
+	ParentID string `json:"parentId" binding:"omitempty,mongodb"`
+	UserID   string `json:"-"`
+	MovieID  string `json:"-"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+// UserSummary is the public view of a user shown to other users.
+type UserSummary struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// FeedItem is an activity with the user and movie it refers to.
+type FeedItem struct {
+	Activity
+	User  UserSummary `json:"user"`
+	Movie *Movie      `json:"movie"`
+}
+
+// ScoredMovie is a recommended movie with the score that ranked it.
+type ScoredMovie struct {
+	Movie Movie   `json:"movie"`
+	Score float64 `json:"score"`
+}
+
+// MovieSummary is a short reference to a movie.
+type MovieSummary struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// Recommendation is a suggested movie. For collaborative recommendations
+// Score is the predicted rating; for content-based ones it is the summed
+// similarity to the movies in BecauseYouLiked.
+type Recommendation struct {
+	Movie           Movie          `json:"movie"`
+	Score           float64        `json:"score"`
+	Source          string         `json:"source"`
+	Explanation     string         `json:"explanation"`
+	BecauseYouLiked []MovieSummary `json:"becauseYouLiked"`
+}
+
+// ImportMoviesRequest is a bulk movie import, or with Format set to
+// letterboxd or imdb, an import of a user's export from that service.
+// Mapping renames fields: it maps a movie field such as "title" to the
+// column or key holding it in the file. Fields without a mapping are
+// looked up by their own name.
+type ImportMoviesRequest struct {
+	Format  string            `binding:"required"`
+	Mapping map[string]string `binding:"max=20,dive,keys,required,endkeys,required,max=100"`
+	DryRun  bool
+	Data    []byte
+	UserID  string
+}
+
+// ExportRequest asks for a download of a user's data. Movies are always
+// included; Include can add "reviews", "lists" and "diary".
+type ExportRequest struct {
+	Format  string   `binding:"oneof=csv json ndjson"`
+	Include []string `binding:"max=3,unique,dive,oneof=reviews lists diary"`
+	UserID  string
+}
+
+// Zipped reports whether the export is a ZIP of one CSV per section, as a
+// CSV export of more than the movies is. A CSV export of the movies alone
+// is a single CSV file.
+func (r *ExportRequest) Zipped() bool {
+	return r.Format == ExportFormatCSV && len(r.Include) > 0
+}
+
+// ReviewExport is a review with its movie's title, as exported.
+type ReviewExport struct {
+	Review     `bson:",inline"`
+	MovieTitle string `bson:"movieTitle" json:"movieTitle"`
+}
+
+// DiaryEntryExport is a diary entry with its movie's title, as exported.
+type DiaryEntryExport struct {
+	DiaryEntry `bson:",inline"`
+	MovieTitle string `bson:"movieTitle" json:"movieTitle"`
+}
+
+// DuplicateGroup is a set of movies that look like the same film. Score
+// (0–1) is how confident the match is; CanonicalID suggests which movie to
+// keep, the one with the most reviews and likes.
+type DuplicateGroup struct {
+	Score       float64 `json:"score"`
+	CanonicalID string  `json:"canonicalId"`
+	Movies      []Movie `json:"movies"`
+}
+
+// MergeMoviesRequest merges duplicate movies into a canonical one.
+type MergeMoviesRequest struct {
+	CanonicalID  string   `json:"canonicalId" binding:"required,mongodb"`
+	DuplicateIDs []string `json:"duplicateIds" binding:"required,min=1,max=50,unique,dive,mongodb"`
+}
+
+// MergeResult reports a merge. Moved counts the references moved to the
+// canonical movie per kind; references that would have clashed with one the
+// canonical movie already had (e.g. a user's second review) are dropped.
+type MergeResult struct {
+	Movie  *Movie           `json:"movie"`
+	Merged []string         `json:"merged"`
+	Moved  map[string]int64 `json:"moved"`
+}
+
+// MovieMetadata is a movie as described by a metadata provider, used to
+// prefill or enrich a movie. Search results leave out genres and credits.
+type MovieMetadata struct {
+	Source      string   `json:"source"`
+	SourceID    string   `json:"sourceId"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Year        int      `json:"year,omitempty"`
+	IMDbID      string   `json:"imdbId,omitempty"`
+	Genres      []string `json:"genres,omitempty"`
+	Actors      []string `json:"actors,omitempty"`
+	Crew        []string `json:"crew,omitempty"`
+	Trailer     string   `json:"trailer,omitempty"`
+	PosterURL   string   `json:"posterUrl,omitempty"`
+}
+
+// RefreshMetadataRequest refreshes a movie from its metadata provider.
+// Details the movie lacks are filled in; with Overwrite, the provider's
+// description, trailer, genres, credits and poster replace the movie's own.
+type RefreshMetadataRequest struct {
+	Overwrite bool `json:"overwrite"`
+}
+
+// GraphQLRequest is a GraphQL query with its variables. OperationName
+// picks the operation to run when the query holds several.
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required,max=20000"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
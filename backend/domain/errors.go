@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrorDetail is one problem with a request. Code is stable, so clients can
+// branch on it or localize the message themselves; Message is its text in
+// the language the request asked for; Pointer is a JSON pointer (RFC 6901)
+// to the field in the request body it concerns, if any; Params are the
+// values filled into the message, such as the field a rule applies to.
+type ErrorDetail struct {
+	Code    string            `json:"code"`
+	Message string            `json:"message"`
+	Pointer string            `json:"pointer,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+}
+
+// NewErrorDetail builds a detail with an English message. params are
+// name/value pairs, e.g. "field", "name", "max", "100"; a "field" such as
+// "translations[0].title" also sets the Pointer, "/translations/0/title".
+func NewErrorDetail(code, message string, params ...string) ErrorDetail {
+	detail := ErrorDetail{Code: code, Message: message}
+	if len(params) > 1 {
+		detail.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			detail.Params[params[i]] = params[i+1]
+		}
+		if field := detail.Params["field"]; field != "" {
+			detail.Pointer = fieldPointer(field)
+		}
+	}
+	return detail
+}
+
+// pointerEscaper escapes the characters a JSON pointer token cannot hold.
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func fieldPointer(field string) string {
+	tokens := strings.FieldsFunc(field, func(r rune) bool {
+		return r == '.' || r == '[' || r == ']'
+	})
+	var pointer strings.Builder
+	for _, token := range tokens {
+		pointer.WriteString("/" + pointerEscaper.Replace(token))
+	}
+	return pointer.String()
+}
+
+// CodedError is an error carrying a stable code, such as a failed
+// validation rule.
+type CodedError struct {
+	Detail ErrorDetail
+}
+
+// NewCodedError is NewErrorDetail as an error.
+func NewCodedError(code, message string, params ...string) *CodedError {
+	return &CodedError{Detail: NewErrorDetail(code, message, params...)}
+}
+
+func (e *CodedError) Error() string {
+	return e.Detail.Message
+}
+
+// ErrorDetails describes err for a response. A coded error anywhere in
+// err's chain keeps its code; anything else becomes an invalid value with
+// the error's own text.
+func ErrorDetails(err error) []ErrorDetail {
+	if err == nil {
+		return nil
+	}
+
+	var coded *CodedError
+	switch {
+	case errors.As(err, &coded):
+		return []ErrorDetail{coded.Detail}
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return []ErrorDetail{NewErrorDetail(CodeInvalidID, err.Error())}
+	}
+	return []ErrorDetail{NewErrorDetail(CodeInvalidValue, err.Error())}
+}
+
+// Kinds of request failure. Every *Error wraps one, so callers can test
+// for a kind with errors.Is.
+var (
+	ErrValidation   = errors.New("validation failed")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrTooLarge     = errors.New("too large")
+	ErrUnavailable  = errors.New("unavailable")
+)
+
+// Error is a request failure the client can act on: its Kind decides the
+// HTTP status, Code and Message describe it, and Details list what was
+// wrong, such as each invalid field. The constructors below take the
+// details for each kind.
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Details []ErrorDetail
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// Validation is a request that breaks a rule, such as an invalid field.
+func Validation(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrValidation, Code: code, Message: message, Details: details}
+}
+
+// Unauthorized is a request without valid credentials.
+func Unauthorized(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrUnauthorized, Code: code, Message: message, Details: details}
+}
+
+// Forbidden is a request the user is not allowed to make.
+func Forbidden(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrForbidden, Code: code, Message: message, Details: details}
+}
+
+// NotFound is a request for something that does not exist.
+func NotFound(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrNotFound, Code: code, Message: message, Details: details}
+}
+
+// Conflict is a request the current state does not allow, such as
+// creating something that already exists.
+func Conflict(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrConflict, Code: code, Message: message, Details: details}
+}
+
+// TooLarge is a request whose content exceeds a size limit.
+func TooLarge(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrTooLarge, Code: code, Message: message, Details: details}
+}
+
+// Unavailable is a request that cannot be served for now, such as when a
+// service it needs is down.
+func Unavailable(code, message string, details ...ErrorDetail) *Error {
+	return &Error{Kind: ErrUnavailable, Code: code, Message: message, Details: details}
+}
+
+// Problem is the application/problem+json body (RFC 7807) of a failed
+// request. Type is always "about:blank", so Title is the text of Status;
+// Code and Errors carry the Error the request failed with, localized, and
+// TraceID matches the X-Request-ID header and the server logs.
+type Problem struct {
+	Type     string        `json:"type"`
+	Title    string        `json:"title"`
+	Status   int           `json:"status"`
+	Detail   string        `json:"detail"`
+	Instance string        `json:"instance,omitempty"`
+	Code     string        `json:"code"`
+	Errors   []ErrorDetail `json:"errors,omitempty"`
+	TraceID  string        `json:"traceId,omitempty"`
+}
+
+// Error codes of failed requests, in the Code of an Error.
+const (
+	CodeInternalError        = "internal_error"
+	CodeInvalidRequestBody   = "invalid_request_body"
+	CodeValidationFailed     = "validation_failed"
+	CodeInvalidUserID        = "invalid_user_id"
+	CodeInvalidCursor        = "invalid_cursor"
+	CodeInvalidDateFilter    = "invalid_date_filter"
+	CodeInvalidSort          = "invalid_sort"
+	CodeInvalidWindow        = "invalid_window"
+	CodeInvalidLookup        = "invalid_lookup"
+	CodeInvalidMapping       = "invalid_mapping"
+	CodeInvalidTrailer       = "invalid_trailer"
+	CodeInvalidTranslation   = "invalid_translations"
+	CodeInvalidPoster        = "invalid_poster"
+	CodeInvalidExport        = "invalid_export_request"
+	CodeInvalidCredentials   = "invalid_credentials"
+	CodeInvalidInvite        = "invalid_invite"
+	CodeUnsupportedFormat    = "unsupported_format"
+	CodeFileRequired         = "file_required"
+	CodeFileTooLarge         = "file_too_large"
+	CodeRequestTooLarge      = "request_too_large"
+	CodeFileUnreadable       = "file_unreadable"
+	CodeImportFileEmpty      = "import_file_empty"
+	CodeAdminRequired        = "admin_required"
+	CodeAuthorizationMissing = "authorization_missing"
+	CodeBearerTokenMissing   = "bearer_token_missing"
+	CodeInvalidToken         = "invalid_token"
+	CodeInvalidTokenClaims   = "invalid_token_claims"
+	CodeInvalidTokenUser     = "invalid_token_user"
+	CodeInvalidQuery         = "invalid_query"
+	CodeQueryTooDeep         = "query_too_deep"
+	CodeQueryTooComplex      = "query_too_complex"
+
+	CodeRouteNotFound          = "route_not_found"
+	CodeMovieNotFound          = "movie_not_found"
+	CodeMoviesNotFound         = "movies_not_found"
+	CodeCanonicalMovieNotFound = "canonical_movie_not_found"
+	CodeUserNotFound           = "user_not_found"
+	CodeReviewNotFound         = "review_not_found"
+	CodeListNotFound           = "list_not_found"
+	CodeCollectionNotFound     = "collection_not_found"
+	CodeMemberNotFound         = "member_not_found"
+	CodeCommentNotFound        = "comment_not_found"
+	CodeParentCommentNotFound  = "parent_comment_not_found"
+	CodeDiaryEntryNotFound     = "diary_entry_not_found"
+	CodeImportJobNotFound      = "import_job_not_found"
+	CodeExportNotFound         = "export_not_found"
+	CodePosterNotFound         = "poster_not_found"
+	CodeMetadataNotFound       = "metadata_not_found"
+	CodeErasureNotRequested    = "erasure_not_requested"
+	CodeErasureNotScheduled    = "erasure_not_scheduled"
+	CodeNotLiked               = "not_liked"
+	CodeNotFollowing           = "not_following"
+	CodeNotOnWatchlist         = "not_on_watchlist"
+	CodeNotOnList              = "not_on_list"
+
+	CodeMovieEditForbidden        = "movie_edit_forbidden"
+	CodeMovieDeleteForbidden      = "movie_delete_forbidden"
+	CodeListEditForbidden         = "list_edit_forbidden"
+	CodeCollectionManageForbidden = "collection_manage_forbidden"
+	CodeCollectionAddForbidden    = "collection_add_forbidden"
+	CodeCollectionRemoveForbidden = "collection_remove_forbidden"
+	CodeCommentEditForbidden      = "comment_edit_forbidden"
+	CodeCommentDeleteForbidden    = "comment_delete_forbidden"
+
+	CodeEmailTaken          = "email_taken"
+	CodeUsernameTaken       = "username_taken"
+	CodeAlreadyReviewed     = "already_reviewed"
+	CodeAlreadyLiked        = "already_liked"
+	CodeAlreadyFollowing    = "already_following"
+	CodeAlreadyOnWatchlist  = "already_on_watchlist"
+	CodeAlreadyMember       = "already_member"
+	CodeUserAlreadyMember   = "user_already_member"
+	CodeCannotFollowSelf    = "cannot_follow_self"
+	CodeOwnerRoleFixed      = "owner_role_fixed"
+	CodeOwnerCannotLeave    = "owner_cannot_leave"
+	CodeRepliesOnThread     = "replies_on_thread"
+	CodeCommentDeleted      = "comment_deleted"
+	CodeCommentRejected     = "comment_rejected"
+	CodeExportInProgress    = "export_in_progress"
+	CodeErasureStarted      = "erasure_started"
+	CodeMergeIntoSelf       = "merge_into_self"
+	CodeMetadataUnavailable = "metadata_unavailable"
+	CodeListChanged         = "list_changed"
+)
+
+// Error codes of the details in a Problem's Errors.
+const (
+	CodeInvalidID            = "invalid_id"
+	CodeInvalidValue         = "invalid_value"
+	CodeMalformedBody        = "malformed_body"
+	CodeFieldRequired        = "field_required"
+	CodeFieldTooSmall        = "field_too_small"
+	CodeFieldTooLarge        = "field_too_large"
+	CodeFieldInvalid         = "field_invalid"
+	CodeFieldWrongType       = "field_wrong_type"
+	CodeFieldEmpty           = "field_empty"
+	CodeFieldTooLong         = "field_too_long"
+	CodeFieldTooShort        = "field_too_short"
+	CodeFieldTooFew          = "field_too_few"
+	CodeFieldTooMany         = "field_too_many"
+	CodeFieldNotUnique       = "field_not_unique"
+	CodeFieldNotURL          = "field_not_url"
+	CodeFieldNotAlphanumeric = "field_not_alphanumeric"
+	CodeFieldNotOneOf        = "field_not_one_of"
+	CodeFieldNotNumber       = "field_not_number"
+	CodeDateInvalid          = "date_invalid"
+	CodeDateInFuture         = "date_in_future"
+	CodeDateRangeOrder       = "date_range_reversed"
+	CodeUnknownField         = "unknown_field"
+	CodeUnknownMovie         = "unknown_movie"
+	CodeListOrderMismatch    = "list_order_mismatch"
+	CodeTitleOrIMDbID        = "title_or_imdb_id_required"
+	CodeSetIMDbID            = "set_imdb_id"
+	CodeBlockedWord          = "blocked_word"
+
+	CodeInvalidEmail             = "invalid_email"
+	CodePasswordMissingUppercase = "password_missing_uppercase"
+	CodePasswordMissingLowercase = "password_missing_lowercase"
+	CodePasswordMissingSpecial   = "password_missing_special"
+
+	CodeRatingOutOfRange = "rating_out_of_range"
+	CodeRatingHalfStep   = "rating_half_step"
+
+	CodeTrailerNotURL      = "trailer_not_url"
+	CodeTrailerUnsupported = "trailer_unsupported"
+	CodeTrailerNotYouTube  = "trailer_not_youtube"
+	CodeTrailerNotVimeo    = "trailer_not_vimeo"
+
+	CodeLocaleUnsupported    = "locale_unsupported"
+	CodeTranslationDuplicate = "translation_duplicate"
+
+	CodePosterUnsupported = "poster_unsupported"
+	CodePosterTooLarge    = "poster_dimensions_too_large"
+)
@@ -0,0 +1,47 @@
+package domain
+
+// MovieTranslation is a movie's title and description in another language.
+// An empty description falls back to the movie's own.
+type MovieTranslation struct {
+	Locale      string `bson:"locale" json:"locale" binding:"required,max=35"`
+	Title       string `bson:"title" json:"title" binding:"required,max=200"`
+	Description string `bson:"description,omitempty" json:"description,omitempty" binding:"max=5000"`
+}
+
+// Localize rewrites the movie for display in the first locale of chain it
+// has a title in. The chain must end with the default locale, which movies
+// without a Locale are written in. The text it replaces moves into
+// Translations, so every language stays available and Locale names the one
+// shown.
+func (m *Movie) Localize(chain []string) {
+	if len(chain) == 0 {
+		return
+	}
+	if m.Locale == "" {
+		m.Locale = chain[len(chain)-1]
+	}
+
+	for _, locale := range chain {
+		if locale == m.Locale {
+			return
+		}
+		for i, translation := range m.Translations {
+			if translation.Locale != locale {
+				continue
+			}
+
+			translations := make([]MovieTranslation, 0, len(m.Translations))
+			translations = append(translations, MovieTranslation{Locale: m.Locale, Title: m.Title, Description: m.Description})
+			translations = append(translations, m.Translations[:i]...)
+			translations = append(translations, m.Translations[i+1:]...)
+
+			m.Title = translation.Title
+			if translation.Description != "" {
+				m.Description = translation.Description
+			}
+			m.Locale = locale
+			m.Translations = translations
+			return
+		}
+	}
+}
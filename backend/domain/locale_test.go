@@ -0,0 +1,107 @@
+package domain
+
+import "testing"
+
+func TestMovieLocalize(t *testing.T) {
+	movie := func() Movie {
+		return Movie{
+			Title:       "The Lion King",
+			Description: "A lion cub grows up.",
+			Translations: []MovieTranslation{
+				{Locale: "fr", Title: "Le Roi lion", Description: "Un lionceau grandit."},
+				{Locale: "am", Title: "የአንበሳው ንጉሥ"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		movie       func() Movie
+		chain       []string
+		locale      string
+		title       string
+		description string
+		others      []string // locales left in Translations, in order
+	}{
+		{
+			name:        "the first locale with a title wins",
+			movie:       movie,
+			chain:       []string{"fr", "am", "en"},
+			locale:      "fr",
+			title:       "Le Roi lion",
+			description: "Un lionceau grandit.",
+			others:      []string{"en", "am"},
+		},
+		{
+			name:        "locales without a title are skipped",
+			movie:       movie,
+			chain:       []string{"de", "am", "en"},
+			locale:      "am",
+			title:       "የአንበሳው ንጉሥ",
+			description: "A lion cub grows up.",
+			others:      []string{"en", "fr"},
+		},
+		{
+			name:        "the default locale keeps the movie as written",
+			movie:       movie,
+			chain:       []string{"de", "en"},
+			locale:      "en",
+			title:       "The Lion King",
+			description: "A lion cub grows up.",
+			others:      []string{"fr", "am"},
+		},
+		{
+			name: "a movie written in another locale",
+			movie: func() Movie {
+				return Movie{Locale: "fr", Title: "Amélie", Description: "Paris.", Translations: []MovieTranslation{{Locale: "en", Title: "Amelie"}}}
+			},
+			chain:       []string{"fr", "en"},
+			locale:      "fr",
+			title:       "Amélie",
+			description: "Paris.",
+			others:      []string{"en"},
+		},
+		{
+			name:        "no chain leaves the movie alone",
+			movie:       movie,
+			chain:       nil,
+			locale:      "",
+			title:       "The Lion King",
+			description: "A lion cub grows up.",
+			others:      []string{"fr", "am"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.movie()
+			m.Localize(tt.chain)
+
+			if m.Locale != tt.locale || m.Title != tt.title || m.Description != tt.description {
+				t.Errorf("movie = %q: %q, %q; want %q: %q, %q", m.Locale, m.Title, m.Description, tt.locale, tt.title, tt.description)
+			}
+			if len(m.Translations) != len(tt.others) {
+				t.Fatalf("translations = %+v, want locales %q", m.Translations, tt.others)
+			}
+			for i, locale := range tt.others {
+				if m.Translations[i].Locale != locale {
+					t.Errorf("translation %d = %q, want %q", i, m.Translations[i].Locale, locale)
+				}
+			}
+		})
+	}
+}
+
+func TestMovieLocalizeKeepsEveryLanguage(t *testing.T) {
+	m := Movie{Title: "The Lion King", Description: "A lion cub grows up.", Translations: []MovieTranslation{{Locale: "fr", Title: "Le Roi lion"}}}
+	m.Localize([]string{"fr", "en"})
+
+	// Localizing again, for another chain, gets back to the original
+	m.Localize([]string{"en"})
+	if m.Locale != "en" || m.Title != "The Lion King" || m.Description != "A lion cub grows up." {
+		t.Errorf("movie = %q: %q, %q; want the English original", m.Locale, m.Title, m.Description)
+	}
+	if len(m.Translations) != 1 || m.Translations[0].Title != "Le Roi lion" {
+		t.Errorf("translations = %+v, want the French title kept", m.Translations)
+	}
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 // User represents a user in the system
 type User struct {
@@ -8,16 +12,429 @@ type User struct {
 	Username string             `bson:"username" json:"username"`
 	Email    string             `bson:"email" json:"email"`
 	Password string             `bson:"password" json:"-"`
+	Role     string             `bson:"role,omitempty" json:"role,omitempty"`
 }
 
+// User roles. Regular users have no role; moderators and admins can remove
+// other users' content.
+const (
+	UserRoleModerator = "moderator"
+	UserRoleAdmin     = "admin"
+)
+
 // Movie represents a movie in the collection
 type Movie struct {
 	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	Title       string             `bson:"title" json:"title"`
 	Description string             `bson:"description" json:"description"`
-	Poster      string             `bson:"poster" json:"poster"`
 	Trailer     string             `bson:"trailer" json:"trailer"`
 	Actors      []string           `bson:"actors" json:"actors"`
 	Genres      []string           `bson:"genres" json:"genres"`
+	Crew        []string           `bson:"crew,omitempty" json:"crew"`
+	Year        int                `bson:"year,omitempty" json:"year,omitempty"`
+	IMDbID      string             `bson:"imdbId,omitempty" json:"imdbId,omitempty"`
+	UserID      primitive.ObjectID `bson:"userId" json:"userId"`
+
+	// Locale is the language of Title and Description; empty means the
+	// default locale. Translations hold them in other languages.
+	Locale       string             `bson:"locale,omitempty" json:"locale"`
+	Translations []MovieTranslation `bson:"translations" json:"translations,omitempty"`
+
+	// PosterKey is the blob key of the uploaded poster's original image,
+	// with thumbnails stored next to it. Poster is an external poster URL
+	// on movies from before uploads; it is only read, never set.
+	PosterKey string `bson:"posterKey,omitempty" json:"-"`
+	Poster    string `bson:"poster,omitempty" json:"-"`
+
+	// TrailerVideo is Trailer parsed into the video it plays; Trailer holds
+	// its normalized URL.
+	TrailerVideo *TrailerVideo `bson:"trailerVideo,omitempty" json:"-"`
+
+	// Metadata links the movie to the metadata provider entry it was last
+	// refreshed from.
+	Metadata *MetadataRef `bson:"metadata,omitempty" json:"metadata,omitempty"`
+
+	// CollectionID is set when the movie belongs to a shared collection,
+	// whose members may then manage it according to their role.
+	CollectionID primitive.ObjectID `bson:"collectionId,omitempty" json:"collectionId,omitempty"`
+
+	// Rating aggregates are maintained by the review usecase and are never
+	// written through a plain movie update.
+	AverageRating float64 `bson:"averageRating,omitempty" json:"averageRating"`
+	RatingCount   int64   `bson:"ratingCount,omitempty" json:"ratingCount"`
+
+	// LikeCount is denormalized from the likes collection and only changed
+	// with atomic increments.
+	LikeCount int64 `bson:"likeCount,omitempty" json:"likeCount"`
+}
+
+// MetadataRef identifies a movie at a metadata provider such as TMDb.
+type MetadataRef struct {
+	Source      string    `bson:"source" json:"source"`
+	ID          string    `bson:"id" json:"id"`
+	RefreshedAt time.Time `bson:"refreshedAt" json:"refreshedAt"`
+}
+
+// Movie list sort orders. The default is insertion order.
+const (
+	MovieSortPopular = "popular"
+)
+
+// Review is a user's rating and optional write-up of a movie.
+// Each user has at most one review per movie.
+type Review struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	MovieID   primitive.ObjectID `bson:"movieId" json:"movieId"`
+	Rating    float64            `bson:"rating" json:"rating"`
+	Text      string             `bson:"text,omitempty" json:"text,omitempty"`
+	Spoiler   bool               `bson:"spoiler" json:"spoiler"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// WatchlistEntry marks a movie a user intends to watch.
+type WatchlistEntry struct {
+	ID      primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID  primitive.ObjectID `bson:"userId" json:"userId"`
+	MovieID primitive.ObjectID `bson:"movieId" json:"movieId"`
+	AddedAt time.Time          `bson:"addedAt" json:"addedAt"`
+}
+
+// DiaryEntry records a single viewing of a movie. Rating is zero when the
+// viewing was logged without one.
+type DiaryEntry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	MovieID   primitive.ObjectID `bson:"movieId" json:"movieId"`
+	WatchedOn time.Time          `bson:"watchedOn" json:"watchedOn"`
+	Rewatch   bool               `bson:"rewatch" json:"rewatch"`
+	Rating    float64            `bson:"rating,omitempty" json:"rating,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// List visibility levels. Unlisted lists are readable by anyone holding the
+// share link but are never listed publicly.
+const (
+	ListVisibilityPrivate  = "private"
+	ListVisibilityUnlisted = "unlisted"
+	ListVisibilityPublic   = "public"
+)
+
+// MovieList is a named, user-curated list of movies. Entries are kept in
+// display order.
+type MovieList struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
 	UserID      primitive.ObjectID `bson:"userId" json:"userId"`
-}
\ No newline at end of file
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description" json:"description"`
+	Visibility  string             `bson:"visibility" json:"visibility"`
+	ShareToken  string             `bson:"shareToken" json:"shareToken,omitempty"`
+	Entries     []ListEntry        `bson:"entries" json:"entries"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// ListEntry is a movie reference inside a list with an optional note.
+type ListEntry struct {
+	MovieID primitive.ObjectID `bson:"movieId" json:"movieId"`
+	Note    string             `bson:"note,omitempty" json:"note,omitempty"`
+	AddedAt time.Time          `bson:"addedAt" json:"addedAt"`
+}
+
+// Collection member roles, from most to least privileged.
+const (
+	CollectionRoleOwner  = "owner"
+	CollectionRoleEditor = "editor"
+	CollectionRoleViewer = "viewer"
+)
+
+// Collection is a group of movies co-managed by its members. The owner is
+// always present in Members with the owner role.
+type Collection struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name        string             `bson:"name" json:"name"`
+	Description string             `bson:"description" json:"description"`
+	OwnerID     primitive.ObjectID `bson:"ownerId" json:"ownerId"`
+	Members     []CollectionMember `bson:"members" json:"members"`
+	InviteToken string             `bson:"inviteToken,omitempty" json:"inviteToken,omitempty"`
+	InviteRole  string             `bson:"inviteRole,omitempty" json:"inviteRole,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time          `bson:"updatedAt" json:"updatedAt"`
+}
+
+// CollectionMember is a user's membership in a collection.
+type CollectionMember struct {
+	UserID   primitive.ObjectID `bson:"userId" json:"userId"`
+	Username string             `bson:"username" json:"username"`
+	Role     string             `bson:"role" json:"role"`
+	JoinedAt time.Time          `bson:"joinedAt" json:"joinedAt"`
+}
+
+// Like records that a user loves a movie.
+type Like struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID `bson:"userId" json:"userId"`
+	MovieID   primitive.ObjectID `bson:"movieId" json:"movieId"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Who removed a deleted comment.
+const (
+	CommentDeletedByAuthor    = "author"
+	CommentDeletedByModerator = "moderator"
+)
+
+// Comment is a discussion post on a movie. Top-level comments start a
+// thread; replies point at their parent and at the thread's root comment.
+// A deleted comment that still has replies is kept as a tombstone with its
+// body cleared so the thread stays intact.
+type Comment struct {
+	ID         primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	MovieID    primitive.ObjectID  `bson:"movieId" json:"movieId"`
+	UserID     primitive.ObjectID  `bson:"userId" json:"userId"`
+	ParentID   *primitive.ObjectID `bson:"parentId,omitempty" json:"parentId,omitempty"`
+	ThreadID   *primitive.ObjectID `bson:"threadId,omitempty" json:"threadId,omitempty"`
+	Body       string              `bson:"body" json:"body"`
+	ReplyCount int64               `bson:"replyCount" json:"replyCount"`
+	Edited     bool                `bson:"edited" json:"edited"`
+	EditedAt   *time.Time          `bson:"editedAt,omitempty" json:"editedAt,omitempty"`
+	Flagged    bool                `bson:"flagged" json:"flagged"`
+	FlagReason string              `bson:"flagReason,omitempty" json:"-"`
+	Deleted    bool                `bson:"deleted" json:"deleted"`
+	DeletedBy  string              `bson:"deletedBy,omitempty" json:"deletedBy,omitempty"`
+	CreatedAt  time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+// Follow links a follower to a user whose activity they want in their feed.
+type Follow struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	FollowerID primitive.ObjectID `bson:"followerId" json:"followerId"`
+	FolloweeID primitive.ObjectID `bson:"followeeId" json:"followeeId"`
+	CreatedAt  time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+// Activity types recorded for the follower feed.
+const (
+	ActivityMovieAdded    = "movie_added"
+	ActivityMovieUpdated  = "movie_updated"
+	ActivityMovieReviewed = "movie_reviewed"
+	ActivityMovieListed   = "movie_listed"
+)
+
+// Activity is an entry in a user's activity log. ListID is set for
+// movie_listed events and ReviewID for movie_reviewed events.
+type Activity struct {
+	ID        primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID    primitive.ObjectID  `bson:"userId" json:"userId"`
+	Type      string              `bson:"type" json:"type"`
+	MovieID   primitive.ObjectID  `bson:"movieId" json:"movieId"`
+	ListID    *primitive.ObjectID `bson:"listId,omitempty" json:"listId,omitempty"`
+	ReviewID  *primitive.ObjectID `bson:"reviewId,omitempty" json:"reviewId,omitempty"`
+	CreatedAt time.Time           `bson:"createdAt" json:"createdAt"`
+}
+
+// SimilarMovies holds the precomputed neighbours of one movie, best first.
+// The documents are rebuilt in the background whenever the catalogue changes.
+type SimilarMovies struct {
+	MovieID    primitive.ObjectID `bson:"_id" json:"movieId"`
+	Neighbours []SimilarMovie     `bson:"neighbours" json:"neighbours"`
+	ComputedAt time.Time          `bson:"computedAt" json:"computedAt"`
+}
+
+type SimilarMovie struct {
+	MovieID primitive.ObjectID `bson:"movieId" json:"movieId"`
+	Score   float64            `bson:"score" json:"score"`
+}
+
+// RatingNeighbours is one movie's row of the item-item collaborative
+// filtering model: the movies users rated in a similar way, best first.
+type RatingNeighbours struct {
+	MovieID    primitive.ObjectID `bson:"_id" json:"movieId"`
+	Neighbours []RatingNeighbour  `bson:"neighbours" json:"neighbours"`
+	ComputedAt time.Time          `bson:"computedAt" json:"computedAt"`
+}
+
+// RatingNeighbour is a similar movie with the number of users who rated
+// both, which the similarity is based on.
+type RatingNeighbour struct {
+	MovieID primitive.ObjectID `bson:"movieId" json:"movieId"`
+	Score   float64            `bson:"score" json:"score"`
+	Support int                `bson:"support" json:"support"`
+}
+
+// Recommendation sources
+const (
+	RecommendationCollaborative = "collaborative"
+	RecommendationContent       = "content"
+)
+
+// Movie engagement events that feed the trending scores.
+const (
+	MovieEventView    = "view"
+	MovieEventLike    = "like"
+	MovieEventReview  = "review"
+	MovieEventListAdd = "list_add"
+)
+
+// Trending windows
+const (
+	TrendingDay   = "day"
+	TrendingWeek  = "week"
+	TrendingMonth = "month"
+)
+
+// MovieEventCount is how many events of one type a movie had in one hour.
+type MovieEventCount struct {
+	MovieID primitive.ObjectID
+	Type    string
+	Hour    time.Time
+	Count   int64
+}
+
+// TrendingList is the precomputed ranking for one trending window.
+type TrendingList struct {
+	Window     string          `bson:"_id" json:"window"`
+	Movies     []TrendingMovie `bson:"movies" json:"movies"`
+	ComputedAt time.Time       `bson:"computedAt" json:"computedAt"`
+}
+
+type TrendingMovie struct {
+	MovieID primitive.ObjectID `bson:"movieId" json:"movieId"`
+	Score   float64            `bson:"score" json:"score"`
+}
+
+// Export formats
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatJSON   = "json"
+	ExportFormatNDJSON = "ndjson"
+)
+
+// Export sections. Movies are always exported; the rest are opt-in.
+const (
+	ExportMovies  = "movies"
+	ExportReviews = "reviews"
+	ExportLists   = "lists"
+	ExportDiary   = "diary"
+)
+
+// Import file formats
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatJSON   = "json"
+	ImportFormatNDJSON = "ndjson"
+
+	// Exports from other services
+	ImportFormatLetterboxd = "letterboxd"
+	ImportFormatIMDb       = "imdb"
+)
+
+// Import job statuses
+const (
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// What happened, or in a dry run would happen, to an imported row.
+const (
+	ImportRowCreate    = "create"
+	ImportRowDuplicate = "duplicate"
+	ImportRowInvalid   = "invalid"
+)
+
+// ImportJob tracks a bulk import running in the background. In a dry run
+// nothing is written and Created counts the movies that would be created.
+// Rows reports each row's outcome, up to a limit noted by RowsTruncated.
+type ImportJob struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID `bson:"userId" json:"userId"`
+	Format        string             `bson:"format" json:"format"`
+	DryRun        bool               `bson:"dryRun" json:"dryRun"`
+	Status        string             `bson:"status" json:"status"`
+	Total         int                `bson:"total" json:"total"`
+	Processed     int                `bson:"processed" json:"processed"`
+	Created       int                `bson:"created" json:"created"`
+	Duplicates    int                `bson:"duplicates" json:"duplicates"`
+	Invalid       int                `bson:"invalid" json:"invalid"`
+	Rows          []ImportRowResult  `bson:"rows" json:"rows"`
+	RowsTruncated bool               `bson:"rowsTruncated,omitempty" json:"rowsTruncated,omitempty"`
+	Error         string             `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt" json:"createdAt"`
+	FinishedAt    *time.Time         `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+}
+
+// ImportRowResult is the outcome of one row. Row numbers start at 1 and
+// count data rows, not a CSV header. Source names the file within an
+// export the row came from, and NewMovie is set when the row's movie was
+// not in the collection and has been (or would be) added.
+type ImportRowResult struct {
+	Source   string   `bson:"source,omitempty" json:"source,omitempty"`
+	Row      int      `bson:"row" json:"row"`
+	Title    string   `bson:"title,omitempty" json:"title,omitempty"`
+	Year     int      `bson:"year,omitempty" json:"year,omitempty"`
+	Action   string   `bson:"action" json:"action"`
+	MovieID  string   `bson:"movieId,omitempty" json:"movieId,omitempty"`
+	NewMovie bool     `bson:"newMovie,omitempty" json:"newMovie,omitempty"`
+	Errors   []string `bson:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// Account data export statuses. Expired archives have been removed and can
+// no longer be downloaded.
+const (
+	DataExportStatusRunning   = "running"
+	DataExportStatusCompleted = "completed"
+	DataExportStatusFailed    = "failed"
+	DataExportStatusExpired   = "expired"
+)
+
+// DataExport is a ZIP archive of everything stored about a user, built in
+// the background and kept for download until ExpiresAt.
+type DataExport struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      primitive.ObjectID `bson:"userId" json:"userId"`
+	Status      string             `bson:"status" json:"status"`
+	Size        int64              `bson:"size,omitempty" json:"size,omitempty"`
+	Error       string             `bson:"error,omitempty" json:"error,omitempty"`
+	CreatedAt   time.Time          `bson:"createdAt" json:"createdAt"`
+	FinishedAt  *time.Time         `bson:"finishedAt,omitempty" json:"finishedAt,omitempty"`
+	ExpiresAt   *time.Time         `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	DownloadURL string             `bson:"-" json:"downloadUrl,omitempty"`
+}
+
+// Account erasure statuses
+const (
+	ErasureStatusScheduled = "scheduled"
+	ErasureStatusCancelled = "cancelled"
+	ErasureStatusRunning   = "running"
+	ErasureStatusCompleted = "completed"
+)
+
+// ErasureRequest schedules a user's data for erasure once a grace period
+// has passed, during which the user can cancel. It is kept as the audit
+// record after erasure and holds nothing personal beyond the user's ID.
+// Erased counts the documents deleted or anonymized in each collection.
+// ReviewedMovies holds the movies whose ratings the erasure changes while
+// it runs, so a rerun still refreshes them after the reviews are gone.
+type ErasureRequest struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID       primitive.ObjectID `bson:"userId" json:"userId"`
+	Status       string             `bson:"status" json:"status"`
+	RequestedAt  time.Time          `bson:"requestedAt" json:"requestedAt"`
+	ScheduledFor time.Time          `bson:"scheduledFor" json:"scheduledFor"`
+	CancelledAt  *time.Time         `bson:"cancelledAt,omitempty" json:"cancelledAt,omitempty"`
+	CompletedAt  *time.Time         `bson:"completedAt,omitempty" json:"completedAt,omitempty"`
+	Erased       map[string]int64   `bson:"erased,omitempty" json:"erased,omitempty"`
+
+	ReviewedMovies []primitive.ObjectID `bson:"reviewedMovies,omitempty" json:"-"`
+}
+
+// MovieRedirect points a movie ID merged away as a duplicate at the movie
+// it was merged into.
+type MovieRedirect struct {
+	ID       primitive.ObjectID `bson:"_id" json:"id"`
+	MovieID  primitive.ObjectID `bson:"movieId" json:"movieId"`
+	MergedBy primitive.ObjectID `bson:"mergedBy" json:"mergedBy"`
+	MergedAt time.Time          `bson:"mergedAt" json:"mergedAt"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+// PosterURLPrefix is where uploaded poster images are served.
+const PosterURLPrefix = "/api/v1/posters/"
+
+// PosterSizes are the thumbnail widths generated for every uploaded poster,
+// by name. Thumbnails are never wider than the original.
+var PosterSizes = map[string]int{
+	"small":  185,
+	"medium": 342,
+	"large":  780,
+}
+
+// PosterThumbnailKey is the blob key of a poster thumbnail, next to the
+// original at key.
+func PosterThumbnailKey(key, size string) string {
+	return path.Dir(key) + "/" + size + ".jpg"
+}
+
+// PosterURL is the URL of the movie's poster: the uploaded original, or the
+// external URL of a movie from before uploads. It is empty without a poster.
+func (m *Movie) PosterURL() string {
+	if m.PosterKey == "" {
+		return m.Poster
+	}
+	return PosterURLPrefix + strings.TrimPrefix(m.PosterKey, "posters/")
+}
+
+// PosterThumbnails maps each thumbnail size to its URL. Only uploaded
+// posters have thumbnails.
+func (m *Movie) PosterThumbnails() map[string]string {
+	if m.PosterKey == "" {
+		return nil
+	}
+	thumbnails := make(map[string]string, len(PosterSizes))
+	for size := range PosterSizes {
+		key := PosterThumbnailKey(m.PosterKey, size)
+		thumbnails[size] = PosterURLPrefix + strings.TrimPrefix(key, "posters/")
+	}
+	return thumbnails
+}
+
+// MarshalJSON adds the poster's URLs, which are derived from its blob key,
+// and the parsed trailer with its embed URL.
+func (m Movie) MarshalJSON() ([]byte, error) {
+	type movie Movie
+	return json.Marshal(struct {
+		movie
+		Poster           string            `json:"poster"`
+		PosterThumbnails map[string]string `json:"posterThumbnails,omitempty"`
+		TrailerVideo     *TrailerVideo     `json:"trailerVideo,omitempty"`
+	}{movie(m), m.PosterURL(), m.PosterThumbnails(), m.TrailerDetails()})
+}
@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trailer video providers.
+const (
+	TrailerProviderYouTube = "youtube"
+	TrailerProviderVimeo   = "vimeo"
+	TrailerProviderMP4     = "mp4"
+)
+
+// ErrUnsupportedTrailer is returned for trailer URLs that are not a
+// YouTube or Vimeo video or a direct MP4 link.
+var ErrUnsupportedTrailer error = NewCodedError(CodeTrailerUnsupported, "trailer must be a YouTube or Vimeo video or a direct MP4 link")
+
+var (
+	youTubeIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoIDPattern   = regexp.MustCompile(`^[0-9]+$`)
+	// startPattern matches offsets such as "90", "90s" and "1h2m30s".
+	startPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?$`)
+)
+
+// TrailerVideo is a trailer URL parsed into the video it plays. VideoID is
+// the provider's video ID, or the file's URL for direct MP4 links. StartAt
+// is the offset playback starts at, in seconds.
+type TrailerVideo struct {
+	Provider string `bson:"provider" json:"provider"`
+	VideoID  string `bson:"videoId" json:"videoId"`
+	StartAt  int    `bson:"startAt,omitempty" json:"startAt,omitempty"`
+}
+
+// ParseTrailerURL parses a YouTube (watch, youtu.be, shorts or embed),
+// Vimeo or direct MP4 link. Other hosts are rejected.
+func ParseTrailerURL(raw string) (*TrailerVideo, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, NewCodedError(CodeTrailerNotURL, "trailer must be an http or https URL")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	host = strings.TrimPrefix(host, "www.")
+	host = strings.TrimPrefix(host, "m.")
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var video *TrailerVideo
+	switch host {
+	case "youtube.com", "youtube-nocookie.com":
+		id := ""
+		switch {
+		case len(segments) == 1 && segments[0] == "watch":
+			id = u.Query().Get("v")
+		case len(segments) == 2 && (segments[0] == "shorts" || segments[0] == "embed" || segments[0] == "live"):
+			id = segments[1]
+		}
+		video = &TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id}
+	case "youtu.be":
+		video = &TrailerVideo{Provider: TrailerProviderYouTube}
+		if len(segments) == 1 {
+			video.VideoID = segments[0]
+		}
+	case "vimeo.com", "player.vimeo.com":
+		// vimeo.com/ID, vimeo.com/channels/name/ID and player.vimeo.com/video/ID
+		video = &TrailerVideo{Provider: TrailerProviderVimeo, VideoID: segments[len(segments)-1]}
+		if host == "player.vimeo.com" && (len(segments) != 2 || segments[0] != "video") {
+			video.VideoID = ""
+		}
+	default:
+		if !strings.HasSuffix(strings.ToLower(u.Path), ".mp4") {
+			return nil, ErrUnsupportedTrailer
+		}
+		file := *u
+		file.Fragment = ""
+		file.RawFragment = ""
+		video = &TrailerVideo{Provider: TrailerProviderMP4, VideoID: file.String()}
+	}
+
+	switch video.Provider {
+	case TrailerProviderYouTube:
+		if !youTubeIDPattern.MatchString(video.VideoID) {
+			return nil, NewCodedError(CodeTrailerNotYouTube, "trailer is not a link to a YouTube video")
+		}
+		start := u.Query().Get("t")
+		if start == "" {
+			start = u.Query().Get("start")
+		}
+		video.StartAt = parseStartAt(start)
+	case TrailerProviderVimeo:
+		if !vimeoIDPattern.MatchString(video.VideoID) {
+			return nil, NewCodedError(CodeTrailerNotVimeo, "trailer is not a link to a Vimeo video")
+		}
+		video.StartAt = parseFragmentStart(u.Fragment)
+	case TrailerProviderMP4:
+		video.StartAt = parseFragmentStart(u.Fragment)
+	}
+	return video, nil
+}
+
+// parseFragmentStart reads the start offset from a "#t=90s" fragment.
+func parseFragmentStart(fragment string) int {
+	values, err := url.ParseQuery(fragment)
+	if err != nil {
+		return 0
+	}
+	start, _, _ := strings.Cut(values.Get("t"), ",")
+	return parseStartAt(start)
+}
+
+// parseStartAt converts an offset in seconds or "1h2m30s" form to seconds.
+// Offsets it cannot read start the video from the beginning.
+func parseStartAt(value string) int {
+	match := startPattern.FindStringSubmatch(value)
+	if value == "" || match == nil {
+		return 0
+	}
+	seconds := 0
+	for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+		if match[i+1] != "" {
+			n, _ := strconv.Atoi(match[i+1])
+			seconds += n * int(unit/time.Second)
+		}
+	}
+	return seconds
+}
+
+// URL is the normalized link to the trailer, which is what movies store.
+func (v *TrailerVideo) URL() string {
+	switch v.Provider {
+	case TrailerProviderYouTube:
+		link := "https://www.youtube.com/watch?v=" + v.VideoID
+		if v.StartAt > 0 {
+			link += "&t=" + strconv.Itoa(v.StartAt) + "s"
+		}
+		return link
+	case TrailerProviderVimeo:
+		return "https://vimeo.com/" + v.VideoID + v.fragment()
+	}
+	return v.VideoID + v.fragment()
+}
+
+// EmbedURL is the URL to load in the frontend's player: an iframe source
+// for YouTube and Vimeo, or a video source for MP4 files.
+func (v *TrailerVideo) EmbedURL() string {
+	switch v.Provider {
+	case TrailerProviderYouTube:
+		link := "https://www.youtube.com/embed/" + v.VideoID
+		if v.StartAt > 0 {
+			link += "?start=" + strconv.Itoa(v.StartAt)
+		}
+		return link
+	case TrailerProviderVimeo:
+		return "https://player.vimeo.com/video/" + v.VideoID + v.fragment()
+	}
+	return v.VideoID + v.fragment()
+}
+
+func (v *TrailerVideo) fragment() string {
+	if v.StartAt == 0 {
+		return ""
+	}
+	if v.Provider == TrailerProviderVimeo {
+		return "#t=" + strconv.Itoa(v.StartAt) + "s"
+	}
+	return "#t=" + strconv.Itoa(v.StartAt)
+}
+
+// MarshalJSON adds the embed URL.
+func (v TrailerVideo) MarshalJSON() ([]byte, error) {
+	type trailerVideo TrailerVideo
+	return json.Marshal(struct {
+		trailerVideo
+		EmbedURL string `json:"embedUrl"`
+	}{trailerVideo(v), v.EmbedURL()})
+}
+
+// TrailerDetails is the movie's parsed trailer. Movies saved before trailers
+// were parsed are parsed on the fly; it is nil when that fails.
+func (m *Movie) TrailerDetails() *TrailerVideo {
+	if m.TrailerVideo != nil || m.Trailer == "" {
+		return m.TrailerVideo
+	}
+	video, err := ParseTrailerURL(m.Trailer)
+	if err != nil {
+		return nil
+	}
+	return video
+}
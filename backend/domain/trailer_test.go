@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseTrailerURL(t *testing.T) {
+	const id = "dQw4w9WgXcQ"
+	tests := []struct {
+		raw   string
+		want  TrailerVideo
+		code  string
+		url   string // normalized URL, when it differs from raw
+		embed string
+	}{
+		{
+			raw:   "https://www.youtube.com/watch?v=" + id,
+			want:  TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id},
+			embed: "https://www.youtube.com/embed/" + id,
+		},
+		{
+			raw:   "http://m.youtube.com/watch?feature=share&v=" + id + "&t=1m30s",
+			want:  TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id, StartAt: 90},
+			url:   "https://www.youtube.com/watch?v=" + id + "&t=90s",
+			embed: "https://www.youtube.com/embed/" + id + "?start=90",
+		},
+		{
+			raw:   "https://youtu.be/" + id + "?t=42",
+			want:  TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id, StartAt: 42},
+			url:   "https://www.youtube.com/watch?v=" + id + "&t=42s",
+			embed: "https://www.youtube.com/embed/" + id + "?start=42",
+		},
+		{
+			raw:  "https://youtube.com/shorts/" + id,
+			want: TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id},
+			url:  "https://www.youtube.com/watch?v=" + id,
+		},
+		{
+			raw:  "https://www.youtube-nocookie.com/embed/" + id + "?start=1h",
+			want: TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id, StartAt: 3600},
+			url:  "https://www.youtube.com/watch?v=" + id + "&t=3600s",
+		},
+		{
+			raw:  "https://www.youtube.com/watch?v=" + id + "&t=soon",
+			want: TrailerVideo{Provider: TrailerProviderYouTube, VideoID: id},
+			url:  "https://www.youtube.com/watch?v=" + id,
+		},
+		{
+			raw:   "https://vimeo.com/76979871#t=30s",
+			want:  TrailerVideo{Provider: TrailerProviderVimeo, VideoID: "76979871", StartAt: 30},
+			embed: "https://player.vimeo.com/video/76979871#t=30s",
+		},
+		{
+			raw:  "https://vimeo.com/channels/staffpicks/76979871",
+			want: TrailerVideo{Provider: TrailerProviderVimeo, VideoID: "76979871"},
+			url:  "https://vimeo.com/76979871",
+		},
+		{
+			raw:  "https://player.vimeo.com/video/76979871",
+			want: TrailerVideo{Provider: TrailerProviderVimeo, VideoID: "76979871"},
+			url:  "https://vimeo.com/76979871",
+		},
+		{
+			raw:   "https://cdn.example.com/trailers/heat.MP4?sig=abc#t=12,20",
+			want:  TrailerVideo{Provider: TrailerProviderMP4, VideoID: "https://cdn.example.com/trailers/heat.MP4?sig=abc", StartAt: 12},
+			url:   "https://cdn.example.com/trailers/heat.MP4?sig=abc#t=12",
+			embed: "https://cdn.example.com/trailers/heat.MP4?sig=abc#t=12",
+		},
+		{raw: "", code: CodeTrailerNotURL},
+		{raw: "youtube.com/watch?v=" + id, code: CodeTrailerNotURL},
+		{raw: "ftp://example.com/heat.mp4", code: CodeTrailerNotURL},
+		{raw: "https://example.com/heat", code: CodeTrailerUnsupported},
+		{raw: "https://www.youtube.com/watch?v=short", code: CodeTrailerNotYouTube},
+		{raw: "https://www.youtube.com/channel/UC123", code: CodeTrailerNotYouTube},
+		{raw: "https://youtu.be/", code: CodeTrailerNotYouTube},
+		{raw: "https://vimeo.com/staff", code: CodeTrailerNotVimeo},
+		{raw: "https://player.vimeo.com/76979871", code: CodeTrailerNotVimeo},
+	}
+
+	for _, tt := range tests {
+		video, err := ParseTrailerURL(tt.raw)
+		var coded *CodedError
+		if tt.code != "" {
+			if !errors.As(err, &coded) || coded.Detail.Code != tt.code {
+				t.Errorf("ParseTrailerURL(%q) error = %v, want %q", tt.raw, err, tt.code)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseTrailerURL(%q): %v", tt.raw, err)
+			continue
+		}
+		if *video != tt.want {
+			t.Errorf("ParseTrailerURL(%q) = %+v, want %+v", tt.raw, *video, tt.want)
+		}
+
+		url := tt.url
+		if url == "" {
+			url = tt.raw
+		}
+		if got := video.URL(); got != url {
+			t.Errorf("URL of %q = %q, want %q", tt.raw, got, url)
+		}
+		if tt.embed != "" && video.EmbedURL() != tt.embed {
+			t.Errorf("EmbedURL of %q = %q, want %q", tt.raw, video.EmbedURL(), tt.embed)
+		}
+
+		// The normalized URL parses back to the same video
+		if again, err := ParseTrailerURL(video.URL()); err != nil || *again != *video {
+			t.Errorf("normalized URL %q parses to %+v, %v", video.URL(), again, err)
+		}
+	}
+}
+
+func TestTrailerVideoJSON(t *testing.T) {
+	data, err := json.Marshal(TrailerVideo{Provider: TrailerProviderVimeo, VideoID: "76979871"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"provider":"vimeo","videoId":"76979871","embedUrl":"https://player.vimeo.com/video/76979871"}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
+
+func TestMovieTrailerDetails(t *testing.T) {
+	stored := &TrailerVideo{Provider: TrailerProviderYouTube, VideoID: "dQw4w9WgXcQ"}
+	tests := []struct {
+		name  string
+		movie Movie
+		want  *TrailerVideo
+	}{
+		{name: "a parsed trailer", movie: Movie{Trailer: "https://vimeo.com/1", TrailerVideo: stored}, want: stored},
+		{name: "a trailer saved before parsing", movie: Movie{Trailer: "https://vimeo.com/1"}, want: &TrailerVideo{Provider: TrailerProviderVimeo, VideoID: "1"}},
+		{name: "an old trailer that does not parse", movie: Movie{Trailer: "https://example.com/heat"}},
+		{name: "no trailer", movie: Movie{}},
+	}
+
+	for _, tt := range tests {
+		got := tt.movie.TrailerDetails()
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("%s: TrailerDetails = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// Execute runs a query for viewer. A query that does not parse, does not
+// fit the schema or goes past the limits is not run; its errors are all
+// the result holds. Errors carry their code in extensions, as problem
+// responses do, and a *domain.Error its localized details too.
+func (s *Schema) Execute(ctx context.Context, viewer Viewer, req *domain.GraphQLRequest) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		return &graphql.Result{Errors: s.formatErrors(viewer, gqlerrors.FormatErrors(err))}
+	}
+	if validation := graphql.ValidateDocument(&s.schema, doc, nil); !validation.IsValid {
+		return &graphql.Result{Errors: s.formatErrors(viewer, validation.Errors)}
+	}
+
+	cost := s.measure(doc, req.OperationName, req.Variables)
+	switch {
+	case s.limits.MaxDepth > 0 && cost.depth > s.limits.MaxDepth:
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{
+			limitError(viewer, domain.CodeQueryTooDeep, "Query is nested too deeply", "depth", cost.depth, s.limits.MaxDepth),
+		}}
+	case s.limits.MaxComplexity > 0 && cost.complexity > s.limits.MaxComplexity:
+		return &graphql.Result{Errors: []gqlerrors.FormattedError{
+			limitError(viewer, domain.CodeQueryTooComplex, "Query is too complex", "complexity", cost.complexity, s.limits.MaxComplexity),
+		}}
+	}
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withState(ctx, newRequestState(viewer, s.movieRepo, s.userRepo, s.reviewRepo)),
+	})
+	result.Errors = s.formatErrors(viewer, result.Errors)
+	return result
+}
+
+func limitError(viewer Viewer, code, message, measure string, value, limit int) gqlerrors.FormattedError {
+	params := map[string]string{measure: strconv.Itoa(value), "max": strconv.Itoa(limit)}
+	formatted := gqlerrors.NewFormattedError(i18n.Translate(viewer.Locales, code, message, params))
+	formatted.Extensions = map[string]interface{}{"code": code, measure: value, "max": limit}
+	return formatted
+}
+
+// formatErrors gives each error a code. A *domain.Error from a usecase
+// keeps its code, message and details, localized; an error from parsing
+// or validating the query is an invalid query; anything else is an
+// internal error, logged with the trace ID and hidden from the client.
+func (s *Schema) formatErrors(viewer Viewer, errs []gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	for i, formatted := range errs {
+		err := cause(formatted)
+
+		var domainErr *domain.Error
+		switch {
+		case errors.As(err, &domainErr):
+			details := make([]domain.ErrorDetail, len(domainErr.Details))
+			for j, detail := range domainErr.Details {
+				detail.Message = i18n.Translate(viewer.Locales, detail.Code, detail.Message, detail.Params)
+				details[j] = detail
+			}
+			formatted.Message = i18n.Translate(viewer.Locales, domainErr.Code, domainErr.Message, nil)
+			formatted.Extensions = map[string]interface{}{"code": domainErr.Code}
+			if len(details) > 0 {
+				formatted.Extensions["errors"] = details
+			}
+		case isQueryError(err):
+			formatted.Extensions = map[string]interface{}{"code": domain.CodeInvalidQuery}
+		default:
+			log.Printf("graphql: %v failed (trace %s): %v", formatted.Path, viewer.TraceID, err)
+			formatted.Message = i18n.Translate(viewer.Locales, domain.CodeInternalError, "Internal server error", nil)
+			formatted.Extensions = map[string]interface{}{"code": domain.CodeInternalError}
+		}
+		errs[i] = formatted
+	}
+	return errs
+}
+
+// cause is the error a formatted error was made from, which the executor
+// wraps once per field it passes through.
+func cause(formatted gqlerrors.FormattedError) error {
+	var err error = formatted
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return e
+			}
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return e
+			}
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
+
+// isQueryError tells errors in the query itself, which parsing and
+// validation report without an underlying error, from failures.
+func isQueryError(err error) bool {
+	switch err.(type) {
+	case gqlerrors.FormattedError, *gqlerrors.Error:
+		return true
+	}
+	return false
+}
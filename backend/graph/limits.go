@@ -0,0 +1,150 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// cost is how deep a selection nests and how much it asks for.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// measure works out the cost of the operation a request runs. The
+// document must have passed validation, which rules out fragment cycles
+// and fields the schema does not have. Introspection is free.
+func (s *Schema) measure(doc *ast.Document, operationName string, variables map[string]interface{}) cost {
+	var operation *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		}
+	}
+	if operation == nil {
+		return cost{}
+	}
+
+	defaults := map[string]ast.Value{}
+	for _, definition := range operation.VariableDefinitions {
+		if definition.DefaultValue != nil {
+			defaults[definition.Variable.Name.Value] = definition.DefaultValue
+		}
+	}
+
+	m := &measurer{schema: &s.schema, fragments: fragments, variables: variables, defaults: defaults}
+	return m.selectionSet(s.schema.QueryType(), operation.SelectionSet)
+}
+
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	defaults  map[string]ast.Value
+}
+
+// selectionSet costs the fields selected on object: as deep as its
+// deepest field, and as complex as all of them together.
+func (m *measurer) selectionSet(object *graphql.Object, set *ast.SelectionSet) cost {
+	var total cost
+	if object == nil || set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			c = m.field(object, selection)
+		case *ast.FragmentSpread:
+			if fragment := m.fragments[selection.Name.Value]; fragment != nil {
+				c = m.selectionSet(m.typeOf(object, fragment.TypeCondition), fragment.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			c = m.selectionSet(m.typeOf(object, selection.TypeCondition), selection.SelectionSet)
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+	return total
+}
+
+// field costs one, plus what it selects once for every item it asks for.
+func (m *measurer) field(parent *graphql.Object, field *ast.Field) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+	definition := parent.Fields()[field.Name.Value]
+	if definition == nil {
+		return cost{depth: 1, complexity: 1}
+	}
+
+	object, _ := graphql.GetNamed(definition.Type).(*graphql.Object)
+	children := m.selectionSet(object, field.SelectionSet)
+	return cost{
+		depth:      children.depth + 1,
+		complexity: 1 + m.multiplier(definition, field)*children.complexity,
+	}
+}
+
+// multiplier is how many items a field asks for: its size or first
+// argument, as given or by default, up to the most a page holds.
+func (m *measurer) multiplier(definition *graphql.FieldDefinition, field *ast.Field) int {
+	for _, arg := range definition.Args {
+		if arg.Name() != "size" && arg.Name() != "first" {
+			continue
+		}
+		n, _ := arg.DefaultValue.(int)
+		for _, given := range field.Arguments {
+			if value, ok := m.intValue(given.Value); ok && given.Name.Value == arg.Name() {
+				n = value
+			}
+		}
+		return min(max(n, 1), domain.MaxPageSize)
+	}
+	return 1
+}
+
+// intValue reads an integer argument, given inline or as a variable. A
+// variable the request leaves out takes the default the operation
+// declares for it, as it does when the query runs.
+func (m *measurer) intValue(value ast.Value) (int, bool) {
+	switch value := value.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(value.Value)
+		return n, err == nil
+	case *ast.Variable:
+		given, ok := m.variables[value.Name.Value]
+		if !ok {
+			if defaultValue := m.defaults[value.Name.Value]; defaultValue != nil {
+				return m.intValue(defaultValue)
+			}
+		}
+		switch n := given.(type) {
+		case int:
+			return n, true
+		case float64:
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+// typeOf is the type a fragment applies to, or parent when it names none.
+func (m *measurer) typeOf(parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+	object, _ := m.schema.Type(condition.Name.Value).(*graphql.Object)
+	return object
+}
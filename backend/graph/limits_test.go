@@ -0,0 +1,126 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+func TestMeasure(t *testing.T) {
+	s, err := NewSchema(nil, nil, nil, nil, nil, nil, nil, Limits{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		variables     map[string]interface{}
+		want          cost
+	}{
+		{name: "one field", query: `{ me { username } }`, want: cost{depth: 2, complexity: 2}},
+		{name: "siblings add up and the deepest counts", query: `{ me { id username } movie(id: "1") { title addedBy { username } } }`, want: cost{depth: 3, complexity: 7}},
+		{name: "a page counts its items once each", query: `{ movies(size: 5) { items { title } } }`, want: cost{depth: 3, complexity: 1 + 5*2}},
+		{name: "a page size left out takes its default", query: `{ movies { items { title } total } }`, want: cost{depth: 3, complexity: 1 + 10*3}},
+		{name: "page sizes are capped", query: `{ movies(size: 5000) { items { title } } }`, want: cost{depth: 3, complexity: 1 + 100*2}},
+		{name: "page sizes below one count as one", query: `{ movies(size: -3) { items { title } } }`, want: cost{depth: 3, complexity: 1 + 1*2}},
+		{
+			name:  "nested lists multiply",
+			query: `{ movies(size: 2) { items { reviews(first: 3) { rating } } } }`,
+			want:  cost{depth: 4, complexity: 1 + 2*(1+(1+3*1))},
+		},
+		{
+			name:      "a size given as a variable",
+			query:     `query($n: Int) { movies(size: $n) { items { title } } }`,
+			variables: map[string]interface{}{"n": float64(3)},
+			want:      cost{depth: 3, complexity: 1 + 3*2},
+		},
+		{
+			name:  "a variable left out takes the default the operation declares",
+			query: `query($n: Int = 40) { movies(size: $n) { items { title } } }`,
+			want:  cost{depth: 3, complexity: 1 + 40*2},
+		},
+		{
+			name:      "a variable given wins over its default",
+			query:     `query($n: Int = 40) { movies(size: $n) { items { title } } }`,
+			variables: map[string]interface{}{"n": 4},
+			want:      cost{depth: 3, complexity: 1 + 4*2},
+		},
+		{
+			name:  "a variable with no value or default takes the field's default",
+			query: `query($n: Int) { movies(size: $n) { items { title } } }`,
+			want:  cost{depth: 3, complexity: 1 + 10*2},
+		},
+		{
+			name:  "a variable default past the cap is capped",
+			query: `query($n: Int = 100000) { movies(size: $n) { items { title } } }`,
+			want:  cost{depth: 3, complexity: 1 + 100*2},
+		},
+		{
+			name:  "fragment spreads",
+			query: `{ ...Top } fragment Top on Query { me { ...Name } } fragment Name on User { username }`,
+			want:  cost{depth: 2, complexity: 2},
+		},
+		{name: "inline fragments", query: `{ me { ... on User { id username } } }`, want: cost{depth: 2, complexity: 3}},
+		{name: "introspection is free", query: `{ __schema { types { name } } me { __typename } }`, want: cost{depth: 1, complexity: 1}},
+		{
+			name:          "only the operation run counts",
+			query:         `query Small { me { id } } query Big { movies(size: 50) { items { title } } }`,
+			operationName: "Small",
+			want:          cost{depth: 2, complexity: 2},
+		},
+		{
+			name:          "an operation that is not there costs nothing",
+			query:         `query Small { me { id } }`,
+			operationName: "Other",
+			want:          cost{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(tt.query)})})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := s.measure(doc, tt.operationName, tt.variables); got != tt.want {
+				t.Errorf("measure = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRejectsQueriesPastTheLimits(t *testing.T) {
+	s, err := NewSchema(nil, nil, nil, nil, nil, nil, nil, Limits{MaxDepth: 3, MaxComplexity: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{name: "too deep", query: `{ movie(id: "1") { reviews { movie { title } } } }`, code: domain.CodeQueryTooDeep},
+		{name: "too complex", query: `{ movies(size: 30) { items { title } } }`, code: domain.CodeQueryTooComplex},
+		{name: "too complex through a variable default", query: `query($n: Int = 30) { movies(size: $n) { items { title } } }`, code: domain.CodeQueryTooComplex},
+		{name: "not valid", query: `{ movies { nope } }`, code: domain.CodeInvalidQuery},
+		{name: "not parsable", query: `{ movies `, code: domain.CodeInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Execute(context.Background(), Viewer{}, &domain.GraphQLRequest{Query: tt.query})
+			if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != tt.code {
+				t.Fatalf("errors = %+v, want one %q", result.Errors, tt.code)
+			}
+			if result.Data != nil {
+				t.Errorf("data = %v, want the query not run", result.Data)
+			}
+		})
+	}
+}
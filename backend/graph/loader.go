@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// loader batches lookups by key, dataloader style. A resolver asks for a
+// key and gets back a thunk; the executor resolves every field on one
+// level of a query before calling any of that level's thunks, so the
+// first thunk called fetches every key asked for so far in one call.
+// Results are kept for the rest of the request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]result[V]
+}
+
+type result[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, results: map[K]result[V]{}}
+}
+
+// load returns a thunk resolving to the value of key, or to null when
+// there is none.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, done := l.results[key]; !done && !slices.Contains(l.pending, key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if _, done := l.results[key]; !done {
+			l.flush(ctx)
+		}
+		result := l.results[key]
+		if result.err != nil || !result.found {
+			return nil, result.err
+		}
+		return result.value, nil
+	}
+}
+
+// prime stores a value found some other way, such as a movie on a page of
+// them, so that asking for it again costs nothing.
+func (l *loader[K, V]) prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, done := l.results[key]; !done {
+		l.results[key] = result[V]{value: value, found: true}
+	}
+}
+
+func (l *loader[K, V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		value, found := values[key]
+		l.results[key] = result[V]{value: value, found: found, err: err}
+	}
+}
@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// countingFetch finds keys in values, recording every batch it is asked.
+type countingFetch struct {
+	values  map[string]int
+	err     error
+	batches []string
+}
+
+func (f *countingFetch) fetch(ctx context.Context, keys []string) (map[string]int, error) {
+	f.batches = append(f.batches, strings.Join(keys, ","))
+	if f.err != nil {
+		return nil, f.err
+	}
+	found := map[string]int{}
+	for _, key := range keys {
+		if value, ok := f.values[key]; ok {
+			found[key] = value
+		}
+	}
+	return found, nil
+}
+
+func TestLoaderBatchesKeys(t *testing.T) {
+	f := &countingFetch{values: map[string]int{"a": 1, "b": 2, "c": 3}}
+	l := newLoader(f.fetch)
+	ctx := context.Background()
+
+	// One level of a query asks for its keys before resolving any
+	a, b, again, missing := l.load(ctx, "a"), l.load(ctx, "b"), l.load(ctx, "a"), l.load(ctx, "x")
+	for _, tt := range []struct {
+		thunk func() (interface{}, error)
+		want  interface{}
+	}{{a, 1}, {b, 2}, {again, 1}, {missing, nil}} {
+		got, err := tt.thunk()
+		if err != nil || got != tt.want {
+			t.Errorf("thunk = %v, %v; want %v", got, err, tt.want)
+		}
+	}
+	if strings.Join(f.batches, " ") != "a,b,x" {
+		t.Errorf("batches = %q, want one of a,b,x", f.batches)
+	}
+
+	// The next level fetches only what is new
+	c, cached := l.load(ctx, "c"), l.load(ctx, "b")
+	if got, _ := c(); got != 3 {
+		t.Errorf("c = %v, want 3", got)
+	}
+	if got, _ := cached(); got != 2 {
+		t.Errorf("b = %v, want 2", got)
+	}
+	if strings.Join(f.batches, " ") != "a,b,x c" {
+		t.Errorf("batches = %q, want a,b,x then c", f.batches)
+	}
+}
+
+func TestLoaderPrime(t *testing.T) {
+	f := &countingFetch{values: map[string]int{"a": 1}}
+	l := newLoader(f.fetch)
+
+	l.prime("a", 10)
+	l.prime("a", 20)
+	if got, err := l.load(context.Background(), "a")(); err != nil || got != 10 {
+		t.Errorf("a = %v, %v; want the first primed value", got, err)
+	}
+	if len(f.batches) != 0 {
+		t.Errorf("batches = %q, want nothing fetched", f.batches)
+	}
+}
+
+func TestLoaderFetchError(t *testing.T) {
+	f := &countingFetch{err: errors.New("database down")}
+	l := newLoader(f.fetch)
+	ctx := context.Background()
+
+	a, b := l.load(ctx, "a"), l.load(ctx, "b")
+	for _, thunk := range []func() (interface{}, error){a, b} {
+		if got, err := thunk(); err != f.err || got != nil {
+			t.Errorf("thunk = %v, %v; want the fetch error", got, err)
+		}
+	}
+	if len(f.batches) != 1 {
+		t.Errorf("fetched %d times, want once", len(f.batches))
+	}
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Viewer is who a query runs for: the signed-in user, the locales they
+// asked for, best first, and the request's trace ID.
+type Viewer struct {
+	UserID  string
+	Locales []string
+	TraceID string
+}
+
+// requestState is what the resolvers of one request share.
+type requestState struct {
+	viewer  Viewer
+	movies  *loader[string, *domain.Movie]
+	users   *loader[primitive.ObjectID, *domain.User]
+	reviews *loader[reviewsKey, []*domain.Review]
+}
+
+// reviewsKey asks for the newest reviews of a movie.
+type reviewsKey struct {
+	movieID primitive.ObjectID
+	first   int
+}
+
+type stateKey struct{}
+
+func withState(ctx context.Context, state *requestState) context.Context {
+	return context.WithValue(ctx, stateKey{}, state)
+}
+
+func stateOf(ctx context.Context) *requestState {
+	return ctx.Value(stateKey{}).(*requestState)
+}
+
+func newRequestState(viewer Viewer, movieRepo repository.MovieRepository, userRepo repository.UserRepository, reviewRepo repository.ReviewRepository) *requestState {
+	return &requestState{
+		viewer: viewer,
+		movies: newLoader(func(ctx context.Context, ids []string) (map[string]*domain.Movie, error) {
+			movies, err := movieRepo.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[string]*domain.Movie, len(movies))
+			for i := range movies {
+				movies[i].Localize(viewer.Locales)
+				byID[movies[i].ID.Hex()] = &movies[i]
+			}
+			return byID, nil
+		}),
+		users: newLoader(func(ctx context.Context, ids []primitive.ObjectID) (map[primitive.ObjectID]*domain.User, error) {
+			users, err := userRepo.FindByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[primitive.ObjectID]*domain.User, len(users))
+			for i := range users {
+				byID[users[i].ID] = &users[i]
+			}
+			return byID, nil
+		}),
+		reviews: newLoader(func(ctx context.Context, keys []reviewsKey) (map[reviewsKey][]*domain.Review, error) {
+			// One query per page size asked for; a query asks for one
+			// size on most of its movies
+			movieIDs := map[int][]primitive.ObjectID{}
+			for _, key := range keys {
+				movieIDs[key.first] = append(movieIDs[key.first], key.movieID)
+			}
+
+			byKey := make(map[reviewsKey][]*domain.Review, len(keys))
+			for _, key := range keys {
+				byKey[key] = []*domain.Review{}
+			}
+			for first, ids := range movieIDs {
+				reviews, err := reviewRepo.GetLatestByMovieIDs(ctx, ids, first)
+				if err != nil {
+					return nil, err
+				}
+				for i := range reviews {
+					key := reviewsKey{movieID: reviews[i].MovieID, first: first}
+					byKey[key] = append(byKey[key], &reviews[i])
+				}
+			}
+			return byKey, nil
+		}),
+	}
+}
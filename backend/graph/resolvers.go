@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/graphql-go/graphql"
+)
+
+func (s *Schema) resolveMe(p graphql.ResolveParams) (interface{}, error) {
+	return s.user(p, stateOf(p.Context).viewer.UserID)
+}
+
+func (s *Schema) resolveUser(p graphql.ResolveParams) (interface{}, error) {
+	return s.user(p, p.Args["id"].(string))
+}
+
+func (s *Schema) user(p graphql.ResolveParams, id string) (interface{}, error) {
+	response, err := s.userUsecase.GetUser(id)
+	if err != nil {
+		return nil, err
+	}
+	user := response.Object.(*domain.User)
+	stateOf(p.Context).users.prime(user.ID, user)
+	return user, nil
+}
+
+// resolveMovie finds a movie by ID, following it when it was merged into
+// another, as the REST API redirects.
+func (s *Schema) resolveMovie(p graphql.ResolveParams) (interface{}, error) {
+	id := p.Args["id"].(string)
+	response, err := s.movieUsecase.GetMovieByID(id)
+	if errors.Is(err, domain.ErrNotFound) {
+		if target, redirectErr := s.movieUsecase.ResolveRedirect(id); redirectErr == nil && target != "" {
+			response, err = s.movieUsecase.GetMovieByID(target)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.movies(p, response.Object.(*domain.Movie))[0], nil
+}
+
+func (s *Schema) resolveMovies(p graphql.ResolveParams) (interface{}, error) {
+	pageNumber, size, err := pageOf(p)
+	if err != nil {
+		return nil, err
+	}
+	sortBy, _ := p.Args["sort"].(string)
+	response, err := s.movieUsecase.GetMovies(pageNumber, size, sortBy)
+	if err != nil {
+		return nil, err
+	}
+	return s.moviePage(p, response), nil
+}
+
+func (s *Schema) resolveSearchMovies(p graphql.ResolveParams) (interface{}, error) {
+	pageNumber, size, err := pageOf(p)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.movieUsecase.SearchMovies(p.Args["title"].(string), pageNumber, size)
+	if err != nil {
+		return nil, err
+	}
+	return s.moviePage(p, response), nil
+}
+
+func (s *Schema) moviePage(p graphql.ResolveParams, response *domain.PaginatedResponse) *page {
+	movies, _ := response.Object.([]domain.Movie)
+	return &page{
+		Items: s.movies(p, pointers(movies)...),
+		Page:  response.PageNumber,
+		Size:  response.PageSize,
+		Total: response.TotalSize,
+	}
+}
+
+// movies localizes movies found by a usecase, and keeps them for the
+// fields that refer to them later in the query.
+func (s *Schema) movies(p graphql.ResolveParams, movies ...*domain.Movie) []*domain.Movie {
+	state := stateOf(p.Context)
+	for _, movie := range movies {
+		movie.Localize(state.viewer.Locales)
+		state.movies.prime(movie.ID.Hex(), movie)
+	}
+	return movies
+}
+
+func (s *Schema) resolveReviews(p graphql.ResolveParams) (interface{}, error) {
+	pageNumber, size, err := pageOf(p)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.reviewUsecase.GetMovieReviews(p.Args["movieId"].(string), pageNumber, size)
+	if err != nil {
+		return nil, err
+	}
+	reviews, _ := response.Object.([]domain.Review)
+	return &page{Items: pointers(reviews), Page: response.PageNumber, Size: response.PageSize, Total: response.TotalSize}, nil
+}
+
+func (s *Schema) resolveList(p graphql.ResolveParams) (interface{}, error) {
+	response, err := s.listUsecase.GetList(p.Args["id"].(string), stateOf(p.Context).viewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	details := response.Object.(domain.ListDetailsResponse)
+	s.movies(p, pointers(details.Movies)...)
+	return details.MovieList, nil
+}
+
+func (s *Schema) resolveMyLists(p graphql.ResolveParams) (interface{}, error) {
+	pageNumber, size, err := pageOf(p)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.listUsecase.GetMyLists(stateOf(p.Context).viewer.UserID, pageNumber, size)
+	if err != nil {
+		return nil, err
+	}
+	lists, _ := response.Object.([]domain.MovieList)
+	return &page{Items: pointers(lists), Page: response.PageNumber, Size: response.PageSize, Total: response.TotalSize}, nil
+}
+
+// pageOf reads the page and size arguments of a paged field. A size over
+// the most a page holds asks for a full page.
+func pageOf(p graphql.ResolveParams) (int, int, error) {
+	pageNumber, size := p.Args["page"].(int), p.Args["size"].(int)
+	var details []domain.ErrorDetail
+	if pageNumber < 1 {
+		details = append(details, domain.NewErrorDetail(domain.CodeFieldTooSmall, "page must be at least 1", "field", "page", "min", "1"))
+	}
+	if size < 1 {
+		details = append(details, domain.NewErrorDetail(domain.CodeFieldTooSmall, "size must be at least 1", "field", "size", "min", "1"))
+	}
+	if len(details) > 0 {
+		return 0, 0, domain.Validation(domain.CodeValidationFailed, "Validation failed", details...)
+	}
+	return pageNumber, min(size, domain.MaxPageSize), nil
+}
+
+func resolveUserID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*domain.User).ID.Hex(), nil
+}
+
+func resolveUserEmail(p graphql.ResolveParams) (interface{}, error) {
+	user := p.Source.(*domain.User)
+	if user.ID.Hex() != stateOf(p.Context).viewer.UserID {
+		return nil, nil
+	}
+	return user.Email, nil
+}
+
+func resolvePersonName(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(string), nil
+}
+
+func resolveMovieID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*domain.Movie).ID.Hex(), nil
+}
+
+func resolveMovieYear(p graphql.ResolveParams) (interface{}, error) {
+	if year := p.Source.(*domain.Movie).Year; year != 0 {
+		return year, nil
+	}
+	return nil, nil
+}
+
+func resolveMovieIMDbID(p graphql.ResolveParams) (interface{}, error) {
+	return optional(p.Source.(*domain.Movie).IMDbID), nil
+}
+
+func resolveMovieTrailer(p graphql.ResolveParams) (interface{}, error) {
+	return optional(p.Source.(*domain.Movie).Trailer), nil
+}
+
+func resolveMoviePoster(p graphql.ResolveParams) (interface{}, error) {
+	return optional(p.Source.(*domain.Movie).PosterURL()), nil
+}
+
+func resolveMovieAddedBy(p graphql.ResolveParams) (interface{}, error) {
+	movie := p.Source.(*domain.Movie)
+	if movie.UserID.IsZero() {
+		return nil, nil
+	}
+	return stateOf(p.Context).users.load(p.Context, movie.UserID), nil
+}
+
+func resolveMovieReviews(p graphql.ResolveParams) (interface{}, error) {
+	first := p.Args["first"].(int)
+	if first < 1 {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed",
+			domain.NewErrorDetail(domain.CodeFieldTooSmall, "first must be at least 1", "field", "first", "min", "1"))
+	}
+	key := reviewsKey{movieID: p.Source.(*domain.Movie).ID, first: min(first, domain.MaxPageSize)}
+	return stateOf(p.Context).reviews.load(p.Context, key), nil
+}
+
+func resolveReviewID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*domain.Review).ID.Hex(), nil
+}
+
+func resolveReviewUser(p graphql.ResolveParams) (interface{}, error) {
+	return stateOf(p.Context).users.load(p.Context, p.Source.(*domain.Review).UserID), nil
+}
+
+func resolveReviewMovie(p graphql.ResolveParams) (interface{}, error) {
+	return stateOf(p.Context).movies.load(p.Context, p.Source.(*domain.Review).MovieID.Hex()), nil
+}
+
+func resolveListID(p graphql.ResolveParams) (interface{}, error) {
+	return p.Source.(*domain.MovieList).ID.Hex(), nil
+}
+
+func resolveListOwner(p graphql.ResolveParams) (interface{}, error) {
+	return stateOf(p.Context).users.load(p.Context, p.Source.(*domain.MovieList).UserID), nil
+}
+
+func resolveListEntries(p graphql.ResolveParams) (interface{}, error) {
+	return pointers(p.Source.(*domain.MovieList).Entries), nil
+}
+
+func resolveListEntryMovie(p graphql.ResolveParams) (interface{}, error) {
+	return stateOf(p.Context).movies.load(p.Context, p.Source.(*domain.ListEntry).MovieID.Hex()), nil
+}
+
+// optional is null for an empty string.
+func optional(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// pointers points at the items of a slice, which resolvers take their
+// sources as.
+func pointers[T any](items []T) []*T {
+	pointed := make([]*T, len(items))
+	for i := range items {
+		pointed[i] = &items[i]
+	}
+	return pointed
+}
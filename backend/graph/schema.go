@@ -0,0 +1,272 @@
+package graph
+
+import (
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"github.com/AfomiaTadesse/Afomia_M/backend/usecase"
+	"github.com/graphql-go/graphql"
+)
+
+// Schema is the GraphQL API over movies, their people and reviews, users
+// and lists. Top-level queries go through the same usecases as the REST
+// API; the records they refer to, such as a review's user, are batched
+// per level of the query through the repositories.
+type Schema struct {
+	schema graphql.Schema
+	limits Limits
+
+	movieUsecase  usecase.MovieUsecase
+	userUsecase   usecase.UserUsecase
+	reviewUsecase usecase.ReviewUsecase
+	listUsecase   usecase.ListUsecase
+
+	movieRepo  repository.MovieRepository
+	userRepo   repository.UserRepository
+	reviewRepo repository.ReviewRepository
+}
+
+// Limits bound the queries a schema runs. Depth counts how deeply fields
+// nest; complexity counts every field once, and the fields inside a page
+// or a list of reviews once per item asked for.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+func NewSchema(
+	movieUsecase usecase.MovieUsecase,
+	userUsecase usecase.UserUsecase,
+	reviewUsecase usecase.ReviewUsecase,
+	listUsecase usecase.ListUsecase,
+	movieRepo repository.MovieRepository,
+	userRepo repository.UserRepository,
+	reviewRepo repository.ReviewRepository,
+	limits Limits,
+) (*Schema, error) {
+	s := &Schema{
+		limits:        limits,
+		movieUsecase:  movieUsecase,
+		userUsecase:   userUsecase,
+		reviewUsecase: reviewUsecase,
+		listUsecase:   listUsecase,
+		movieRepo:     movieRepo,
+		userRepo:      userRepo,
+		reviewRepo:    reviewRepo,
+	}
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: s.queryType()})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// pageArgs are the arguments of a paged field.
+var pageArgs = graphql.FieldConfigArgument{
+	"page": {Type: graphql.Int, DefaultValue: 1, Description: "Page number, from 1"},
+	"size": {Type: graphql.Int, DefaultValue: 10, Description: "Items per page"},
+}
+
+func withPageArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	for name, arg := range pageArgs {
+		args[name] = arg
+	}
+	return args
+}
+
+func nonNull(t graphql.Output) graphql.Output {
+	return graphql.NewNonNull(t)
+}
+
+func listOf(t graphql.Output) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+func (s *Schema) queryType() *graphql.Object {
+	types := s.objectTypes()
+
+	movieSort := graphql.NewEnum(graphql.EnumConfig{
+		Name: "MovieSort",
+		Values: graphql.EnumValueConfigMap{
+			"POPULAR": {Value: domain.MovieSortPopular, Description: "Most liked first"},
+		},
+	})
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": {
+				Type:        nonNull(types.user),
+				Description: "The signed-in user",
+				Resolve:     s.resolveMe,
+			},
+			"user": {
+				Type:    types.user,
+				Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve: s.resolveUser,
+			},
+			"movie": {
+				Type:        types.movie,
+				Description: "A movie; the ID of a movie merged into another finds that one",
+				Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     s.resolveMovie,
+			},
+			"movies": {
+				Type: nonNull(types.moviePage),
+				Args: withPageArgs(graphql.FieldConfigArgument{
+					"sort": {Type: movieSort, Description: "Insertion order when left out"},
+				}),
+				Resolve: s.resolveMovies,
+			},
+			"searchMovies": {
+				Type:        nonNull(types.moviePage),
+				Description: "Movies whose title, in any language, matches",
+				Args:        withPageArgs(graphql.FieldConfigArgument{"title": {Type: graphql.NewNonNull(graphql.String)}}),
+				Resolve:     s.resolveSearchMovies,
+			},
+			"reviews": {
+				Type:    nonNull(types.reviewPage),
+				Args:    withPageArgs(graphql.FieldConfigArgument{"movieId": {Type: graphql.NewNonNull(graphql.ID)}}),
+				Resolve: s.resolveReviews,
+			},
+			"list": {
+				Type:        types.list,
+				Description: "One of the signed-in user's lists, or a public one",
+				Args:        graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     s.resolveList,
+			},
+			"myLists": {
+				Type:    nonNull(types.listPage),
+				Args:    withPageArgs(graphql.FieldConfigArgument{}),
+				Resolve: s.resolveMyLists,
+			},
+		},
+	})
+}
+
+// objectTypes are the types queries return.
+type objectTypes struct {
+	user, movie, review, list       *graphql.Object
+	moviePage, reviewPage, listPage *graphql.Object
+}
+
+func (s *Schema) objectTypes() objectTypes {
+	var types objectTypes
+
+	types.user = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveUserID},
+			"username": {Type: graphql.NewNonNull(graphql.String)},
+			"email": {
+				Type:        graphql.String,
+				Description: "Only shown to the user themselves",
+				Resolve:     resolveUserEmail,
+			},
+		},
+	})
+
+	person := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Person",
+		Description: "Someone in a movie's cast or crew. People are known by name only.",
+		Fields: graphql.Fields{
+			"name": {Type: graphql.NewNonNull(graphql.String), Resolve: resolvePersonName},
+		},
+	})
+
+	// Movies and reviews refer to each other, so their fields are built
+	// once both types exist
+	types.movie = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Movie",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":            {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveMovieID},
+				"title":         {Type: graphql.NewNonNull(graphql.String)},
+				"description":   {Type: graphql.NewNonNull(graphql.String)},
+				"locale":        {Type: graphql.NewNonNull(graphql.String), Description: "The language title and description are shown in"},
+				"year":          {Type: graphql.Int, Resolve: resolveMovieYear},
+				"imdbId":        {Type: graphql.String, Resolve: resolveMovieIMDbID},
+				"trailer":       {Type: graphql.String, Resolve: resolveMovieTrailer},
+				"posterUrl":     {Type: graphql.String, Resolve: resolveMoviePoster},
+				"genres":        {Type: listOf(graphql.String)},
+				"actors":        {Type: listOf(person)},
+				"crew":          {Type: listOf(person)},
+				"averageRating": {Type: graphql.NewNonNull(graphql.Float)},
+				"ratingCount":   {Type: graphql.NewNonNull(graphql.Int)},
+				"likeCount":     {Type: graphql.NewNonNull(graphql.Int)},
+				"addedBy":       {Type: types.user, Resolve: resolveMovieAddedBy},
+				"reviews": {
+					Type:        listOf(types.review),
+					Description: "The newest reviews",
+					Args:        graphql.FieldConfigArgument{"first": {Type: graphql.Int, DefaultValue: 10}},
+					Resolve:     resolveMovieReviews,
+				},
+			}
+		}),
+	})
+
+	types.review = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Review",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":        {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveReviewID},
+				"rating":    {Type: graphql.NewNonNull(graphql.Float)},
+				"text":      {Type: graphql.String},
+				"spoiler":   {Type: graphql.NewNonNull(graphql.Boolean)},
+				"createdAt": {Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt": {Type: graphql.NewNonNull(graphql.DateTime)},
+				"user":      {Type: types.user, Resolve: resolveReviewUser},
+				"movie":     {Type: types.movie, Resolve: resolveReviewMovie},
+			}
+		}),
+	})
+
+	listEntry := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ListEntry",
+		Fields: graphql.Fields{
+			"note":    {Type: graphql.String},
+			"addedAt": {Type: graphql.NewNonNull(graphql.DateTime)},
+			"movie":   {Type: types.movie, Resolve: resolveListEntryMovie},
+		},
+	})
+
+	types.list = graphql.NewObject(graphql.ObjectConfig{
+		Name: "List",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveListID},
+			"name":        {Type: graphql.NewNonNull(graphql.String)},
+			"description": {Type: graphql.NewNonNull(graphql.String)},
+			"visibility":  {Type: graphql.NewNonNull(graphql.String)},
+			"createdAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":   {Type: graphql.NewNonNull(graphql.DateTime)},
+			"owner":       {Type: types.user, Resolve: resolveListOwner},
+			"entries":     {Type: listOf(listEntry), Resolve: resolveListEntries},
+		},
+	})
+
+	types.moviePage = pageType("MoviePage", types.movie)
+	types.reviewPage = pageType("ReviewPage", types.review)
+	types.listPage = pageType("ListPage", types.list)
+	return types
+}
+
+// page is a page of items and where it sits among them.
+type page struct {
+	Items interface{} `json:"items"`
+	Page  int         `json:"page"`
+	Size  int         `json:"size"`
+	Total int64       `json:"total"`
+}
+
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"items": {Type: listOf(item)},
+			"page":  {Type: graphql.NewNonNull(graphql.Int)},
+			"size":  {Type: graphql.NewNonNull(graphql.Int)},
+			"total": {Type: graphql.NewNonNull(graphql.Int), Description: "Items on all pages"},
+		},
+	})
+}
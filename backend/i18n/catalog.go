@@ -0,0 +1,302 @@
+package i18n
+
+import "strings"
+
+// SourceLocale is the language messages are written in. A request for it
+// gets a message exactly as the code produced it.
+const SourceLocale = "en"
+
+// Translate returns the message for an error code in the first locale of
+// chain that has one, with {name} placeholders filled in from params.
+// message is the English text, used when no locale before English in the
+// chain has a translation.
+func Translate(chain []string, code, message string, params map[string]string) string {
+	for _, locale := range chain {
+		if locale == SourceLocale {
+			break
+		}
+		if template, ok := catalog[locale][code]; ok {
+			return fill(template, params)
+		}
+	}
+	return message
+}
+
+func fill(template string, params map[string]string) string {
+	if len(params) == 0 {
+		return template
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// catalog holds the translations of error messages by locale and code.
+// Codes missing from a locale fall back to the next locale a request
+// accepts, and finally to English.
+var catalog = map[string]map[string]string{
+	"fr": {
+		"internal_error":         "Erreur interne du serveur",
+		"invalid_request_body":   "Corps de la requête invalide",
+		"validation_failed":      "La validation a échoué",
+		"invalid_user_id":        "Identifiant d'utilisateur invalide",
+		"invalid_cursor":         "Curseur invalide",
+		"invalid_date_filter":    "Filtre de date invalide",
+		"invalid_sort":           "Ordre de tri invalide",
+		"invalid_window":         "Période invalide",
+		"invalid_lookup":         "Recherche invalide",
+		"invalid_mapping":        "Correspondance de colonnes invalide",
+		"invalid_trailer":        "URL de bande-annonce invalide",
+		"invalid_translations":   "Traductions invalides",
+		"invalid_poster":         "Image d'affiche invalide",
+		"invalid_export_request": "Demande d'export invalide",
+		"invalid_credentials":    "E-mail ou mot de passe invalide",
+		"invalid_invite":         "Le lien d'invitation est invalide ou a été révoqué",
+		"unsupported_format":     "Format non pris en charge",
+		"file_required":          "Un fichier est requis",
+		"file_too_large":         "Le fichier est trop volumineux",
+		"request_too_large":      "Le corps de la requête est trop volumineux",
+		"file_unreadable":        "Impossible de lire le fichier",
+		"import_file_empty":      "Le fichier d'import ne contient aucune ligne",
+		"admin_required":         "Accès administrateur requis",
+		"authorization_missing":  "En-tête Authorization manquant",
+		"bearer_token_missing":   "Jeton Bearer introuvable",
+		"invalid_token":          "Jeton invalide",
+		"invalid_token_claims":   "Revendications du jeton invalides",
+		"invalid_token_user":     "Identifiant d'utilisateur invalide dans le jeton",
+		"invalid_query":          "Requête GraphQL invalide",
+		"query_too_deep":         "La requête est imbriquée trop profondément",
+		"query_too_complex":      "La requête est trop complexe",
+
+		"route_not_found":           "Aucune ressource à cette adresse",
+		"movie_not_found":           "Film introuvable",
+		"movies_not_found":          "Certains films sont introuvables",
+		"canonical_movie_not_found": "Film de référence introuvable",
+		"user_not_found":            "Utilisateur introuvable",
+		"review_not_found":          "Critique introuvable",
+		"list_not_found":            "Liste introuvable",
+		"collection_not_found":      "Collection introuvable",
+		"member_not_found":          "Membre introuvable",
+		"comment_not_found":         "Commentaire introuvable",
+		"parent_comment_not_found":  "Commentaire parent introuvable",
+		"diary_entry_not_found":     "Entrée du journal introuvable",
+		"import_job_not_found":      "Import introuvable",
+		"export_not_found":          "Export introuvable",
+		"poster_not_found":          "Affiche introuvable",
+		"metadata_not_found":        "Aucune métadonnée trouvée pour ce film",
+		"erasure_not_requested":     "Aucune suppression de compte n'a été demandée",
+		"erasure_not_scheduled":     "Aucune suppression de compte n'est programmée",
+		"not_liked":                 "Vous n'aimez pas ce film",
+		"not_following":             "Vous ne suivez pas cet utilisateur",
+		"not_on_watchlist":          "Ce film n'est pas dans votre liste à voir",
+		"not_on_list":               "Ce film n'est pas dans cette liste",
+
+		"movie_edit_forbidden":        "Vous n'êtes pas autorisé à modifier ce film",
+		"movie_delete_forbidden":      "Vous n'êtes pas autorisé à supprimer ce film",
+		"list_edit_forbidden":         "Vous n'êtes pas autorisé à modifier cette liste",
+		"collection_manage_forbidden": "Vous n'êtes pas autorisé à gérer cette collection",
+		"collection_add_forbidden":    "Vous n'êtes pas autorisé à ajouter des films à cette collection",
+		"collection_remove_forbidden": "Vous n'êtes pas autorisé à retirer des films de cette collection",
+		"comment_edit_forbidden":      "Vous n'êtes pas autorisé à modifier ce commentaire",
+		"comment_delete_forbidden":    "Vous n'êtes pas autorisé à supprimer ce commentaire",
+
+		"email_taken":          "Cet e-mail est déjà utilisé",
+		"username_taken":       "Ce nom d'utilisateur est déjà pris",
+		"already_reviewed":     "Vous avez déjà critiqué ce film",
+		"already_liked":        "Vous aimez déjà ce film",
+		"already_following":    "Vous suivez déjà cet utilisateur",
+		"already_on_watchlist": "Ce film est déjà dans votre liste à voir",
+		"already_member":       "Vous êtes déjà membre de cette collection",
+		"user_already_member":  "Cet utilisateur est déjà membre de cette collection",
+		"cannot_follow_self":   "Vous ne pouvez pas vous suivre vous-même",
+		"owner_role_fixed":     "Le rôle du propriétaire ne peut pas être modifié",
+		"owner_cannot_leave":   "Le propriétaire ne peut pas quitter la collection",
+		"replies_on_thread":    "Les réponses sont listées sous le commentaire principal du fil",
+		"comment_deleted":      "Un commentaire supprimé ne peut pas être modifié",
+		"comment_rejected":     "Le commentaire a été refusé par le filtre de contenu",
+		"export_in_progress":   "Un export est déjà en cours de préparation",
+		"erasure_started":      "La suppression du compte a déjà commencé",
+		"merge_into_self":      "Un film ne peut pas être fusionné avec lui-même",
+		"metadata_unavailable": "Les métadonnées de films sont indisponibles, réessayez plus tard",
+		"list_changed":         "La liste a été modifiée entre-temps, rechargez-la et réessayez",
+
+		"invalid_id":                "identifiant invalide",
+		"malformed_body":            "le corps de la requête n'est pas du JSON valide",
+		"field_required":            "{field} est obligatoire",
+		"field_too_small":           "{field} doit être au moins {min}",
+		"field_too_large":           "{field} doit être au plus {max}",
+		"field_invalid":             "{field} est invalide",
+		"field_wrong_type":          "{field} doit être de type {type}",
+		"field_empty":               "{field} ne doit pas être vide",
+		"field_too_long":            "{field} doit faire au plus {max} caractères",
+		"field_too_short":           "{field} doit faire au moins {min} caractères",
+		"field_too_few":             "{field} doit contenir au moins {min} éléments",
+		"field_too_many":            "{field} doit contenir au plus {max} éléments",
+		"field_not_unique":          "{field} ne doit pas contenir de doublons",
+		"field_not_url":             "{field} doit être une URL",
+		"field_not_alphanumeric":    "{field} ne doit contenir que des lettres et des chiffres",
+		"field_not_one_of":          "{field} doit valoir l'une des valeurs suivantes : {values}",
+		"field_not_number":          "{field} doit être un nombre entier",
+		"date_invalid":              "{field} doit être une date au format AAAA-MM-JJ",
+		"date_in_future":            "{field} ne peut pas être dans le futur",
+		"date_range_reversed":       "from ne doit pas être après to",
+		"unknown_field":             "champ inconnu {field} dans la correspondance",
+		"unknown_movie":             "aucun film avec l'identifiant {id}",
+		"list_order_mismatch":       "movieIds doit contenir chaque film de la liste exactement une fois",
+		"title_or_imdb_id_required": "title ou imdbId est obligatoire",
+		"set_imdb_id":               "renseignez l'imdbId du film pour trouver la bonne correspondance",
+		"blocked_word":              "contient un mot interdit",
+
+		"invalid_email":              "format d'e-mail invalide",
+		"password_missing_uppercase": "le mot de passe doit contenir au moins une majuscule",
+		"password_missing_lowercase": "le mot de passe doit contenir au moins une minuscule",
+		"password_missing_special":   "le mot de passe doit contenir au moins un caractère spécial",
+
+		"rating_out_of_range": "la note doit être comprise entre 0,5 et 5",
+		"rating_half_step":    "la note doit aller par demi-étoiles",
+
+		"trailer_not_url":     "la bande-annonce doit être une URL http ou https",
+		"trailer_unsupported": "la bande-annonce doit être une vidéo YouTube ou Vimeo ou un lien MP4 direct",
+		"trailer_not_youtube": "la bande-annonce n'est pas un lien vers une vidéo YouTube",
+		"trailer_not_vimeo":   "la bande-annonce n'est pas un lien vers une vidéo Vimeo",
+
+		"locale_unsupported":    "la langue {locale} n'est pas prise en charge ; langues possibles : {values}",
+		"translation_duplicate": "plus d'un titre pour la langue {locale}",
+
+		"poster_unsupported":          "l'affiche doit être une image JPEG, PNG ou GIF",
+		"poster_dimensions_too_large": "les dimensions de l'affiche sont trop grandes",
+	},
+	"am": {
+		"internal_error":         "የአገልጋይ ውስጣዊ ስህተት",
+		"invalid_request_body":   "ልክ ያልሆነ የጥያቄ አካል",
+		"validation_failed":      "ማረጋገጫው አልተሳካም",
+		"invalid_user_id":        "ልክ ያልሆነ የተጠቃሚ መለያ",
+		"invalid_cursor":         "ልክ ያልሆነ ጠቋሚ",
+		"invalid_date_filter":    "ልክ ያልሆነ የቀን ማጣሪያ",
+		"invalid_sort":           "ልክ ያልሆነ የአደራደር ቅደም ተከተል",
+		"invalid_window":         "ልክ ያልሆነ የጊዜ ገደብ",
+		"invalid_lookup":         "ልክ ያልሆነ ፍለጋ",
+		"invalid_mapping":        "ልክ ያልሆነ የአምድ ካርታ",
+		"invalid_trailer":        "ልክ ያልሆነ የማስታወቂያ ቪዲዮ አድራሻ",
+		"invalid_translations":   "ልክ ያልሆኑ ትርጉሞች",
+		"invalid_poster":         "ልክ ያልሆነ የፖስተር ምስል",
+		"invalid_export_request": "ልክ ያልሆነ የመላክ ጥያቄ",
+		"invalid_credentials":    "ኢሜይል ወይም የይለፍ ቃል ልክ አይደለም",
+		"invalid_invite":         "የግብዣ ማገናኛው ልክ አይደለም ወይም ተሰርዟል",
+		"unsupported_format":     "የማይደገፍ ቅርጸት",
+		"file_required":          "ፋይል ያስፈልጋል",
+		"file_too_large":         "ፋይሉ በጣም ትልቅ ነው",
+		"request_too_large":      "የጥያቄው አካል በጣም ትልቅ ነው",
+		"file_unreadable":        "ፋይሉን ማንበብ አልተቻለም",
+		"import_file_empty":      "የሚገባው ፋይል ምንም ረድፍ የለውም",
+		"admin_required":         "የአስተዳዳሪ ፈቃድ ያስፈልጋል",
+		"authorization_missing":  "የAuthorization ራስጌ የለም",
+		"bearer_token_missing":   "የBearer ማስመሰያ አልተገኘም",
+		"invalid_token":          "ልክ ያልሆነ ማስመሰያ",
+		"invalid_token_claims":   "ልክ ያልሆኑ የማስመሰያ መረጃዎች",
+		"invalid_token_user":     "በማስመሰያው ውስጥ ልክ ያልሆነ የተጠቃሚ መለያ",
+		"invalid_query":          "ልክ ያልሆነ የGraphQL ጥያቄ",
+		"query_too_deep":         "ጥያቄው ከመጠን በላይ ጥልቅ ነው",
+		"query_too_complex":      "ጥያቄው ከመጠን በላይ ውስብስብ ነው",
+
+		"route_not_found":           "በዚህ አድራሻ ምንም ነገር የለም",
+		"movie_not_found":           "ፊልሙ አልተገኘም",
+		"movies_not_found":          "አንዳንድ ፊልሞች አልተገኙም",
+		"canonical_movie_not_found": "ዋናው ፊልም አልተገኘም",
+		"user_not_found":            "ተጠቃሚው አልተገኘም",
+		"review_not_found":          "ግምገማው አልተገኘም",
+		"list_not_found":            "ዝርዝሩ አልተገኘም",
+		"collection_not_found":      "ስብስቡ አልተገኘም",
+		"member_not_found":          "አባሉ አልተገኘም",
+		"comment_not_found":         "አስተያየቱ አልተገኘም",
+		"parent_comment_not_found":  "ዋናው አስተያየት አልተገኘም",
+		"diary_entry_not_found":     "የማስታወሻ ደብተር መግቢያው አልተገኘም",
+		"import_job_not_found":      "የማስገባት ሥራው አልተገኘም",
+		"export_not_found":          "የተላከው ፋይል አልተገኘም",
+		"poster_not_found":          "ፖስተሩ አልተገኘም",
+		"metadata_not_found":        "ለዚህ ፊልም ምንም መረጃ አልተገኘም",
+		"erasure_not_requested":     "የመለያ ስረዛ አልተጠየቀም",
+		"erasure_not_scheduled":     "የታቀደ የመለያ ስረዛ የለም",
+		"not_liked":                 "ይህን ፊልም አልወደዱትም",
+		"not_following":             "ይህን ተጠቃሚ አይከተሉም",
+		"not_on_watchlist":          "ፊልሙ በሚታዩ ዝርዝርዎ ውስጥ የለም",
+		"not_on_list":               "ፊልሙ በዚህ ዝርዝር ውስጥ የለም",
+
+		"movie_edit_forbidden":        "ይህን ፊልም ለማስተካከል ፈቃድ የለዎትም",
+		"movie_delete_forbidden":      "ይህን ፊልም ለመሰረዝ ፈቃድ የለዎትም",
+		"list_edit_forbidden":         "ይህን ዝርዝር ለመቀየር ፈቃድ የለዎትም",
+		"collection_manage_forbidden": "ይህን ስብስብ ለማስተዳደር ፈቃድ የለዎትም",
+		"collection_add_forbidden":    "ወደዚህ ስብስብ ፊልሞችን ለመጨመር ፈቃድ የለዎትም",
+		"collection_remove_forbidden": "ከዚህ ስብስብ ፊልሞችን ለማስወጣት ፈቃድ የለዎትም",
+		"comment_edit_forbidden":      "ይህን አስተያየት ለማስተካከል ፈቃድ የለዎትም",
+		"comment_delete_forbidden":    "ይህን አስተያየት ለመሰረዝ ፈቃድ የለዎትም",
+
+		"email_taken":          "ኢሜይሉ አስቀድሞ ተመዝግቧል",
+		"username_taken":       "የተጠቃሚ ስሙ አስቀድሞ ተይዟል",
+		"already_reviewed":     "ይህን ፊልም አስቀድመው ገምግመዋል",
+		"already_liked":        "ይህን ፊልም አስቀድመው ወደውታል",
+		"already_following":    "ይህን ተጠቃሚ አስቀድመው ይከተላሉ",
+		"already_on_watchlist": "ፊልሙ አስቀድሞ በሚታዩ ዝርዝርዎ ውስጥ አለ",
+		"already_member":       "አስቀድመው የዚህ ስብስብ አባል ነዎት",
+		"user_already_member":  "ተጠቃሚው አስቀድሞ የዚህ ስብስብ አባል ነው",
+		"cannot_follow_self":   "ራስዎን መከተል አይችሉም",
+		"owner_role_fixed":     "የባለቤቱ ሚና ሊቀየር አይችልም",
+		"owner_cannot_leave":   "ባለቤቱ ስብስቡን መልቀቅ አይችልም",
+		"replies_on_thread":    "ምላሾች የሚዘረዘሩት በውይይቱ ዋና አስተያየት ስር ነው",
+		"comment_deleted":      "የተሰረዙ አስተያየቶች ሊስተካከሉ አይችሉም",
+		"comment_rejected":     "አስተያየቱ በይዘት ማጣሪያው ውድቅ ተደርጓል",
+		"export_in_progress":   "መላክ አስቀድሞ በዝግጅት ላይ ነው",
+		"erasure_started":      "የመለያ ስረዛው አስቀድሞ ተጀምሯል",
+		"merge_into_self":      "ፊልም ከራሱ ጋር ሊዋሃድ አይችልም",
+		"metadata_unavailable": "የፊልም መረጃ አሁን አይገኝም፤ ቆይተው እንደገና ይሞክሩ",
+		"list_changed":         "ዝርዝሩ በመሃል ተቀይሯል፤ እንደገና ጭነው ይሞክሩ",
+
+		"invalid_id":                "ልክ ያልሆነ መለያ",
+		"malformed_body":            "የጥያቄው አካል ትክክለኛ JSON አይደለም",
+		"field_required":            "{field} ያስፈልጋል",
+		"field_too_small":           "{field} ቢያንስ {min} መሆን አለበት",
+		"field_too_large":           "{field} ቢበዛ {max} መሆን አለበት",
+		"field_invalid":             "{field} ልክ አይደለም",
+		"field_wrong_type":          "{field} {type} መሆን አለበት",
+		"field_empty":               "{field} ባዶ መሆን የለበትም",
+		"field_too_long":            "{field} ቢበዛ {max} ፊደላት መሆን አለበት",
+		"field_too_short":           "{field} ቢያንስ {min} ፊደላት መሆን አለበት",
+		"field_too_few":             "{field} ቢያንስ {min} ንጥሎች ሊኖሩት ይገባል",
+		"field_too_many":            "{field} ቢበዛ {max} ንጥሎች ሊኖሩት ይገባል",
+		"field_not_unique":          "{field} ተደጋጋሚ እሴቶችን መያዝ የለበትም",
+		"field_not_url":             "{field} የድር አድራሻ መሆን አለበት",
+		"field_not_alphanumeric":    "{field} ፊደላትና ቁጥሮችን ብቻ መያዝ አለበት",
+		"field_not_one_of":          "{field} ከሚከተሉት አንዱ መሆን አለበት፦ {values}",
+		"field_not_number":          "{field} ሙሉ ቁጥር መሆን አለበት",
+		"date_invalid":              "{field} በ YYYY-MM-DD ቅርጸት ቀን መሆን አለበት",
+		"date_in_future":            "{field} ወደፊት ያለ ቀን መሆን አይችልም",
+		"date_range_reversed":       "from ከ to በኋላ መሆን የለበትም",
+		"unknown_field":             "በካርታው ውስጥ ያልታወቀ መስክ {field}",
+		"unknown_movie":             "{id} መለያ ያለው ፊልም የለም",
+		"list_order_mismatch":       "movieIds በዝርዝሩ ያለውን እያንዳንዱን ፊልም አንድ ጊዜ ብቻ መያዝ አለበት",
+		"title_or_imdb_id_required": "title ወይም imdbId ያስፈልጋል",
+		"set_imdb_id":               "ትክክለኛውን ለማግኘት የፊልሙን imdbId ያስገቡ",
+		"blocked_word":              "የተከለከለ ቃል ይዟል",
+
+		"invalid_email":              "የኢሜይል ቅርጸቱ ልክ አይደለም",
+		"password_missing_uppercase": "የይለፍ ቃል ቢያንስ አንድ ትልቅ ፊደል መያዝ አለበት",
+		"password_missing_lowercase": "የይለፍ ቃል ቢያንስ አንድ ትንሽ ፊደል መያዝ አለበት",
+		"password_missing_special":   "የይለፍ ቃል ቢያንስ አንድ ልዩ ምልክት መያዝ አለበት",
+
+		"rating_out_of_range": "ደረጃው ከ 0.5 እስከ 5 መሆን አለበት",
+		"rating_half_step":    "ደረጃው በግማሽ ኮከብ ደረጃዎች መሆን አለበት",
+
+		"trailer_not_url":     "የማስታወቂያ ቪዲዮው http ወይም https አድራሻ መሆን አለበት",
+		"trailer_unsupported": "የማስታወቂያ ቪዲዮው የ YouTube ወይም Vimeo ቪዲዮ ወይም ቀጥተኛ የ MP4 ማገናኛ መሆን አለበት",
+		"trailer_not_youtube": "የማስታወቂያ ቪዲዮው ወደ YouTube ቪዲዮ የሚወስድ ማገናኛ አይደለም",
+		"trailer_not_vimeo":   "የማስታወቂያ ቪዲዮው ወደ Vimeo ቪዲዮ የሚወስድ ማገናኛ አይደለም",
+
+		"locale_unsupported":    "ቋንቋ {locale} አይደገፍም፤ የሚደገፉት፦ {values}",
+		"translation_duplicate": "ለቋንቋ {locale} ከአንድ በላይ ርዕስ ተሰጥቷል",
+
+		"poster_unsupported":          "ፖስተሩ JPEG፣ PNG ወይም GIF ምስል መሆን አለበት",
+		"poster_dimensions_too_large": "የፖስተሩ መጠን በጣም ትልቅ ነው",
+	},
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// untranslatedCodes are error codes whose message is the text of an
+// arbitrary error, which has no fixed translation.
+var untranslatedCodes = map[string]bool{
+	"invalid_value": true,
+}
+
+// errorCodes reads the values of the Code constants in the domain package.
+func errorCodes(t *testing.T) []string {
+	t.Helper()
+	paths, err := filepath.Glob("../domain/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var codes []string
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			spec, ok := node.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if !strings.HasPrefix(name.Name, "Code") || i >= len(spec.Values) {
+					continue
+				}
+				if literal, ok := spec.Values[i].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+					code, _ := strconv.Unquote(literal.Value)
+					codes = append(codes, code)
+				}
+			}
+			return false
+		})
+	}
+	if len(codes) == 0 {
+		t.Fatal("no error codes found in the domain package")
+	}
+	return codes
+}
+
+func TestCatalogCoversErrorCodes(t *testing.T) {
+	codes := errorCodes(t)
+	known := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		known[code] = true
+	}
+
+	for _, locale := range []string{"fr", "am"} {
+		for _, code := range codes {
+			if _, ok := catalog[locale][code]; !ok && !untranslatedCodes[code] {
+				t.Errorf("%s has no translation of %s", locale, code)
+			}
+		}
+		for code := range catalog[locale] {
+			if !known[code] {
+				t.Errorf("%s translates %s, which is not an error code", locale, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Package i18n picks the language a response is written in.
+package i18n
+
+import (
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// Locales are the languages content can be written in, as lowercase
+// ISO 639-1 codes such as "en", "am" and "fr". Default is used when a
+// request asks for none of them, and is the language of movies saved
+// before they had one.
+type Locales struct {
+	Default   string
+	Supported []string
+}
+
+// NewLocales normalizes the codes, adding the default to the supported
+// locales if it is missing.
+func NewLocales(defaultLocale string, supported []string) *Locales {
+	locales := &Locales{Default: strings.ToLower(strings.TrimSpace(defaultLocale))}
+	for _, locale := range append([]string{locales.Default}, supported...) {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(locales.Supported, locale) {
+			locales.Supported = append(locales.Supported, locale)
+		}
+	}
+	return locales
+}
+
+// IsSupported reports whether locale is one of the supported codes.
+func (l *Locales) IsSupported(locale string) bool {
+	return slices.Contains(l.Supported, locale)
+}
+
+// Chain lists the supported locales a request asks for, best first: lang,
+// then the languages in an Accept-Language header by preference. Regional
+// variants fall back to their language, so "fr-CA" asks for "fr". The
+// chain always ends with the default locale.
+func (l *Locales) Chain(lang, acceptLanguage string) []string {
+	var tags []language.Tag
+	if tag, err := language.Parse(strings.TrimSpace(lang)); err == nil {
+		tags = append(tags, tag)
+	}
+	if accepted, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil {
+		tags = append(tags, accepted...)
+	}
+
+	chain := make([]string, 0, len(tags)+1)
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		locale := base.String()
+		if l.IsSupported(locale) && !slices.Contains(chain, locale) {
+			chain = append(chain, locale)
+		}
+	}
+	if !slices.Contains(chain, l.Default) {
+		chain = append(chain, l.Default)
+	}
+	return chain
+}
@@ -0,0 +1,39 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewLocales(t *testing.T) {
+	locales := NewLocales(" EN ", []string{"am", "fr", "en", " ", "FR"})
+	if locales.Default != "en" || strings.Join(locales.Supported, ",") != "en,am,fr" {
+		t.Errorf("locales = %q of %q, want en of en,am,fr", locales.Default, locales.Supported)
+	}
+}
+
+func TestLocalesChain(t *testing.T) {
+	locales := NewLocales("en", []string{"am", "fr"})
+
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "nothing asked for", want: "en"},
+		{name: "lang alone", lang: "fr", want: "fr,en"},
+		{name: "Accept-Language by preference", acceptLanguage: "am;q=0.5, fr;q=0.9", want: "fr,am,en"},
+		{name: "lang wins over Accept-Language", lang: "am", acceptLanguage: "fr", want: "am,fr,en"},
+		{name: "regional variants fall back to their language", acceptLanguage: "fr-CA, en-GB;q=0.8", want: "fr,en"},
+		{name: "unsupported languages are skipped", lang: "de", acceptLanguage: "es, am;q=0.1", want: "am,en"},
+		{name: "repeats are dropped", lang: "FR", acceptLanguage: "fr-FR, fr;q=0.5", want: "fr,en"},
+		{name: "malformed input is ignored", lang: "not a tag!", acceptLanguage: ";;;", want: "en"},
+	}
+
+	for _, tt := range tests {
+		if got := strings.Join(locales.Chain(tt.lang, tt.acceptLanguage), ","); got != tt.want {
+			t.Errorf("%s: Chain(%q, %q) = %s, want %s", tt.name, tt.lang, tt.acceptLanguage, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package metadata
+
+import (
+	"sync"
+	"time"
+)
+
+// responseCache keeps provider responses for a while, so repeated lookups
+// of the same movie cost no requests. When full, expired entries are
+// dropped first, then the ones closest to expiring.
+type responseCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	entries    map[string]cachedResponse
+	now        func() time.Time
+}
+
+type cachedResponse struct {
+	status  int
+	body    []byte
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration, maxEntries int) *responseCache {
+	return &responseCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]cachedResponse),
+		now:        time.Now,
+	}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) put(key string, status int, body []byte) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		for len(c.entries) >= c.maxEntries {
+			oldest := ""
+			for k, entry := range c.entries {
+				if oldest == "" || entry.expires.Before(c.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[key] = cachedResponse{status: status, body: body, expires: now.Add(c.ttl)}
+}
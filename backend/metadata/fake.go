@@ -0,0 +1,154 @@
+package metadata
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/tmdb_movies.json
+var tmdbFixtures []byte
+
+// fakeTMDb answers the TMDb API calls the client makes from a few fixture
+// movies, so the client can be developed and tried offline. Posters are
+// plain generated images under /images/.
+type fakeTMDb struct {
+	movies []tmdbMovie
+
+	// Requests beyond requestsPerSecond in any one second get a 429.
+	requestsPerSecond int
+	mu                sync.Mutex
+	window            time.Time
+	count             int
+}
+
+// NewFakeTMDb returns a handler serving the fixture movies, for use as
+// TMDB_API_URL, with TMDB_IMAGE_URL set to its /images path. A positive
+// requestsPerSecond makes it rate limit like the real API.
+func NewFakeTMDb(requestsPerSecond int) http.Handler {
+	fake := &fakeTMDb{requestsPerSecond: requestsPerSecond}
+	if err := json.Unmarshal(tmdbFixtures, &fake.movies); err != nil {
+		panic("metadata: invalid TMDb fixtures: " + err.Error())
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /search/movie", fake.search)
+	mux.HandleFunc("GET /movie/{id}", fake.movie)
+	mux.HandleFunc("GET /find/{externalId}", fake.find)
+	mux.HandleFunc("GET /images/{file}", fake.poster)
+	return fake.limit(mux)
+}
+
+func (f *fakeTMDb) limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if f.requestsPerSecond > 0 && !strings.HasPrefix(r.URL.Path, "/images/") {
+			f.mu.Lock()
+			now := time.Now().Truncate(time.Second)
+			if !now.Equal(f.window) {
+				f.window, f.count = now, 0
+			}
+			f.count++
+			limited := f.count > f.requestsPerSecond
+			f.mu.Unlock()
+
+			if limited {
+				w.Header().Set("Retry-After", "1")
+				writeFakeJSON(w, http.StatusTooManyRequests, map[string]interface{}{
+					"status_code":    25,
+					"status_message": "Your request count is over the allowed limit.",
+				})
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (f *fakeTMDb) search(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	year := r.URL.Query().Get("year")
+
+	results := []tmdbMovie{}
+	for _, movie := range f.movies {
+		if query == "" || !strings.Contains(strings.ToLower(movie.Title), query) {
+			continue
+		}
+		if year != "" && !strings.HasPrefix(movie.ReleaseDate, year) {
+			continue
+		}
+		results = append(results, summary(movie))
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"page":          1,
+		"results":       results,
+		"total_pages":   1,
+		"total_results": len(results),
+	})
+}
+
+func (f *fakeTMDb) movie(w http.ResponseWriter, r *http.Request) {
+	for _, movie := range f.movies {
+		if strconv.Itoa(movie.ID) == r.PathValue("id") {
+			writeFakeJSON(w, http.StatusOK, movie)
+			return
+		}
+	}
+	writeFakeJSON(w, http.StatusNotFound, map[string]interface{}{
+		"status_code":    34,
+		"status_message": "The resource you requested could not be found.",
+	})
+}
+
+func (f *fakeTMDb) find(w http.ResponseWriter, r *http.Request) {
+	results := []tmdbMovie{}
+	for _, movie := range f.movies {
+		if movie.IMDbID == r.PathValue("externalId") {
+			results = append(results, summary(movie))
+		}
+	}
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"movie_results": results})
+}
+
+// poster draws a solid poster-shaped PNG, its colour derived from the name.
+func (f *fakeTMDb) poster(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(strings.TrimSuffix(r.PathValue("file"), ".png"))
+	shade := color.RGBA{R: uint8(id * 37), G: uint8(id * 91), B: uint8(id * 53), A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, 500, 750))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = shade.R, shade.G, shade.B, shade.A
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(buf.Bytes())
+}
+
+// summary strips a movie down to what search results carry.
+func summary(movie tmdbMovie) tmdbMovie {
+	return tmdbMovie{
+		ID:          movie.ID,
+		Title:       movie.Title,
+		Overview:    movie.Overview,
+		ReleaseDate: movie.ReleaseDate,
+		PosterPath:  movie.PosterPath,
+	}
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,32 @@
+// Package metadata looks movies up in external databases such as TMDb to
+// prefill and enrich the collection.
+package metadata
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+var (
+	// ErrNotFound is returned when the provider has no such movie.
+	ErrNotFound = errors.New("movie not found at metadata provider")
+	// ErrRateLimited is returned when the provider still turns requests
+	// away after backing off.
+	ErrRateLimited = errors.New("metadata provider rate limit exceeded")
+)
+
+// Provider is a source of movie metadata. IDs are the provider's own, as
+// found in MovieMetadata.SourceID.
+type Provider interface {
+	// Search finds movies by title, best match first. A year of 0 matches
+	// any year. Results are summaries without genres or credits.
+	Search(ctx context.Context, title string, year int) ([]domain.MovieMetadata, error)
+	// Movie returns the full details of a movie.
+	Movie(ctx context.Context, id string) (*domain.MovieMetadata, error)
+	// FindByIMDbID returns the full details of the movie with an IMDb ID.
+	FindByIMDbID(ctx context.Context, imdbID string) (*domain.MovieMetadata, error)
+	// Poster downloads the image at a movie's PosterURL.
+	Poster(ctx context.Context, movie *domain.MovieMetadata) ([]byte, error)
+}
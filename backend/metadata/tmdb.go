@@ -0,0 +1,345 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+// TMDbSource names TMDb in MovieMetadata.Source.
+const TMDbSource = "tmdb"
+
+const (
+	tmdbMaxRetries     = 3
+	tmdbMaxActors      = 10
+	tmdbMaxBody        = 5 << 20
+	tmdbMaxPoster      = 20 << 20
+	tmdbCacheEntries   = 1000
+	tmdbDefaultBackoff = time.Second
+)
+
+// tmdbCrewJobs are the crew credits kept on a movie.
+var tmdbCrewJobs = map[string]bool{"Director": true, "Screenplay": true, "Writer": true}
+
+// TMDbConfig points a TMDb client at the API. BaseURL is the API root, e.g.
+// "https://api.themoviedb.org/3", and ImageURL the root poster paths are
+// relative to, e.g. "https://image.tmdb.org/t/p/original". Both can point
+// at a local fake server instead. Token is an API read access token.
+type TMDbConfig struct {
+	BaseURL           string
+	ImageURL          string
+	Token             string
+	RequestsPerSecond int
+	CacheTTL          time.Duration
+}
+
+// tmdbClient talks to a TMDb-compatible API. Requests are spaced out to
+// stay under RequestsPerSecond; when the API answers 429 anyway, every
+// request waits out its Retry-After before trying again.
+type tmdbClient struct {
+	config TMDbConfig
+	client *http.Client
+	cache  *responseCache
+
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+func NewTMDbClient(config TMDbConfig) Provider {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	config.ImageURL = strings.TrimRight(config.ImageURL, "/")
+
+	client := &tmdbClient{
+		config: config,
+		client: &http.Client{Timeout: 15 * time.Second},
+		cache:  newResponseCache(config.CacheTTL, tmdbCacheEntries),
+	}
+	if config.RequestsPerSecond > 0 {
+		client.interval = time.Second / time.Duration(config.RequestsPerSecond)
+	}
+	return client
+}
+
+// tmdbMovie is a movie as the API returns it. Search results only carry
+// the summary fields; details add genres, credits, videos and the IMDb ID.
+type tmdbMovie struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Overview    string `json:"overview"`
+	ReleaseDate string `json:"release_date"`
+	PosterPath  string `json:"poster_path"`
+	IMDbID      string `json:"imdb_id,omitempty"`
+	Genres      []struct {
+		Name string `json:"name"`
+	} `json:"genres,omitempty"`
+	Credits *struct {
+		Cast []struct {
+			Name  string `json:"name"`
+			Order int    `json:"order"`
+		} `json:"cast"`
+		Crew []struct {
+			Name string `json:"name"`
+			Job  string `json:"job"`
+		} `json:"crew"`
+	} `json:"credits,omitempty"`
+	Videos *struct {
+		Results []struct {
+			Key      string `json:"key"`
+			Site     string `json:"site"`
+			Type     string `json:"type"`
+			Official bool   `json:"official"`
+		} `json:"results"`
+	} `json:"videos,omitempty"`
+}
+
+func (c *tmdbClient) Search(ctx context.Context, title string, year int) ([]domain.MovieMetadata, error) {
+	query := url.Values{"query": {title}, "include_adult": {"false"}}
+	if year > 0 {
+		query.Set("year", strconv.Itoa(year))
+	}
+
+	var page struct {
+		Results []tmdbMovie `json:"results"`
+	}
+	if err := c.get(ctx, "/search/movie", query, &page); err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.MovieMetadata, 0, len(page.Results))
+	for _, movie := range page.Results {
+		results = append(results, *c.toMetadata(&movie))
+	}
+	return results, nil
+}
+
+func (c *tmdbClient) Movie(ctx context.Context, id string) (*domain.MovieMetadata, error) {
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, ErrNotFound
+	}
+
+	var movie tmdbMovie
+	query := url.Values{"append_to_response": {"credits,videos"}}
+	if err := c.get(ctx, "/movie/"+id, query, &movie); err != nil {
+		return nil, err
+	}
+	return c.toMetadata(&movie), nil
+}
+
+func (c *tmdbClient) FindByIMDbID(ctx context.Context, imdbID string) (*domain.MovieMetadata, error) {
+	var found struct {
+		MovieResults []tmdbMovie `json:"movie_results"`
+	}
+	query := url.Values{"external_source": {"imdb_id"}}
+	if err := c.get(ctx, "/find/"+url.PathEscape(imdbID), query, &found); err != nil {
+		return nil, err
+	}
+	if len(found.MovieResults) == 0 {
+		return nil, ErrNotFound
+	}
+	return c.Movie(ctx, strconv.Itoa(found.MovieResults[0].ID))
+}
+
+func (c *tmdbClient) Poster(ctx context.Context, movie *domain.MovieMetadata) ([]byte, error) {
+	if movie.PosterURL == "" {
+		return nil, ErrNotFound
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, movie.PosterURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tmdb: downloading poster: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, tmdbMaxPoster+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > tmdbMaxPoster {
+		return nil, errors.New("tmdb: poster image too large")
+	}
+	return data, nil
+}
+
+// get fetches an API path into out, from the cache when it can. Not-found
+// answers are cached too, so unknown IDs are not asked about again.
+func (c *tmdbClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	key := path + "?" + query.Encode()
+
+	cached, ok := c.cache.get(key)
+	if !ok {
+		status, body, err := c.fetch(ctx, key)
+		if err != nil {
+			return err
+		}
+		cached = cachedResponse{status: status, body: body}
+		c.cache.put(key, status, body)
+	}
+
+	if cached.status == http.StatusNotFound {
+		return ErrNotFound
+	}
+	if err := json.Unmarshal(cached.body, out); err != nil {
+		return fmt.Errorf("tmdb: decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// fetch requests a path and query, retrying while the API is rate
+// limiting. It returns only 200 and 404 responses.
+func (c *tmdbClient) fetch(ctx context.Context, pathAndQuery string) (int, []byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return 0, nil, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.config.BaseURL+pathAndQuery, nil)
+		if err != nil {
+			return 0, nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return 0, nil, err
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, tmdbMaxBody))
+		resp.Body.Close()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusNotFound:
+			return resp.StatusCode, body, nil
+		case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+			if attempt == tmdbMaxRetries {
+				return 0, nil, ErrRateLimited
+			}
+			c.backOff(retryAfter(resp.Header.Get("Retry-After"), attempt))
+		case http.StatusUnauthorized:
+			return 0, nil, errors.New("tmdb: API token rejected")
+		default:
+			return 0, nil, fmt.Errorf("tmdb: %s", resp.Status)
+		}
+	}
+}
+
+// wait blocks until the client may send its next request.
+func (c *tmdbClient) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	start := c.next
+	if start.Before(now) {
+		start = now
+	}
+	c.next = start.Add(c.interval)
+	c.mu.Unlock()
+
+	delay := start.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// backOff holds every request until delay has passed.
+func (c *tmdbClient) backOff(delay time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(c.next) {
+		c.next = until
+	}
+}
+
+// retryAfter reads a Retry-After header given in seconds or as a date,
+// doubling a default delay per attempt when there is none.
+func retryAfter(header string, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		return time.Until(at)
+	}
+	return tmdbDefaultBackoff << attempt
+}
+
+func (c *tmdbClient) toMetadata(movie *tmdbMovie) *domain.MovieMetadata {
+	metadata := &domain.MovieMetadata{
+		Source:      TMDbSource,
+		SourceID:    strconv.Itoa(movie.ID),
+		Title:       movie.Title,
+		Description: movie.Overview,
+		IMDbID:      movie.IMDbID,
+	}
+	if len(movie.ReleaseDate) >= 4 {
+		metadata.Year, _ = strconv.Atoi(movie.ReleaseDate[:4])
+	}
+	if movie.PosterPath != "" {
+		metadata.PosterURL = c.config.ImageURL + movie.PosterPath
+	}
+	for _, genre := range movie.Genres {
+		metadata.Genres = append(metadata.Genres, genre.Name)
+	}
+
+	if movie.Credits != nil {
+		cast := movie.Credits.Cast
+		sort.SliceStable(cast, func(i, j int) bool { return cast[i].Order < cast[j].Order })
+		for i := 0; i < len(cast) && i < tmdbMaxActors; i++ {
+			metadata.Actors = append(metadata.Actors, cast[i].Name)
+		}
+
+		seen := make(map[string]bool)
+		for _, member := range movie.Credits.Crew {
+			if tmdbCrewJobs[member.Job] && !seen[member.Name] {
+				seen[member.Name] = true
+				metadata.Crew = append(metadata.Crew, member.Name)
+			}
+		}
+	}
+
+	if movie.Videos != nil {
+		official := false
+		for _, video := range movie.Videos.Results {
+			if video.Type != "Trailer" || (metadata.Trailer != "" && (official || !video.Official)) {
+				continue
+			}
+			switch video.Site {
+			case "YouTube":
+				metadata.Trailer = "https://www.youtube.com/watch?v=" + video.Key
+			case "Vimeo":
+				metadata.Trailer = "https://vimeo.com/" + video.Key
+			default:
+				continue
+			}
+			official = video.Official
+		}
+	}
+	return metadata
+}
@@ -0,0 +1,102 @@
+package metadata
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFakeServer serves the fake TMDb API behind limit, which may answer a
+// request itself by returning true, and counts the requests that reach it.
+func newFakeServer(t *testing.T, limit func(w http.ResponseWriter, n int64) bool) (Provider, *atomic.Int64) {
+	var requests atomic.Int64
+	fake := NewFakeTMDb(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if limit != nil && limit(w, n) {
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewTMDbClient(TMDbConfig{
+		BaseURL:  server.URL,
+		ImageURL: server.URL + "/images",
+		Token:    "token",
+		CacheTTL: time.Hour,
+	})
+	return client, &requests
+}
+
+func rateLimited(w http.ResponseWriter, retryAfter string) bool {
+	w.Header().Set("Retry-After", retryAfter)
+	w.WriteHeader(http.StatusTooManyRequests)
+	return true
+}
+
+func TestTMDbCachesResponses(t *testing.T) {
+	client, requests := newFakeServer(t, nil)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		movie, err := client.Movie(ctx, "603")
+		if err != nil {
+			t.Fatalf("Movie: %v", err)
+		}
+		if movie.Title != "The Matrix" {
+			t.Errorf("Movie title = %q", movie.Title)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("two Movie calls sent %d requests, want 1", n)
+	}
+
+	// Movies that do not exist are cached too
+	for i := 0; i < 2; i++ {
+		if _, err := client.Movie(ctx, "1"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("Movie of a missing movie = %v, want ErrNotFound", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("two Movie calls for a missing movie sent %d requests, want 1", n-1)
+	}
+}
+
+func TestTMDbRetriesAfterRateLimit(t *testing.T) {
+	client, requests := newFakeServer(t, func(w http.ResponseWriter, n int64) bool {
+		return n == 1 && rateLimited(w, "1")
+	})
+
+	start := time.Now()
+	movie, err := client.Movie(context.Background(), "27205")
+	if err != nil {
+		t.Fatalf("Movie: %v", err)
+	}
+	if movie.Title != "Inception" {
+		t.Errorf("Movie title = %q", movie.Title)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("sent %d requests, want 2", n)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, before Retry-After passed", elapsed)
+	}
+}
+
+func TestTMDbGivesUpWhenStillRateLimited(t *testing.T) {
+	client, requests := newFakeServer(t, func(w http.ResponseWriter, n int64) bool {
+		return rateLimited(w, "0")
+	})
+
+	if _, err := client.Movie(context.Background(), "129"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Movie = %v, want ErrRateLimited", err)
+	}
+	if n := requests.Load(); n != tmdbMaxRetries+1 {
+		t.Errorf("sent %d requests, want %d", n, tmdbMaxRetries+1)
+	}
+}
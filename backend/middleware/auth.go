@@ -1,10 +1,10 @@
 package middleware
 
 import (
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
 	"github.com/gin-gonic/gin"
 	"github.com/dgrijalva/jwt-go"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"net/http"
 	"strings"
 )
 
@@ -12,19 +12,13 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Authorization header missing",
-			})
+			abortUnauthorized(c, domain.CodeAuthorizationMissing, "Authorization header missing")
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader { // No prefix found
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Bearer token not found",
-			})
+			abortUnauthorized(c, domain.CodeBearerTokenMissing, "Bearer token not found")
 			return
 		}
 
@@ -32,32 +26,30 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 			return []byte(jwtSecret), nil
 		})
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Invalid token",
-			})
+			abortUnauthorized(c, domain.CodeInvalidToken, "Invalid token")
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Invalid token claims",
-			})
+			abortUnauthorized(c, domain.CodeInvalidTokenClaims, "Invalid token claims")
 			return
 		}
 
 		userID, ok := claims["user_id"].(string)
 		if !ok || !primitive.IsValidObjectID(userID) {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"success": false,
-				"message": "Invalid user ID in token",
-			})
+			abortUnauthorized(c, domain.CodeInvalidTokenUser, "Invalid user ID in token")
 			return
 		}
 
 		c.Set("userID", userID)
 		c.Next()
 	}
+}
+
+// abortUnauthorized stops the request with a 401, rendered by
+// ErrorMiddleware.
+func abortUnauthorized(c *gin.Context, code, message string) {
+	c.Error(domain.Unauthorized(code, message))
+	c.Abort()
 }
\ No newline at end of file
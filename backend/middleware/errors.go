@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// kindStatuses maps each kind of domain.Error to its HTTP status.
+var kindStatuses = []struct {
+	kind   error
+	status int
+}{
+	{domain.ErrValidation, http.StatusBadRequest},
+	{domain.ErrUnauthorized, http.StatusUnauthorized},
+	{domain.ErrForbidden, http.StatusForbidden},
+	{domain.ErrNotFound, http.StatusNotFound},
+	{domain.ErrConflict, http.StatusConflict},
+	{domain.ErrTooLarge, http.StatusRequestEntityTooLarge},
+	{domain.ErrUnavailable, http.StatusServiceUnavailable},
+}
+
+// ErrorMiddleware renders the last error a handler recorded with c.Error
+// as an application/problem+json response, unless the handler already
+// wrote one. A *domain.Error gets the status of its kind and its code,
+// message and details, localized; anything else is an internal error,
+// logged with the trace ID and hidden from the client.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		problem := problemFor(err)
+		problem.Instance = c.Request.URL.Path
+		problem.TraceID = c.GetString("traceID")
+		if problem.Status >= http.StatusInternalServerError {
+			log.Printf("%s %s failed (trace %s): %v", c.Request.Method, c.Request.URL.Path, problem.TraceID, err)
+		}
+		localizeProblem(&problem, c.GetStringSlice("locales"))
+
+		c.Header("Content-Type", "application/problem+json")
+		c.AbortWithStatusJSON(problem.Status, problem)
+	}
+}
+
+func problemFor(err error) domain.Problem {
+	var domainErr *domain.Error
+	if !errors.As(err, &domainErr) {
+		return newProblem(http.StatusInternalServerError, domain.CodeInternalError, "Internal server error", nil)
+	}
+
+	status := http.StatusInternalServerError
+	for _, kindStatus := range kindStatuses {
+		if errors.Is(domainErr.Kind, kindStatus.kind) {
+			status = kindStatus.status
+			break
+		}
+	}
+	// Details are copied, as errors such as sentinels are shared
+	details := append([]domain.ErrorDetail(nil), domainErr.Details...)
+	return newProblem(status, domainErr.Code, domainErr.Message, details)
+}
+
+func newProblem(status int, code, detail string, errs []domain.ErrorDetail) domain.Problem {
+	return domain.Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: errs,
+	}
+}
+
+func localizeProblem(problem *domain.Problem, chain []string) {
+	if len(chain) == 0 {
+		return
+	}
+	problem.Detail = i18n.Translate(chain, problem.Code, problem.Detail, nil)
+	for i, detail := range problem.Errors {
+		problem.Errors[i].Message = i18n.Translate(chain, detail.Code, detail.Message, detail.Params)
+	}
+}
@@ -0,0 +1,137 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	titleRequired := domain.Validation(domain.CodeValidationFailed, "Validation failed",
+		domain.NewErrorDetail(domain.CodeFieldRequired, "title is required", "field", "title"))
+
+	tests := []struct {
+		name    string
+		handler gin.HandlerFunc
+		lang    string
+		status  int
+		problem *domain.Problem // nil when the middleware leaves the response alone
+	}{
+		{
+			name:    "validation errors keep their details",
+			handler: func(c *gin.Context) { c.Error(titleRequired) },
+			status:  http.StatusBadRequest,
+			problem: &domain.Problem{Title: "Bad Request", Detail: "Validation failed", Code: domain.CodeValidationFailed, Errors: []domain.ErrorDetail{
+				domain.NewErrorDetail(domain.CodeFieldRequired, "title is required", "field", "title"),
+			}},
+		},
+		{
+			name:    "each kind has its status",
+			handler: func(c *gin.Context) { c.Error(domain.NotFound(domain.CodeMovieNotFound, "Movie not found")) },
+			status:  http.StatusNotFound,
+			problem: &domain.Problem{Title: "Not Found", Detail: "Movie not found", Code: domain.CodeMovieNotFound},
+		},
+		{
+			name: "a wrapped error keeps its kind",
+			handler: func(c *gin.Context) {
+				c.Error(fmt.Errorf("saving movie: %w", domain.Conflict(domain.CodeAlreadyReviewed, "Already reviewed")))
+			},
+			status:  http.StatusConflict,
+			problem: &domain.Problem{Title: "Conflict", Detail: "Already reviewed", Code: domain.CodeAlreadyReviewed},
+		},
+		{
+			name:    "other errors are hidden",
+			handler: func(c *gin.Context) { c.Error(errors.New("connection refused")) },
+			status:  http.StatusInternalServerError,
+			problem: &domain.Problem{Title: "Internal Server Error", Detail: "Internal server error", Code: domain.CodeInternalError},
+		},
+		{
+			name: "the last error wins",
+			handler: func(c *gin.Context) {
+				c.Error(errors.New("first"))
+				c.Error(domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+			},
+			status:  http.StatusNotFound,
+			problem: &domain.Problem{Title: "Not Found", Detail: "Movie not found", Code: domain.CodeMovieNotFound},
+		},
+		{
+			name:    "messages are translated",
+			handler: func(c *gin.Context) { c.Error(titleRequired) },
+			lang:    "fr",
+			status:  http.StatusBadRequest,
+			problem: &domain.Problem{Title: "Bad Request", Detail: "La validation a échoué", Code: domain.CodeValidationFailed, Errors: []domain.ErrorDetail{
+				domain.NewErrorDetail(domain.CodeFieldRequired, "title est obligatoire", "field", "title"),
+			}},
+		},
+		{
+			name: "a response already written is left alone",
+			handler: func(c *gin.Context) {
+				c.Error(errors.New("logged only"))
+				c.JSON(http.StatusAccepted, gin.H{"ok": true})
+			},
+			status: http.StatusAccepted,
+		},
+		{
+			name:    "no error",
+			handler: func(c *gin.Context) { c.Status(http.StatusNoContent) },
+			status:  http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(TraceMiddleware(), LocaleMiddleware(i18n.NewLocales("en", []string{"fr"})), ErrorMiddleware())
+			router.GET("/movies/:id", tt.handler)
+
+			req := httptest.NewRequest(http.MethodGet, "/movies/42?lang="+tt.lang, nil)
+			req.Header.Set("X-Request-ID", "trace-1")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.problem == nil {
+				if w.Header().Get("Content-Type") == "application/problem+json" {
+					t.Errorf("response was replaced with a problem: %s", w.Body)
+				}
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", ct)
+			}
+			var got domain.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("body is not a problem: %v\n%s", err, w.Body)
+			}
+			want := *tt.problem
+			want.Type, want.Status, want.Instance, want.TraceID = "about:blank", tt.status, "/movies/42", "trace-1"
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("problem = %s\nwant %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestProblemForCopiesDetails(t *testing.T) {
+	shared := domain.Validation(domain.CodeValidationFailed, "Validation failed",
+		domain.NewErrorDetail(domain.CodeFieldRequired, "title is required", "field", "title"))
+
+	problem := problemFor(shared)
+	localizeProblem(&problem, []string{"fr", "en"})
+	if shared.Details[0].Message != "title is required" {
+		t.Errorf("localizing a problem changed the error it came from: %q", shared.Details[0].Message)
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware stores the locales the request asks for, best first,
+// under "locales". A lang query parameter wins over Accept-Language.
+func LocaleMiddleware(locales *i18n.Locales) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("locales", locales.Chain(c.Query("lang"), c.GetHeader("Accept-Language")))
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxTraceIDLength caps a trace ID taken from the client.
+const maxTraceIDLength = 64
+
+// TraceMiddleware gives every request a trace ID under "traceID" and in the
+// X-Request-ID response header. A caller's own X-Request-ID is kept, so a
+// request can be followed from the client through the server logs.
+func TraceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		traceID := c.GetHeader("X-Request-ID")
+		if traceID == "" || len(traceID) > maxTraceIDLength {
+			traceID = newTraceID()
+		}
+		c.Set("traceID", traceID)
+		c.Header("X-Request-ID", traceID)
+		c.Next()
+	}
+}
+
+func newTraceID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,219 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// apiPrefix is where the described routes live; the docs themselves and
+// anything else outside it are left out.
+const apiPrefix = "/api/"
+
+// handlerName matches the runtime name of a controller method value, such
+// as "…/controller.(*MovieController).GetMovieByID-fm".
+var handlerName = regexp.MustCompile(`\(\*(\w+)\)\.(\w+)(-fm)?$`)
+
+// Generate describes the API routes of an engine. The handler of every
+// route must be described in operations; request and response bodies are
+// described from their types in domain, binding rules included.
+func Generate(routes gin.RoutesInfo) (*Document, error) {
+	sorted := append(gin.RoutesInfo(nil), routes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+
+	schemas := newSchemas()
+	problem := schemas.of(domain.Problem{})
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Info: Info{
+			Title:       "Movie Collection API",
+			Version:     "1.0.0",
+			Description: "Failed requests answer with an application/problem+json body.",
+		},
+		Paths:  map[string]*PathItem{},
+		routes: map[string]*Operation{},
+		Components: Components{
+			Schemas: schemas.components,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	operationIDs := map[string]string{}
+	for _, route := range sorted {
+		if !strings.HasPrefix(route.Path, apiPrefix) {
+			continue
+		}
+
+		match := handlerName.FindStringSubmatch(route.Handler)
+		if match == nil {
+			return nil, fmt.Errorf("openapi: %s %s: handler %s is not a controller method", route.Method, route.Path, route.Handler)
+		}
+		key := match[1] + "." + match[2]
+		described, ok := operations[key]
+		if !ok {
+			return nil, fmt.Errorf("openapi: %s %s: %s is not described in operations", route.Method, route.Path, key)
+		}
+
+		op := describe(schemas, described, problem)
+		op.OperationID = strings.ToLower(match[2][:1]) + match[2][1:]
+		if other, taken := operationIDs[op.OperationID]; taken {
+			return nil, fmt.Errorf("openapi: %s and %s share the operation ID %s", other, key, op.OperationID)
+		}
+		operationIDs[op.OperationID] = key
+		op.Tags = []string{strings.TrimSuffix(match[1], "Controller")}
+
+		path, pathParams := openAPIPath(route.Path)
+		op.Parameters = append(pathParams, op.Parameters...)
+
+		item := doc.Paths[path]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		if err := item.set(route.Method, op); err != nil {
+			return nil, fmt.Errorf("openapi: %s %s: %w", route.Method, route.Path, err)
+		}
+		doc.routes[route.Method+" "+route.Path] = op
+	}
+	return doc, nil
+}
+
+// JSON is the document as indented JSON. Maps are written with sorted
+// keys, so the same routes and types always give the same bytes.
+func (d *Document) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func describe(schemas *schemas, described operation, problem *Schema) *Operation {
+	op := &Operation{
+		Summary:    described.summary,
+		Parameters: described.query,
+		Responses:  map[string]*Response{},
+	}
+	if !described.public {
+		op.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	switch {
+	case described.body != nil:
+		op.RequestBody = &RequestBody{
+			Required: hasRequiredFields(schemas, described.body),
+			Content:  map[string]*MediaType{"application/json": {Schema: schemas.of(described.body)}},
+		}
+	case described.form != nil:
+		form := &Schema{Type: "object", Properties: described.form}
+		for name, field := range described.form {
+			if field.Format == "binary" {
+				form.Required = append(form.Required, name)
+			}
+		}
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"multipart/form-data": {Schema: form}},
+		}
+	}
+
+	status := described.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := &Response{Description: http.StatusText(status), Content: map[string]*MediaType{}}
+	switch {
+	case described.envelope != nil:
+		envelope := schemas.of(described.envelope)
+		if described.object != nil {
+			envelope = &Schema{AllOf: []*Schema{envelope, {
+				Type:       "object",
+				Properties: map[string]*Schema{"object": schemas.of(described.object)},
+			}}}
+		}
+		success.Content["application/json"] = &MediaType{Schema: envelope}
+	case described.result != nil:
+		success.Content["application/json"] = &MediaType{Schema: schemas.of(described.result)}
+	}
+	for _, mediaType := range described.files {
+		if _, ok := success.Content[mediaType]; !ok {
+			success.Content[mediaType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		}
+	}
+	op.Responses[strconv.Itoa(status)] = success
+	op.Responses["default"] = &Response{
+		Description: "Error",
+		Content:     map[string]*MediaType{"application/problem+json": {Schema: problem}},
+	}
+	return op
+}
+
+// hasRequiredFields reports whether a request body must be sent: a body
+// without required fields may be left out.
+func hasRequiredFields(schemas *schemas, body interface{}) bool {
+	ref := schemas.of(body).Ref
+	component := schemas.components[strings.TrimPrefix(ref, "#/components/schemas/")]
+	return component == nil || len(component.Required) > 0
+}
+
+// openAPIPath turns a gin path such as "/movies/:id" into "/movies/{id}",
+// with its path parameters.
+func openAPIPath(path string) (string, []*Parameter) {
+	segments := strings.Split(path, "/")
+	var parameters []*Parameter
+	for i, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		segments[i] = "{" + name + "}"
+		parameters = append(parameters, &Parameter{Name: name, In: "path", Required: true, Schema: pathSchema(name, segments[i-1])})
+	}
+	return strings.Join(segments, "/"), parameters
+}
+
+// pathSchema is the schema of a path parameter. Parameters named "id" or
+// "…Id" hold ObjectIDs, except that a user's may also be "me".
+func pathSchema(name, previous string) *Schema {
+	switch {
+	case name == "id" && previous == "users":
+		return &Schema{Type: "string", Pattern: selfOrObjectIDPattern}
+	case name == "id" || strings.HasSuffix(name, "Id"):
+		return &Schema{Type: "string", Pattern: objectIDPattern}
+	}
+	return &Schema{Type: "string"}
+}
+
+func (p *PathItem) set(method string, op *Operation) error {
+	var slot **Operation
+	switch method {
+	case http.MethodGet:
+		slot = &p.Get
+	case http.MethodPost:
+		slot = &p.Post
+	case http.MethodPut:
+		slot = &p.Put
+	case http.MethodPatch:
+		slot = &p.Patch
+	case http.MethodDelete:
+		slot = &p.Delete
+	default:
+		return fmt.Errorf("method %s is not supported", method)
+	}
+	*slot = op
+	return nil
+}
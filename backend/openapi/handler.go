@@ -0,0 +1,30 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// docsPage is Swagger UI showing /openapi.json. The UI itself is loaded
+// from a CDN.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// Handler serves the document as JSON. It is encoded once, up front.
+func Handler(doc *Document) (gin.HandlerFunc, error) {
+	data, err := doc.JSON()
+	if err != nil {
+		return nil, err
+	}
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", data)
+	}, nil
+}
+
+// DocsHandler serves a page for browsing and trying out the API.
+func DocsHandler(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", docsPage)
+}
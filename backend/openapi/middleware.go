@@ -0,0 +1,292 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin"
+)
+
+// Validator checks requests against the operation the document describes
+// for their route, before any handler runs, so that a page of "abc" or a
+// title of 300 characters never reaches one. It can check successful JSON
+// responses too, which is meant for development: a response that breaks
+// its schema is replaced by an internal error. Only operations that answer
+// with nothing but JSON are checked; files and streams, such as exports
+// and posters, are sent as they are written.
+//
+// JSON bodies are read whole to check them, so one larger than
+// maxBodySize bytes is refused; zero leaves them unbounded.
+//
+// The document is generated from the routes, and the middleware must be
+// in place before they are registered, so it is set once they all are;
+// until then requests pass unchecked.
+type Validator struct {
+	doc            *Document
+	checkResponses bool
+	maxBodySize    int64
+}
+
+func NewValidator(checkResponses bool, maxBodySize int64) *Validator {
+	return &Validator{checkResponses: checkResponses, maxBodySize: maxBodySize}
+}
+
+// SetDocument sets the document to check against. It must be called
+// before the server starts.
+func (v *Validator) SetDocument(doc *Document) {
+	v.doc = doc
+}
+
+// Middleware records a validation error, for ErrorMiddleware to render,
+// and aborts when a request breaks the document. Routes the document does
+// not describe are left alone.
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if v.doc == nil {
+			c.Next()
+			return
+		}
+		op := v.doc.routes[c.Request.Method+" "+c.FullPath()]
+		if op == nil {
+			c.Next()
+			return
+		}
+
+		if err := v.checkRequest(c, op); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+		if !v.checkResponses || !answersJSON(op) {
+			c.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if err := v.checkResponse(c, op, writer); err != nil {
+			// The response is dropped; ErrorMiddleware logs the error
+			c.Error(err)
+			return
+		}
+		writer.flush()
+	}
+}
+
+func (v *Validator) checkRequest(c *gin.Context, op *Operation) error {
+	checker := &checker{components: v.doc.Components.Schemas, body: "request body"}
+	for _, param := range op.Parameters {
+		checkParameter(checker, c, param)
+	}
+
+	if op.RequestBody != nil {
+		if media := op.RequestBody.Content["application/json"]; media != nil {
+			value, present, err := readBody(c, v.maxBodySize)
+			var tooLarge *http.MaxBytesError
+			switch {
+			case errors.As(err, &tooLarge):
+				return domain.TooLarge(domain.CodeRequestTooLarge, "Request body is too large")
+			case err != nil, !present && op.RequestBody.Required:
+				return domain.Validation(domain.CodeInvalidRequestBody, "Invalid request body",
+					domain.NewErrorDetail(domain.CodeMalformedBody, "request body is not valid JSON"))
+			case present:
+				checker.check(media.Schema, value, "")
+			}
+		}
+	}
+
+	if len(checker.details) > 0 {
+		return domain.Validation(domain.CodeValidationFailed, "Validation failed", checker.details...)
+	}
+	return nil
+}
+
+// checkParameter checks a path or query parameter. Query parameters
+// arrive as text, so numbers and booleans are parsed first; parameters
+// the operation does not declare, such as lang, are left alone.
+func checkParameter(checker *checker, c *gin.Context, param *Parameter) {
+	var value string
+	switch param.In {
+	case "path":
+		value = c.Param(param.Name)
+	case "query":
+		var ok bool
+		if value, ok = c.GetQuery(param.Name); !ok {
+			if param.Required {
+				checker.fail(domain.CodeFieldRequired, param.Name, " is required")
+			}
+			return
+		}
+	default:
+		return
+	}
+
+	var typed interface{} = value
+	switch param.Schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			checker.fail(domain.CodeFieldNotNumber, param.Name, " must be a whole number")
+			return
+		}
+		typed = json.Number(value)
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			checker.fail(domain.CodeFieldWrongType, param.Name, " must be a number", "type", "number")
+			return
+		}
+		typed = json.Number(value)
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			checker.fail(domain.CodeFieldWrongType, param.Name, " must be a boolean", "type", "boolean")
+			return
+		}
+		typed = b
+	}
+	checker.check(param.Schema, typed, param.Name)
+}
+
+// readBody decodes a JSON request body of up to maxSize bytes, and puts
+// it back for the handler to read again. An empty body is reported as not
+// present.
+func readBody(c *gin.Context, maxSize int64) (interface{}, bool, error) {
+	if c.Request.Body == nil {
+		return nil, false, nil
+	}
+	if maxSize > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxSize)
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil, false, err
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, false, nil
+	}
+	value, err := decode(data)
+	return value, true, err
+}
+
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// answersJSON reports whether every successful response of op is JSON, so
+// that holding one back to check it costs no more than the response.
+func answersJSON(op *Operation) bool {
+	for status, response := range op.Responses {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		for mediaType := range response.Content {
+			if mediaType != "application/json" {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkResponse checks a successful JSON response against the schema the
+// operation describes for its status. Other responses, such as problems
+// and files, are not checked.
+func (v *Validator) checkResponse(c *gin.Context, op *Operation, writer *bufferedWriter) error {
+	mediaType, _, _ := mime.ParseMediaType(writer.Header().Get("Content-Type"))
+	if !writer.written || writer.status < 200 || writer.status >= 300 || mediaType != "application/json" {
+		return nil
+	}
+
+	route := c.Request.Method + " " + c.FullPath()
+	response := op.Responses[strconv.Itoa(writer.status)]
+	if response == nil || response.Content["application/json"] == nil {
+		return fmt.Errorf("openapi: %s answered %d with JSON, which the document does not describe", route, writer.status)
+	}
+	value, err := decode(writer.body.Bytes())
+	if err != nil {
+		return fmt.Errorf("openapi: %s answered invalid JSON: %w", route, err)
+	}
+
+	checker := &checker{components: v.doc.Components.Schemas, body: "response body"}
+	checker.check(response.Content["application/json"].Schema, value, "")
+	if len(checker.details) > 0 {
+		messages := make([]string, len(checker.details))
+		for i, detail := range checker.details {
+			messages[i] = detail.Message
+		}
+		return fmt.Errorf("openapi: response of %s does not match the document: %s", route, strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+// bufferedWriter holds a response back until it has been checked.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body    bytes.Buffer
+	status  int
+	written bool
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.written = true
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+// Flush does nothing: the response is sent once it has been checked.
+func (w *bufferedWriter) Flush() {}
+
+// flush sends the response held back to the client.
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.written {
+		w.ResponseWriter.WriteHeaderNow()
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
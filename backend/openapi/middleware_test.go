@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// testDocument describes two routes on things, which have a name of at
+// most five characters.
+func testDocument() *Document {
+	maxName, minPage := 5, 1.0
+	thing := &Schema{
+		Type:       "object",
+		Properties: map[string]*Schema{"name": {Type: "string", MaxLength: &maxName}},
+		Required:   []string{"name"},
+	}
+	answers := func(status string) map[string]*Response {
+		return map[string]*Response{status: {Content: map[string]*MediaType{"application/json": {Schema: thing}}}}
+	}
+	return &Document{
+		Components: Components{Schemas: map[string]*Schema{}},
+		routes: map[string]*Operation{
+			"GET /things/:id": {
+				Parameters: []*Parameter{
+					{Name: "id", In: "path", Required: true, Schema: &Schema{Type: "string", Pattern: objectIDPattern}},
+					{Name: "page", In: "query", Schema: &Schema{Type: "integer", Minimum: &minPage}},
+				},
+				Responses: answers("200"),
+			},
+			"POST /things": {
+				RequestBody: &RequestBody{Required: true, Content: map[string]*MediaType{"application/json": {Schema: thing}}},
+				Responses:   answers("201"),
+			},
+		},
+	}
+}
+
+// serve runs a request through the validator, with ErrorMiddleware in
+// front to render its problems, to a handler that answers body.
+func serve(v *Validator, method, target, requestBody string, status int, body interface{}) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.ErrorMiddleware(), v.Middleware())
+	var received string
+	handler := func(c *gin.Context) {
+		if c.Request.Body != nil {
+			data, _ := io.ReadAll(c.Request.Body)
+			received = string(data)
+		}
+		c.JSON(status, body)
+	}
+	engine.GET("/things/:id", handler)
+	engine.POST("/things", handler)
+
+	recorder := httptest.NewRecorder()
+	var reader io.Reader
+	if requestBody != "" {
+		reader = strings.NewReader(requestBody)
+	}
+	engine.ServeHTTP(recorder, httptest.NewRequest(method, target, reader))
+	return recorder, received
+}
+
+func problemOf(t *testing.T, recorder *httptest.ResponseRecorder) domain.Problem {
+	t.Helper()
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Fatalf("Content-Type = %q, want application/problem+json", contentType)
+	}
+	var problem domain.Problem
+	if err := json.Unmarshal(recorder.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	return problem
+}
+
+func TestMiddlewareRejectsBadRequests(t *testing.T) {
+	const id = "5f1d7a3e9d3b2c0012345678"
+	tests := []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		status     int
+		code       string
+		detailCode string
+	}{
+		{name: "a bad path parameter", method: "GET", target: "/things/abc", status: http.StatusBadRequest, code: domain.CodeValidationFailed, detailCode: domain.CodeInvalidID},
+		{name: "a query parameter that is not a number", method: "GET", target: "/things/" + id + "?page=two", status: http.StatusBadRequest, code: domain.CodeValidationFailed, detailCode: domain.CodeFieldNotNumber},
+		{name: "a query parameter out of range", method: "GET", target: "/things/" + id + "?page=0", status: http.StatusBadRequest, code: domain.CodeValidationFailed, detailCode: domain.CodeFieldTooSmall},
+		{name: "a body breaking its schema", method: "POST", target: "/things", body: `{"name": "Nosferatu"}`, status: http.StatusBadRequest, code: domain.CodeValidationFailed, detailCode: domain.CodeFieldTooLong},
+		{name: "a body that is not JSON", method: "POST", target: "/things", body: `{"name":`, status: http.StatusBadRequest, code: domain.CodeInvalidRequestBody, detailCode: domain.CodeMalformedBody},
+		{name: "a missing body", method: "POST", target: "/things", status: http.StatusBadRequest, code: domain.CodeInvalidRequestBody, detailCode: domain.CodeMalformedBody},
+		{name: "a body over the size limit", method: "POST", target: "/things", body: `{"name": "Alien", "notes": "` + strings.Repeat("x", 64) + `"}`, status: http.StatusRequestEntityTooLarge, code: domain.CodeRequestTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(false, 64)
+			v.SetDocument(testDocument())
+			recorder, received := serve(v, tt.method, tt.target, tt.body, http.StatusOK, gin.H{"name": "Alien"})
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.status)
+			}
+			if received != "" {
+				t.Error("the handler ran")
+			}
+			problem := problemOf(t, recorder)
+			if problem.Code != tt.code {
+				t.Errorf("code = %q, want %q", problem.Code, tt.code)
+			}
+			if tt.detailCode != "" && (len(problem.Errors) != 1 || problem.Errors[0].Code != tt.detailCode) {
+				t.Errorf("errors = %+v, want one %q", problem.Errors, tt.detailCode)
+			}
+		})
+	}
+}
+
+func TestMiddlewarePutsTheBodyBack(t *testing.T) {
+	v := NewValidator(false, 64)
+	v.SetDocument(testDocument())
+	const body = `{"name": "Alien"}`
+
+	recorder, received := serve(v, "POST", "/things", body, http.StatusCreated, gin.H{"name": "Alien"})
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d: %s", recorder.Code, http.StatusCreated, recorder.Body)
+	}
+	if received != body {
+		t.Errorf("handler read %q, want %q", received, body)
+	}
+}
+
+func TestMiddlewareChecksResponses(t *testing.T) {
+	tests := []struct {
+		name           string
+		checkResponses bool
+		status         int
+		body           interface{}
+		wantStatus     int
+	}{
+		{name: "a matching response", checkResponses: true, status: http.StatusOK, body: gin.H{"name": "Alien"}, wantStatus: http.StatusOK},
+		{name: "a response breaking its schema", checkResponses: true, status: http.StatusOK, body: gin.H{"title": "Alien"}, wantStatus: http.StatusInternalServerError},
+		{name: "a status the document does not describe", checkResponses: true, status: http.StatusAccepted, body: gin.H{"name": "Alien"}, wantStatus: http.StatusInternalServerError},
+		{name: "an error response", checkResponses: true, status: http.StatusNotFound, body: gin.H{"title": "Alien"}, wantStatus: http.StatusNotFound},
+		{name: "a response breaking its schema, unchecked", checkResponses: false, status: http.StatusOK, body: gin.H{"title": "Alien"}, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(tt.checkResponses, 0)
+			v.SetDocument(testDocument())
+			recorder, _ := serve(v, "GET", "/things/5f1d7a3e9d3b2c0012345678", "", tt.status, tt.body)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusInternalServerError {
+				if problem := problemOf(t, recorder); problem.Code != domain.CodeInternalError {
+					t.Errorf("code = %q, want %q", problem.Code, domain.CodeInternalError)
+				}
+				return
+			}
+			want, _ := json.Marshal(tt.body)
+			if strings.TrimSpace(recorder.Body.String()) != string(want) {
+				t.Errorf("body = %s, want %s", recorder.Body, want)
+			}
+		})
+	}
+}
+
+func TestMiddlewareWithoutDocument(t *testing.T) {
+	recorder, _ := serve(NewValidator(true, 64), "GET", "/things/abc", "", http.StatusOK, gin.H{"title": "Alien"})
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d before the document is set", recorder.Code, http.StatusOK)
+	}
+}
+
+func TestBufferedWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	w := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+
+	if w.Written() || w.Size() != -1 {
+		t.Errorf("new writer: written %v, size %d; want false, -1", w.Written(), w.Size())
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(`{"name":`))
+	w.WriteString(`"Alien"}`)
+	w.WriteHeader(http.StatusTeapot) // too late once the body has started
+	w.Flush()
+
+	if w.Status() != http.StatusCreated || w.Size() != len(`{"name":"Alien"}`) || !w.Written() {
+		t.Errorf("writer: status %d, size %d, written %v; want %d, %d, true", w.Status(), w.Size(), w.Written(), http.StatusCreated, len(`{"name":"Alien"}`))
+	}
+	if recorder.Body.Len() != 0 || c.Writer.Written() {
+		t.Fatal("the response was sent before it was checked")
+	}
+
+	w.flush()
+	if recorder.Code != http.StatusCreated || recorder.Body.String() != `{"name":"Alien"}` {
+		t.Errorf("sent %d %q, want %d %q", recorder.Code, recorder.Body, http.StatusCreated, `{"name":"Alien"}`)
+	}
+}
+
+func TestBufferedWriterWithoutBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	w := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+
+	w.WriteHeader(http.StatusNoContent)
+	w.flush()
+	if c.Writer.Status() != http.StatusNoContent || c.Writer.Size() > 0 {
+		t.Errorf("sent %d with %d bytes, want %d with none", c.Writer.Status(), c.Writer.Size(), http.StatusNoContent)
+	}
+}
@@ -0,0 +1,36 @@
+package openapi_test
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/openapi"
+	"github.com/AfomiaTadesse/Afomia_M/backend/router"
+	"github.com/gin-gonic/gin"
+)
+
+// TestDocumentIsUpToDate fails when a route or a domain type changed
+// without openapi.json being regenerated with go run ./cmd/openapi.
+func TestDocumentIsUpToDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Only the routes are needed, so no controller is set up
+	engine := router.SetupRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, "", false, 0)
+	doc, err := openapi.Generate(engine.Routes())
+	if err != nil {
+		t.Fatalf("generating the document: %v", err)
+	}
+	generated, err := doc.JSON()
+	if err != nil {
+		t.Fatalf("encoding the document: %v", err)
+	}
+
+	committed, err := os.ReadFile("openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(committed, generated) {
+		t.Fatal("openapi.json is out of date with the routes or domain types; run go run ./cmd/openapi and commit the result")
+	}
+}
@@ -0,0 +1,217 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+// operation describes what a handler takes and returns, beyond what its
+// route says.
+type operation struct {
+	summary string
+	// public operations need no bearer token
+	public bool
+	// status of a success; 200 when zero
+	status int
+	query  []*Parameter
+	// body is the JSON request body, form a multipart one
+	body interface{}
+	form map[string]*Schema
+	// envelope is the response type (BaseResponse, PaginatedResponse,
+	// ...) and object what its object holds. result is a response that is
+	// not wrapped in an envelope, and files the other media types a
+	// success can be.
+	envelope interface{}
+	object   interface{}
+	result   interface{}
+	files    []string
+}
+
+func queryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func integer(defaultValue, minimum int) *Schema {
+	min := float64(minimum)
+	return &Schema{Type: "integer", Default: defaultValue, Minimum: &min}
+}
+
+func atMost(schema *Schema, maximum int) *Schema {
+	max := float64(maximum)
+	schema.Maximum = &max
+	return schema
+}
+
+func enum(defaultValue string, values ...string) *Schema {
+	schema := &Schema{Type: "string", Enum: values}
+	if defaultValue != "" {
+		schema.Default = defaultValue
+	}
+	return schema
+}
+
+var (
+	pageParams = []*Parameter{
+		queryParam("page", "Page number, from 1", integer(1, 1)),
+		queryParam("size", "Items per page", atMost(integer(10, 1), domain.MaxPageSize)),
+	}
+	dateRangeParams = []*Parameter{
+		queryParam("from", "Earliest date, YYYY-MM-DD", &Schema{Type: "string", Format: "date"}),
+		queryParam("to", "Latest date, YYYY-MM-DD", &Schema{Type: "string", Format: "date"}),
+	}
+	uploadFile = &Schema{Type: "string", Format: "binary"}
+	dryRun     = &Schema{Type: "boolean", Default: false}
+)
+
+func limitParam(defaultValue int) *Parameter {
+	return queryParam("limit", "Maximum number of items", integer(defaultValue, 1))
+}
+
+func cursorParams(defaultLimit int) []*Parameter {
+	return []*Parameter{
+		queryParam("cursor", "nextCursor of the previous page", &Schema{Type: "string"}),
+		limitParam(defaultLimit),
+	}
+}
+
+func params(groups ...[]*Parameter) []*Parameter {
+	var all []*Parameter
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return all
+}
+
+var (
+	base      = domain.BaseResponse{}
+	paginated = domain.PaginatedResponse{}
+	cursor    = domain.CursorResponse{}
+)
+
+// operations describes every handler, by controller and method name. A
+// route whose handler is missing here fails generation.
+var operations = map[string]operation{
+	"UserController.Signup": {summary: "Create an account", public: true, body: domain.SignupRequest{}, envelope: domain.AuthResponse{}},
+	"UserController.Login":  {summary: "Log in and get a token", public: true, body: domain.LoginRequest{}, envelope: domain.AuthResponse{}},
+
+	"WatchlistController.GetWatchlist":        {summary: "List the watchlist", query: params(pageParams, dateRangeParams), envelope: paginated, object: []domain.WatchlistEntry{}},
+	"WatchlistController.AddToWatchlist":      {summary: "Add a movie to the watchlist", status: http.StatusCreated, body: domain.AddToWatchlistRequest{}, envelope: base, object: domain.WatchlistEntry{}},
+	"WatchlistController.RemoveFromWatchlist": {summary: "Remove a movie from the watchlist", envelope: base},
+
+	"DiaryController.GetDiary": {summary: "List diary entries", query: params(pageParams, dateRangeParams), envelope: paginated, object: []domain.DiaryEntry{}},
+	"DiaryController.ExportDiary": {summary: "Export the diary",
+		query:  params([]*Parameter{queryParam("format", "", enum("csv", "csv", "json"))}, dateRangeParams),
+		result: []domain.DiaryExportRow{}, files: []string{"text/csv"}},
+	"DiaryController.LogViewing":  {summary: "Log a viewing", status: http.StatusCreated, body: domain.LogViewingRequest{}, envelope: base, object: domain.DiaryEntry{}},
+	"DiaryController.DeleteEntry": {summary: "Delete a diary entry", envelope: base},
+
+	"ListController.GetMyLists":             {summary: "List the caller's lists", query: pageParams, envelope: paginated, object: []domain.MovieList{}},
+	"CollectionController.GetMyCollections": {summary: "List the caller's collections", query: pageParams, envelope: paginated, object: []domain.Collection{}},
+	"LikeController.GetLikedMovies":         {summary: "List liked movies", query: pageParams, envelope: paginated, object: []domain.Movie{}},
+	"FeedController.GetFeed":                {summary: "Activity of followed users", query: cursorParams(20), envelope: cursor, object: []domain.FeedItem{}},
+	"RecommendationController.GetRecommendations": {summary: "Recommended movies", query: []*Parameter{limitParam(10)},
+		envelope: base, object: []domain.Recommendation{}},
+	"ExportController.Export": {summary: "Download the caller's data",
+		query: []*Parameter{
+			queryParam("format", "", enum(domain.ExportFormatCSV, domain.ExportFormatCSV, domain.ExportFormatJSON, domain.ExportFormatNDJSON)),
+			queryParam("include", "Comma-separated sections to add: reviews, lists, diary", &Schema{Type: "string"}),
+		},
+		files: []string{"text/csv", "application/zip", "application/json", "application/x-ndjson"}},
+
+	"AccountController.RequestDataExport":  {summary: "Request an archive of the caller's data", status: http.StatusAccepted, envelope: base, object: domain.DataExport{}},
+	"AccountController.GetDataExport":      {summary: "Get a data export", envelope: base, object: domain.DataExport{}},
+	"AccountController.DownloadDataExport": {summary: "Download a finished data export", files: []string{"application/zip"}},
+	"AccountController.GetErasure":         {summary: "Get the caller's account erasure", envelope: base, object: domain.ErasureRequest{}},
+	"AccountController.RequestErasure":     {summary: "Schedule erasure of the caller's account", status: http.StatusAccepted, envelope: base, object: domain.ErasureRequest{}},
+	"AccountController.CancelErasure":      {summary: "Cancel a scheduled account erasure", envelope: base},
+
+	"FollowController.Follow":       {summary: "Follow a user", envelope: base, object: domain.Follow{}},
+	"FollowController.Unfollow":     {summary: "Unfollow a user", envelope: base},
+	"FollowController.GetFollowers": {summary: "List a user's followers", query: pageParams, envelope: paginated, object: []domain.UserSummary{}},
+	"FollowController.GetFollowing": {summary: "List the users a user follows", query: pageParams, envelope: paginated, object: []domain.UserSummary{}},
+
+	"CollectionController.CreateCollection":    {summary: "Create a shared collection", status: http.StatusCreated, body: domain.CreateCollectionRequest{}, envelope: base, object: domain.Collection{}},
+	"CollectionController.JoinByInvite":        {summary: "Join a collection through an invite link", envelope: base, object: domain.CollectionMember{}},
+	"CollectionController.GetCollection":       {summary: "Get a collection", envelope: base, object: domain.Collection{}},
+	"CollectionController.UpdateCollection":    {summary: "Update a collection", body: domain.UpdateCollectionRequest{}, envelope: base, object: domain.Collection{}},
+	"CollectionController.DeleteCollection":    {summary: "Delete a collection", envelope: base},
+	"CollectionController.GetCollectionMovies": {summary: "List a collection's movies", query: pageParams, envelope: paginated, object: []domain.Movie{}},
+	"CollectionController.InviteMember":        {summary: "Add a member to a collection", body: domain.InviteMemberRequest{}, envelope: base, object: domain.CollectionMember{}},
+	"CollectionController.UpdateMemberRole":    {summary: "Change a member's role", body: domain.UpdateMemberRoleRequest{}, envelope: base},
+	"CollectionController.RemoveMember":        {summary: "Remove a member, or leave a collection", envelope: base},
+	"CollectionController.CreateInviteLink":    {summary: "Create an invite link", body: domain.CreateInviteLinkRequest{}, envelope: base, object: domain.Collection{}},
+	"CollectionController.RevokeInviteLink":    {summary: "Revoke the invite link", envelope: base},
+
+	"ListController.CreateList":    {summary: "Create a list", status: http.StatusCreated, body: domain.CreateListRequest{}, envelope: base, object: domain.MovieList{}},
+	"ListController.GetList":       {summary: "Get a list with its movies", envelope: base, object: domain.ListDetailsResponse{}},
+	"ListController.GetSharedList": {summary: "Get a list shared by link", public: true, envelope: base, object: domain.ListDetailsResponse{}},
+	"ListController.UpdateList":    {summary: "Update a list", body: domain.UpdateListRequest{}, envelope: base, object: domain.MovieList{}},
+	"ListController.DeleteList":    {summary: "Delete a list", envelope: base},
+	"ListController.AddEntries":    {summary: "Add movies to a list", body: domain.AddListEntriesRequest{}, envelope: base, object: domain.MovieList{}},
+	"ListController.RemoveEntries": {summary: "Remove movies from a list", body: domain.RemoveListEntriesRequest{}, envelope: base, object: domain.MovieList{}},
+	"ListController.UpdateEntry":   {summary: "Update a list entry's note", body: domain.UpdateListEntryRequest{}, envelope: base},
+	"ListController.ReorderList":   {summary: "Reorder a list", body: domain.ReorderListRequest{}, envelope: base, object: domain.MovieList{}},
+
+	"AdminController.GetDuplicates": {summary: "Find movies that look like duplicates", query: []*Parameter{limitParam(50)},
+		envelope: base, object: []domain.DuplicateGroup{}},
+	"AdminController.MergeMovies": {summary: "Merge duplicate movies", body: domain.MergeMoviesRequest{}, envelope: base, object: domain.MergeResult{}},
+
+	"PosterController.GetPoster":    {summary: "Get a poster image", public: true, files: []string{"image/jpeg", "image/png", "image/gif"}},
+	"PosterController.UploadPoster": {summary: "Upload a movie's poster", form: map[string]*Schema{"poster": uploadFile}, envelope: base, object: domain.Movie{}},
+
+	"MovieController.CreateMovie": {summary: "Create a movie", status: http.StatusCreated, body: domain.CreateMovieRequest{}, envelope: base, object: domain.Movie{}},
+	"MovieController.GetMovies": {summary: "List movies",
+		query:    params(pageParams, []*Parameter{queryParam("sort", "Insertion order when left out", enum("", domain.MovieSortPopular))}),
+		envelope: paginated, object: []domain.Movie{}},
+	"MovieController.SearchMovies": {summary: "Search movies by title",
+		query:    params([]*Parameter{queryParam("title", "", &Schema{Type: "string"})}, pageParams),
+		envelope: paginated, object: []domain.Movie{}},
+	"MovieController.GetMovieByID": {summary: "Get a movie", envelope: base, object: domain.Movie{}},
+	"MovieController.UpdateMovie":  {summary: "Update a movie", body: domain.UpdateMovieRequest{}, envelope: base, object: domain.Movie{}},
+	"MovieController.DeleteMovie":  {summary: "Delete a movie", envelope: base},
+
+	"TrendingController.GetTrending": {summary: "Trending movies",
+		query:    []*Parameter{queryParam("window", "", enum(domain.TrendingDay, domain.TrendingDay, domain.TrendingWeek, domain.TrendingMonth)), limitParam(10)},
+		envelope: base, object: []domain.ScoredMovie{}},
+
+	"MetadataController.LookupMetadata": {summary: "Look up movie metadata",
+		query: []*Parameter{
+			queryParam("title", "", &Schema{Type: "string"}),
+			queryParam("year", "", &Schema{Type: "integer"}),
+			queryParam("imdbId", "", &Schema{Type: "string"}),
+		},
+		envelope: base, object: []domain.MovieMetadata{}},
+	"MetadataController.RefreshMetadata": {summary: "Refresh a movie from its metadata provider", body: domain.RefreshMetadataRequest{}, envelope: base, object: domain.Movie{}},
+
+	"ImportController.StartImport": {summary: "Import movies from a file", status: http.StatusAccepted,
+		form: map[string]*Schema{
+			"file":    uploadFile,
+			"format":  enum("", domain.ImportFormatCSV, domain.ImportFormatJSON, domain.ImportFormatNDJSON),
+			"mapping": {Type: "string", Description: "JSON object mapping movie fields to columns"},
+			"dryRun":  dryRun,
+		},
+		envelope: base, object: domain.ImportJob{}},
+	"ImportController.ImportLetterboxd": {summary: "Import a Letterboxd export", status: http.StatusAccepted,
+		form: map[string]*Schema{"file": uploadFile, "dryRun": dryRun}, envelope: base, object: domain.ImportJob{}},
+	"ImportController.ImportIMDb": {summary: "Import an IMDb export", status: http.StatusAccepted,
+		form: map[string]*Schema{"file": uploadFile, "dryRun": dryRun}, envelope: base, object: domain.ImportJob{}},
+	"ImportController.GetImportJob": {summary: "Get an import job", envelope: base, object: domain.ImportJob{}},
+
+	"ReviewController.GetMovieReviews": {summary: "List a movie's reviews", query: pageParams, envelope: paginated, object: []domain.Review{}},
+	"ReviewController.CreateReview":    {summary: "Review a movie", status: http.StatusCreated, body: domain.CreateReviewRequest{}, envelope: base, object: domain.Review{}},
+	"ReviewController.UpdateReview":    {summary: "Update the caller's review", body: domain.UpdateReviewRequest{}, envelope: base, object: domain.Review{}},
+	"ReviewController.DeleteReview":    {summary: "Delete the caller's review", envelope: base},
+
+	"LikeController.LikeMovie":   {summary: "Like a movie", envelope: base, object: domain.Like{}},
+	"LikeController.UnlikeMovie": {summary: "Unlike a movie", envelope: base},
+
+	"CommentController.GetThreads":    {summary: "List a movie's comment threads", query: cursorParams(20), envelope: cursor, object: []domain.Comment{}},
+	"CommentController.CreateComment": {summary: "Comment on a movie", status: http.StatusCreated, body: domain.CreateCommentRequest{}, envelope: base, object: domain.Comment{}},
+	"CommentController.GetReplies":    {summary: "List a thread's replies", query: cursorParams(20), envelope: cursor, object: []domain.Comment{}},
+	"CommentController.UpdateComment": {summary: "Edit a comment", body: domain.UpdateCommentRequest{}, envelope: base, object: domain.Comment{}},
+	"CommentController.DeleteComment": {summary: "Delete a comment", envelope: base},
+
+	"RecommendationController.GetSimilarMovies": {summary: "Movies similar to a movie", query: []*Parameter{limitParam(10)},
+		envelope: base, object: []domain.ScoredMovie{}},
+}
@@ -0,0 +1,228 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	objectIDType = reflect.TypeOf(primitive.ObjectID{})
+)
+
+const (
+	// objectIDPattern matches the hex form of a MongoDB ObjectID.
+	objectIDPattern = "^[0-9a-fA-F]{24}$"
+	// selfOrObjectIDPattern also matches "me", meaning the caller.
+	selfOrObjectIDPattern = "^(me|[0-9a-fA-F]{24})$"
+	alphanumPattern       = "^[a-zA-Z0-9]+$"
+)
+
+// schemas builds the schemas of Go types, the way encoding/json writes
+// them. Named structs become components and are referenced by name.
+type schemas struct {
+	components map[string]*Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: map[string]*Schema{}}
+}
+
+// of returns the schema of the type of v.
+func (s *schemas) of(v interface{}) *Schema {
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case objectIDType:
+		return &Schema{Type: "string", Pattern: objectIDPattern}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		if _, ok := s.components[t.Name()]; !ok {
+			// Registered before it is built, for types that refer to themselves
+			s.components[t.Name()] = &Schema{}
+			*s.components[t.Name()] = *s.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	// Interfaces hold any value
+	return &Schema{}
+}
+
+// object is the schema of a struct: its JSON fields, including those of
+// embedded structs, with the constraints of their binding rules.
+func (s *schemas) object(t reflect.Type) *Schema {
+	object := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	s.addFields(object, t)
+	return object
+}
+
+func (s *schemas) addFields(object *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			s.addFields(object, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := s.schema(field.Type)
+		if rules := field.Tag.Get("binding"); rules != "" {
+			property = constrain(property, rules)
+			if hasRule(rules, "required") {
+				object.Required = append(object.Required, name)
+			}
+		}
+		if strings.Contains(options, "string") {
+			property = &Schema{Type: "string"}
+		}
+		object.Properties[name] = property
+	}
+}
+
+// hasRule reports whether a binding tag applies rule to the field itself,
+// rather than to its items.
+func hasRule(rules, rule string) bool {
+	own, _, _ := strings.Cut(rules, ",dive")
+	for _, r := range strings.Split(own, ",") {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// constrain adds the constraints of binding rules to a schema. Rules after
+// "dive" apply to the items of a list or the values of a map.
+func constrain(schema *Schema, rules string) *Schema {
+	own, itemRules, dives := strings.Cut(rules, ",dive")
+	itemRules = strings.TrimPrefix(itemRules, ",")
+
+	if schema.Ref != "" {
+		// Sibling keywords of a reference need it wrapped in 2020-12
+		schema = &Schema{AllOf: []*Schema{schema}}
+	}
+	for _, rule := range strings.Split(own, ",") {
+		applyRule(schema, rule)
+	}
+
+	if dives {
+		// Map keys are described by additionalProperties' own rules
+		if _, values, ok := strings.Cut(itemRules, "endkeys"); ok {
+			itemRules = strings.TrimPrefix(values, ",")
+		}
+		switch {
+		case schema.Items != nil && itemRules != "":
+			schema.Items = constrain(schema.Items, itemRules)
+		case schema.AdditionalProperties != nil && itemRules != "":
+			schema.AdditionalProperties = constrain(schema.AdditionalProperties, itemRules)
+		}
+	}
+	return schema
+}
+
+func applyRule(schema *Schema, rule string) {
+	name, param, _ := strings.Cut(rule, "=")
+	switch name {
+	case "min", "gte":
+		bound(schema, param, true)
+	case "max", "lte":
+		bound(schema, param, false)
+	case "oneof":
+		schema.Enum = strings.Fields(param)
+	case "unique":
+		// unique=Field only asks for distinct values of one field
+		schema.UniqueItems = param == ""
+	case "url", "http_url":
+		schema.Format = "uri"
+	case "email":
+		schema.Format = "email"
+	case "mongodb":
+		schema.Pattern = objectIDPattern
+	case "alphanum":
+		schema.Pattern = alphanumPattern
+	case "halfstep":
+		schema.MultipleOf = 0.5
+	}
+}
+
+// bound sets a lower or upper bound, which limits a string's length, a
+// list's items, a map's entries or a number's value.
+func bound(schema *Schema, param string, lower bool) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := int(n)
+
+	switch schema.Type {
+	case "string":
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case "array":
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	case "object":
+		if !lower {
+			schema.MaxProperties = &count
+		}
+	default:
+		if lower {
+			schema.Minimum = &n
+		} else {
+			schema.Maximum = &n
+		}
+	}
+}
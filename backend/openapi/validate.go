@@ -0,0 +1,267 @@
+package openapi
+
+import (
+	"encoding/json"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+// patterns caches the compiled pattern of every schema checked so far.
+var patterns sync.Map
+
+// checker checks decoded JSON values against schemas, collecting one
+// detail per broken constraint, so that every problem is reported at once.
+// Numbers must be decoded as json.Number, to tell integers apart.
+type checker struct {
+	components map[string]*Schema
+	// body names the value checked, in details about the whole of it
+	body    string
+	details []domain.ErrorDetail
+}
+
+// check checks a value found at field, a path such as
+// "translations[0].title"; the empty path is the whole body. A null is
+// accepted for any schema, as encoding/json reads and writes it as the
+// zero value.
+func (c *checker) check(schema *Schema, value interface{}, field string) {
+	if schema == nil || value == nil {
+		return
+	}
+	if schema.Ref != "" {
+		c.check(c.components[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], value, field)
+	}
+	for _, part := range schema.AllOf {
+		c.check(part, value, field)
+	}
+	if schema.Type != "" && !hasType(value, schema.Type) {
+		c.fail(domain.CodeFieldWrongType, field, " must be "+withArticle(schema.Type), "type", schema.Type)
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		c.checkString(schema, value, field)
+	case json.Number:
+		c.checkNumber(schema, value, field)
+	case []interface{}:
+		c.checkArray(schema, value, field)
+	case map[string]interface{}:
+		c.checkObject(schema, value, field)
+	}
+}
+
+func hasType(value interface{}, typ string) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := number.Int64()
+		return err == nil
+	case "number":
+		_, ok := value.(json.Number)
+		return ok
+	}
+	return true
+}
+
+func (c *checker) checkString(schema *Schema, value, field string) {
+	if len(schema.Enum) > 0 && !contains(schema.Enum, value) {
+		values := strings.Join(schema.Enum, ", ")
+		c.fail(domain.CodeFieldNotOneOf, field, " must be one of "+values, "values", values)
+		return
+	}
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		min := strconv.Itoa(*schema.MinLength)
+		c.fail(domain.CodeFieldTooShort, field, " must be at least "+min+" characters long", "min", min)
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		max := strconv.Itoa(*schema.MaxLength)
+		c.fail(domain.CodeFieldTooLong, field, " must be at most "+max+" characters long", "max", max)
+	}
+	if schema.Pattern != "" && !compiled(schema.Pattern).MatchString(value) {
+		switch schema.Pattern {
+		case objectIDPattern, selfOrObjectIDPattern:
+			c.fail(domain.CodeInvalidID, field, " must be a valid ID")
+		case alphanumPattern:
+			c.fail(domain.CodeFieldNotAlphanumeric, field, " must contain only letters and digits")
+		default:
+			c.fail(domain.CodeFieldInvalid, field, " is invalid")
+		}
+	}
+
+	switch schema.Format {
+	case "uri":
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			c.fail(domain.CodeFieldNotURL, field, " must be a URL")
+		}
+	case "email":
+		if address, err := mail.ParseAddress(value); err != nil || address.Address != value {
+			c.fail(domain.CodeInvalidEmail, field, " must be an email address")
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			c.fail(domain.CodeDateInvalid, field, " must be a date in YYYY-MM-DD format")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			c.fail(domain.CodeFieldInvalid, field, " is invalid")
+		}
+	}
+}
+
+func (c *checker) checkNumber(schema *Schema, value json.Number, field string) {
+	n, err := value.Float64()
+	if err != nil {
+		c.fail(domain.CodeFieldWrongType, field, " must be a number", "type", "number")
+		return
+	}
+	if schema.Minimum != nil && n < *schema.Minimum {
+		min := formatNumber(*schema.Minimum)
+		c.fail(domain.CodeFieldTooSmall, field, " must be at least "+min, "min", min)
+	}
+	if schema.Maximum != nil && n > *schema.Maximum {
+		max := formatNumber(*schema.Maximum)
+		c.fail(domain.CodeFieldTooLarge, field, " must be at most "+max, "max", max)
+	}
+	if schema.MultipleOf > 0 && math.Mod(n, schema.MultipleOf) != 0 {
+		if schema.MultipleOf == 0.5 {
+			// The only steps the API has are ratings' half stars
+			c.fail(domain.CodeRatingHalfStep, field, " must be in half-star steps")
+		} else {
+			c.fail(domain.CodeFieldInvalid, field, " is invalid")
+		}
+	}
+}
+
+func (c *checker) checkArray(schema *Schema, items []interface{}, field string) {
+	if schema.MinItems != nil && len(items) < *schema.MinItems {
+		min := strconv.Itoa(*schema.MinItems)
+		c.fail(domain.CodeFieldTooFew, field, " must have at least "+min+" items", "min", min)
+	}
+	if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+		max := strconv.Itoa(*schema.MaxItems)
+		c.fail(domain.CodeFieldTooMany, field, " must have at most "+max+" items", "max", max)
+	}
+	if schema.UniqueItems && !distinct(items) {
+		c.fail(domain.CodeFieldNotUnique, field, " must not contain duplicates")
+	}
+	for i, item := range items {
+		c.check(schema.Items, item, field+"["+strconv.Itoa(i)+"]")
+	}
+}
+
+func (c *checker) checkObject(schema *Schema, object map[string]interface{}, field string) {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			c.fail(domain.CodeFieldRequired, child(field, name), " is required")
+		}
+	}
+	if schema.MaxProperties != nil && len(object) > *schema.MaxProperties {
+		max := strconv.Itoa(*schema.MaxProperties)
+		c.fail(domain.CodeFieldTooMany, field, " must have at most "+max+" items", "max", max)
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := object[name]
+		if property, ok := schema.Properties[name]; ok {
+			c.check(property, value, child(field, name))
+		} else if schema.AdditionalProperties != nil {
+			c.check(schema.AdditionalProperties, value, child(field, name))
+		}
+		// Other fields are left alone, as encoding/json ignores them
+	}
+}
+
+// fail records a detail about field. The whole body breaking its schema
+// means it is not the kind of JSON value expected at all.
+func (c *checker) fail(code, field, message string, params ...string) {
+	if field == "" {
+		c.details = append(c.details, domain.NewErrorDetail(domain.CodeMalformedBody, c.body+message))
+		return
+	}
+	params = append([]string{"field", field}, params...)
+	c.details = append(c.details, domain.NewErrorDetail(code, field+message, params...))
+}
+
+func child(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// distinct reports whether no two items are equal, comparing them by
+// their JSON encoding.
+func distinct(items []interface{}) bool {
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			continue
+		}
+		if seen[string(data)] {
+			return false
+		}
+		seen[string(data)] = true
+	}
+	return true
+}
+
+// withArticle puts "a" or "an" before the name of a type.
+func withArticle(typ string) string {
+	if strings.ContainsAny(typ[:1], "aeiou") {
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func compiled(pattern string) *regexp.Regexp {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	patterns.Store(pattern, re)
+	return re
+}
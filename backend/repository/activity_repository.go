@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *domain.Activity) error
+	GetByUserIDs(ctx context.Context, userIDs []primitive.ObjectID, cursor string, limit int) ([]domain.Activity, error)
+	DeleteByMovieID(ctx context.Context, movieID string) error
+}
+
+type activityRepository struct {
+	collection *mongo.Collection
+}
+
+func NewActivityRepository(db *mongo.Database) ActivityRepository {
+	collection := db.Collection("activities")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "_id", Value: -1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "movieId", Value: 1}},
+		},
+	)
+
+	return &activityRepository{
+		collection: collection,
+	}
+}
+
+func (r *activityRepository) Create(ctx context.Context, activity *domain.Activity) error {
+	result, err := r.collection.InsertOne(ctx, activity)
+	if err != nil {
+		return err
+	}
+	activity.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByUserIDs returns activity by any of the given users, newest first,
+// starting after cursor (the last activity ID of the previous page).
+func (r *activityRepository) GetByUserIDs(ctx context.Context, userIDs []primitive.ObjectID, cursor string, limit int) ([]domain.Activity, error) {
+	filter := bson.M{"userId": bson.M{"$in": userIDs}}
+	if cursor != "" {
+		cursorID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$lt": cursorID}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	mongoCursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer mongoCursor.Close(ctx)
+
+	var activities []domain.Activity
+	if err = mongoCursor.All(ctx, &activities); err != nil {
+		return nil, err
+	}
+
+	return activities, nil
+}
+
+func (r *activityRepository) DeleteByMovieID(ctx context.Context, movieID string) error {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteMany(ctx, bson.M{"movieId": objID})
+	return err
+}
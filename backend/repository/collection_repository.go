@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CollectionRepository interface {
+	Create(ctx context.Context, collection *domain.Collection) error
+	GetByID(ctx context.Context, id string) (*domain.Collection, error)
+	GetByInviteToken(ctx context.Context, token string) (*domain.Collection, error)
+	GetByMemberID(ctx context.Context, userID string, page, size int) ([]domain.Collection, int64, error)
+	Update(ctx context.Context, id string, collection *domain.Collection) error
+	Delete(ctx context.Context, id string) error
+	AddMember(ctx context.Context, id string, member domain.CollectionMember) (bool, error)
+	UpdateMemberRole(ctx context.Context, id, userID, role string) (bool, error)
+	RemoveMember(ctx context.Context, id, userID string) (bool, error)
+	SetInvite(ctx context.Context, id, token, role string) error
+}
+
+type collectionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCollectionRepository(db *mongo.Database) CollectionRepository {
+	collection := db.Collection("collections")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "members.userId", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "inviteToken", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	)
+
+	return &collectionRepository{
+		collection: collection,
+	}
+}
+
+func (r *collectionRepository) Create(ctx context.Context, collection *domain.Collection) error {
+	result, err := r.collection.InsertOne(ctx, collection)
+	if err != nil {
+		return err
+	}
+	collection.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *collectionRepository) GetByID(ctx context.Context, id string) (*domain.Collection, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection domain.Collection
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (r *collectionRepository) GetByInviteToken(ctx context.Context, token string) (*domain.Collection, error) {
+	var collection domain.Collection
+	err := r.collection.FindOne(ctx, bson.M{"inviteToken": token}).Decode(&collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &collection, nil
+}
+
+func (r *collectionRepository) GetByMemberID(ctx context.Context, userID string, page, size int) ([]domain.Collection, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+
+	filter := bson.M{"members.userId": objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var collections []domain.Collection
+	if err = cursor.All(ctx, &collections); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return collections, total, nil
+}
+
+// Update changes a collection's name and description.
+func (r *collectionRepository) Update(ctx context.Context, id string, collection *domain.Collection) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"name":        collection.Name,
+			"description": collection.Description,
+			"updatedAt":   collection.UpdatedAt,
+		}},
+	)
+	return err
+}
+
+func (r *collectionRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// AddMember adds a member and reports false if the user already belongs to
+// the collection.
+func (r *collectionRepository) AddMember(ctx context.Context, id string, member domain.CollectionMember) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "members.userId": bson.M{"$ne": member.UserID}},
+		bson.M{
+			"$push": bson.M{"members": member},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+func (r *collectionRepository) UpdateMemberRole(ctx context.Context, id, userID, role string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "members.userId": userObjID},
+		bson.M{"$set": bson.M{
+			"members.$.role": role,
+			"updatedAt":      time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *collectionRepository) RemoveMember(ctx context.Context, id, userID string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "members.userId": userObjID},
+		bson.M{
+			"$pull": bson.M{"members": bson.M{"userId": userObjID}},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// SetInvite replaces the collection's invite link. An empty token revokes it.
+func (r *collectionRepository) SetInvite(ctx context.Context, id, token, role string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{
+		"inviteToken": token,
+		"inviteRole":  role,
+		"updatedAt":   time.Now(),
+	}}
+	if token == "" {
+		update = bson.M{
+			"$unset": bson.M{"inviteToken": "", "inviteRole": ""},
+			"$set":   bson.M{"updatedAt": time.Now()},
+		}
+	}
+
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return err
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommentRepository interface {
+	Create(ctx context.Context, comment *domain.Comment) error
+	GetByID(ctx context.Context, id string) (*domain.Comment, error)
+	UpdateBody(ctx context.Context, id string, comment *domain.Comment) error
+	Tombstone(ctx context.Context, id, deletedBy string) error
+	Delete(ctx context.Context, id string) error
+	HasReplies(ctx context.Context, id string) (bool, error)
+	IncrementReplies(ctx context.Context, threadID string, delta int) error
+	GetThreads(ctx context.Context, movieID, cursor string, limit int) ([]domain.Comment, error)
+	GetReplies(ctx context.Context, threadID, cursor string, limit int) ([]domain.Comment, error)
+}
+
+type commentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCommentRepository(db *mongo.Database) CommentRepository {
+	collection := db.Collection("comments")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "movieId", Value: 1}, {Key: "threadId", Value: 1}, {Key: "_id", Value: -1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "threadId", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "parentId", Value: 1}},
+		},
+	)
+
+	return &commentRepository{
+		collection: collection,
+	}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	result, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return err
+	}
+	comment.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *commentRepository) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var comment domain.Comment
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// UpdateBody saves an edited comment along with its edit and flag markers.
+func (r *commentRepository) UpdateBody(ctx context.Context, id string, comment *domain.Comment) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"body":       comment.Body,
+			"edited":     comment.Edited,
+			"editedAt":   comment.EditedAt,
+			"flagged":    comment.Flagged,
+			"flagReason": comment.FlagReason,
+		}},
+	)
+	return err
+}
+
+// Tombstone clears a comment's body and flag but keeps its place in the
+// thread.
+func (r *commentRepository) Tombstone(ctx context.Context, id, deletedBy string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"body":      "",
+			"deleted":   true,
+			"deletedBy": deletedBy,
+			"flagged":   false,
+		}, "$unset": bson.M{"flagReason": ""}},
+	)
+	return err
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+func (r *commentRepository) HasReplies(ctx context.Context, id string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"parentId": objID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// IncrementReplies adjusts the reply counter kept on a thread's root comment.
+func (r *commentRepository) IncrementReplies(ctx context.Context, threadID string, delta int) error {
+	objID, err := primitive.ObjectIDFromHex(threadID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$inc": bson.M{"replyCount": delta}},
+	)
+	return err
+}
+
+// GetThreads returns a movie's top-level comments, newest first, starting
+// after cursor (the last comment ID of the previous page).
+func (r *commentRepository) GetThreads(ctx context.Context, movieID, cursor string, limit int) ([]domain.Comment, error) {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"movieId": objID, "threadId": bson.M{"$exists": false}}
+	if cursor != "" {
+		cursorID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$lt": cursorID}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// GetReplies returns every reply in a thread, oldest first, starting after cursor.
+func (r *commentRepository) GetReplies(ctx context.Context, threadID, cursor string, limit int) ([]domain.Comment, error) {
+	objID, err := primitive.ObjectIDFromHex(threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"threadId": objID}
+	if cursor != "" {
+		cursorID, err := primitive.ObjectIDFromHex(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter["_id"] = bson.M{"$gt": cursorID}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+func (r *commentRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]domain.Comment, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []domain.Comment
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
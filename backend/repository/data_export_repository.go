@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DataExportRepository interface {
+	Create(ctx context.Context, export *domain.DataExport) error
+	GetByID(ctx context.Context, id string) (*domain.DataExport, error)
+	FindRunning(ctx context.Context, userID primitive.ObjectID) (*domain.DataExport, error)
+	Save(ctx context.Context, export *domain.DataExport) error
+	FailRunning(ctx context.Context, reason string) (int64, error)
+	GetExpired(ctx context.Context, now time.Time) ([]domain.DataExport, error)
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.DataExport, error)
+	DeleteByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error)
+}
+
+type dataExportRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDataExportRepository(db *mongo.Database) DataExportRepository {
+	collection := db.Collection("data_exports")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "status", Value: 1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "expiresAt", Value: 1}},
+		},
+	)
+
+	return &dataExportRepository{
+		collection: collection,
+	}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	result, err := r.collection.InsertOne(ctx, export)
+	if err != nil {
+		return err
+	}
+	export.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, id string) (*domain.DataExport, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var export domain.DataExport
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// FindRunning returns the user's export still being built, if any.
+func (r *dataExportRepository) FindRunning(ctx context.Context, userID primitive.ObjectID) (*domain.DataExport, error) {
+	var export domain.DataExport
+	err := r.collection.FindOne(ctx, bson.M{
+		"userId": userID,
+		"status": domain.DataExportStatusRunning,
+	}).Decode(&export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// Save writes the export's progress. Only the export's builder writes to
+// it, so the whole document is replaced.
+func (r *dataExportRepository) Save(ctx context.Context, export *domain.DataExport) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": export.ID}, export)
+	return err
+}
+
+// FailRunning marks exports left running by a previous process as failed,
+// since nothing will finish them.
+func (r *dataExportRepository) FailRunning(ctx context.Context, reason string) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"status": domain.DataExportStatusRunning},
+		bson.M{"$set": bson.M{
+			"status":     domain.DataExportStatusFailed,
+			"error":      reason,
+			"finishedAt": time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// GetExpired returns completed exports whose archives are past expiry.
+func (r *dataExportRepository) GetExpired(ctx context.Context, now time.Time) ([]domain.DataExport, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":    domain.DataExportStatusCompleted,
+		"expiresAt": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var exports []domain.DataExport
+	if err = cursor.All(ctx, &exports); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.DataExport, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var exports []domain.DataExport
+	if err = cursor.All(ctx, &exports); err != nil {
+		return nil, err
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"userId": userID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type DiaryRepository interface {
+	Create(ctx context.Context, entry *domain.DiaryEntry) error
+	GetByID(ctx context.Context, id string) (*domain.DiaryEntry, error)
+	Delete(ctx context.Context, id string) error
+	GetByUserID(ctx context.Context, userID string, from, to time.Time, page, size int) ([]domain.DiaryEntry, int64, error)
+	GetAllByUserID(ctx context.Context, userID string, from, to time.Time) ([]domain.DiaryEntry, error)
+	HasEntry(ctx context.Context, userID, movieID string, watchedOn time.Time) (bool, error)
+	ForEachByUserID(ctx context.Context, userID string, fn func(*domain.DiaryEntryExport) error) error
+}
+
+type diaryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewDiaryRepository(db *mongo.Database) DiaryRepository {
+	collection := db.Collection("diary")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "watchedOn", Value: -1}},
+		},
+	)
+
+	return &diaryRepository{
+		collection: collection,
+	}
+}
+
+func (r *diaryRepository) Create(ctx context.Context, entry *domain.DiaryEntry) error {
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *diaryRepository) GetByID(ctx context.Context, id string) (*domain.DiaryEntry, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry domain.DiaryEntry
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *diaryRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+func (r *diaryRepository) GetByUserID(ctx context.Context, userID string, from, to time.Time, page, size int) ([]domain.DiaryEntry, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "watchedOn", Value: -1}, {Key: "_id", Value: -1}})
+
+	filter := bson.M{"userId": objID}
+	addDateRange(filter, "watchedOn", from, to)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []domain.DiaryEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// GetAllByUserID returns every diary entry in the range, oldest first, for export.
+func (r *diaryRepository) GetAllByUserID(ctx context.Context, userID string, from, to time.Time) ([]domain.DiaryEntry, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"userId": objID}
+	addDateRange(filter, "watchedOn", from, to)
+
+	opts := options.Find().SetSort(bson.D{{Key: "watchedOn", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []domain.DiaryEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// HasEntry reports whether the user logged the movie on watchedOn, or on
+// any day when watchedOn is zero.
+func (r *diaryRepository) HasEntry(ctx context.Context, userID, movieID string, watchedOn time.Time) (bool, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{"userId": userObjID, "movieId": movieObjID}
+	if !watchedOn.IsZero() {
+		filter["watchedOn"] = watchedOn
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ForEachByUserID streams the user's diary, with movie titles, to fn in
+// viewing order.
+func (r *diaryRepository) ForEachByUserID(ctx context.Context, userID string, fn func(*domain.DiaryEntryExport) error) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	pipeline := append(mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"userId": objID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "watchedOn", Value: 1}, {Key: "_id", Value: 1}}}},
+	}, movieTitleLookup...)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return forEach(ctx, cursor, fn)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ErasureRepository interface {
+	Create(ctx context.Context, request *domain.ErasureRequest) error
+	FindScheduled(ctx context.Context, userID primitive.ObjectID) (*domain.ErasureRequest, error)
+	GetLatest(ctx context.Context, userID primitive.ObjectID) (*domain.ErasureRequest, error)
+	Cancel(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error)
+	GetDue(ctx context.Context, now time.Time) ([]domain.ErasureRequest, error)
+	Start(ctx context.Context, id primitive.ObjectID) (bool, error)
+	RecordReviewedMovies(ctx context.Context, id primitive.ObjectID, movieIDs []primitive.ObjectID) error
+	Complete(ctx context.Context, id primitive.ObjectID, at time.Time, erased map[string]int64) error
+}
+
+type erasureRepository struct {
+	collection *mongo.Collection
+}
+
+// Erasure requests are the audit trail for erased accounts and are never
+// deleted.
+func NewErasureRepository(db *mongo.Database) ErasureRepository {
+	collection := db.Collection("erasure_requests")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "requestedAt", Value: -1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "scheduledFor", Value: 1}},
+		},
+	)
+
+	return &erasureRepository{
+		collection: collection,
+	}
+}
+
+func (r *erasureRepository) Create(ctx context.Context, request *domain.ErasureRequest) error {
+	result, err := r.collection.InsertOne(ctx, request)
+	if err != nil {
+		return err
+	}
+	request.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindScheduled returns the user's pending erasure, if any.
+func (r *erasureRepository) FindScheduled(ctx context.Context, userID primitive.ObjectID) (*domain.ErasureRequest, error) {
+	var request domain.ErasureRequest
+	err := r.collection.FindOne(ctx, bson.M{
+		"userId": userID,
+		"status": domain.ErasureStatusScheduled,
+	}).Decode(&request)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetLatest returns the user's most recent erasure request in any status.
+func (r *erasureRepository) GetLatest(ctx context.Context, userID primitive.ObjectID) (*domain.ErasureRequest, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "requestedAt", Value: -1}})
+
+	var request domain.ErasureRequest
+	err := r.collection.FindOne(ctx, bson.M{"userId": userID}, opts).Decode(&request)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// Cancel withdraws a scheduled erasure. It reports false when the request
+// was no longer scheduled, e.g. because erasure has already started.
+func (r *erasureRepository) Cancel(ctx context.Context, id primitive.ObjectID, at time.Time) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": domain.ErasureStatusScheduled},
+		bson.M{"$set": bson.M{
+			"status":      domain.ErasureStatusCancelled,
+			"cancelledAt": at,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// GetDue returns scheduled erasures whose grace period has passed, and
+// erasures left running by a previous process, which are safe to rerun.
+func (r *erasureRepository) GetDue(ctx context.Context, now time.Time) ([]domain.ErasureRequest, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":       bson.M{"$in": bson.A{domain.ErasureStatusScheduled, domain.ErasureStatusRunning}},
+		"scheduledFor": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var requests []domain.ErasureRequest
+	if err = cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Start moves a due erasure to running so it can no longer be cancelled.
+// It reports false when the request was cancelled in the meantime.
+func (r *erasureRepository) Start(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": bson.M{"$in": bson.A{domain.ErasureStatusScheduled, domain.ErasureStatusRunning}}},
+		bson.M{"$set": bson.M{"status": domain.ErasureStatusRunning}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RecordReviewedMovies adds movies to those the erasure refreshes the
+// ratings of.
+func (r *erasureRepository) RecordReviewedMovies(ctx context.Context, id primitive.ObjectID, movieIDs []primitive.ObjectID) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$addToSet": bson.M{"reviewedMovies": bson.M{"$each": movieIDs}}},
+	)
+	return err
+}
+
+// Complete records what the erasure removed. The movies it refreshed are
+// dropped, as they would tell what the user reviewed.
+func (r *erasureRepository) Complete(ctx context.Context, id primitive.ObjectID, at time.Time, erased map[string]int64) error {
+	_, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{
+			"status":      domain.ErasureStatusCompleted,
+			"completedAt": at,
+			"erased":      erased,
+		}, "$unset": bson.M{"reviewedMovies": ""}},
+	)
+	return err
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// addDateRange restricts field to [from, to] on filter. A zero time leaves
+// that side of the range open.
+func addDateRange(filter bson.M, field string, from, to time.Time) {
+	dateFilter := bson.M{}
+	if !from.IsZero() {
+		dateFilter["$gte"] = from
+	}
+	if !to.IsZero() {
+		dateFilter["$lte"] = to
+	}
+	if len(dateFilter) > 0 {
+		filter[field] = dateFilter
+	}
+}
+
+// toObjectIDs converts hex IDs, failing on the first invalid one.
+func toObjectIDs(ids []string) ([]primitive.ObjectID, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		objIDs = append(objIDs, objID)
+	}
+	return objIDs, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FollowRepository interface {
+	Create(ctx context.Context, follow *domain.Follow) error
+	Delete(ctx context.Context, followerID, followeeID string) (bool, error)
+	GetFollowers(ctx context.Context, userID string, page, size int) ([]domain.Follow, int64, error)
+	GetFollowing(ctx context.Context, userID string, page, size int) ([]domain.Follow, int64, error)
+	GetFolloweeIDs(ctx context.Context, userID string) ([]primitive.ObjectID, error)
+}
+
+type followRepository struct {
+	collection *mongo.Collection
+}
+
+func NewFollowRepository(db *mongo.Database) FollowRepository {
+	collection := db.Collection("follows")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "followerId", Value: 1}, {Key: "followeeId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "followeeId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+	)
+
+	return &followRepository{
+		collection: collection,
+	}
+}
+
+func (r *followRepository) Create(ctx context.Context, follow *domain.Follow) error {
+	result, err := r.collection.InsertOne(ctx, follow)
+	if err != nil {
+		return err
+	}
+	follow.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Delete removes a follow and reports whether one existed.
+func (r *followRepository) Delete(ctx context.Context, followerID, followeeID string) (bool, error) {
+	followerObjID, err := primitive.ObjectIDFromHex(followerID)
+	if err != nil {
+		return false, err
+	}
+	followeeObjID, err := primitive.ObjectIDFromHex(followeeID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"followerId": followerObjID, "followeeId": followeeObjID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *followRepository) GetFollowers(ctx context.Context, userID string, page, size int) ([]domain.Follow, int64, error) {
+	return r.page(ctx, "followeeId", userID, page, size)
+}
+
+func (r *followRepository) GetFollowing(ctx context.Context, userID string, page, size int) ([]domain.Follow, int64, error) {
+	return r.page(ctx, "followerId", userID, page, size)
+}
+
+// GetFolloweeIDs returns the IDs of every user that userID follows.
+func (r *followRepository) GetFolloweeIDs(ctx context.Context, userID string) ([]primitive.ObjectID, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetProjection(bson.M{"followeeId": 1})
+	cursor, err := r.collection.Find(ctx, bson.M{"followerId": objID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []domain.Follow
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FolloweeID)
+	}
+	return ids, nil
+}
+
+func (r *followRepository) page(ctx context.Context, field, userID string, page, size int) ([]domain.Follow, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	filter := bson.M{field: objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var follows []domain.Follow
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return follows, total, nil
+}
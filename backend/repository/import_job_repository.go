@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, id string) (*domain.ImportJob, error)
+	Save(ctx context.Context, job *domain.ImportJob) error
+	FailRunning(ctx context.Context, reason string) (int64, error)
+}
+
+type importJobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewImportJobRepository(db *mongo.Database) ImportJobRepository {
+	collection := db.Collection("import_jobs")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "status", Value: 1}},
+		},
+	)
+
+	return &importJobRepository{
+		collection: collection,
+	}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	result, err := r.collection.InsertOne(ctx, job)
+	if err != nil {
+		return err
+	}
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *importJobRepository) GetByID(ctx context.Context, id string) (*domain.ImportJob, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var job domain.ImportJob
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&job)
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Save writes the job's progress. Only the job's runner writes to it, so
+// the whole document is replaced.
+func (r *importJobRepository) Save(ctx context.Context, job *domain.ImportJob) error {
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": job.ID}, job)
+	return err
+}
+
+// FailRunning marks jobs left running by a previous process as failed,
+// since nothing will finish them.
+func (r *importJobRepository) FailRunning(ctx context.Context, reason string) (int64, error) {
+	result, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"status": domain.ImportStatusRunning},
+		bson.M{"$set": bson.M{
+			"status":     domain.ImportStatusFailed,
+			"error":      reason,
+			"finishedAt": time.Now(),
+		}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
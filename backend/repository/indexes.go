@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ensureIndexes creates the given indexes on the collection. Index creation
+// is idempotent, so it is safe to call on every start-up; failures are logged
+// rather than returned so a missing index never stops the API from serving.
+func ensureIndexes(collection *mongo.Collection, models ...mongo.IndexModel) {
+	if _, err := collection.Indexes().CreateMany(context.Background(), models); err != nil {
+		log.Printf("failed to create indexes on %s: %v", collection.Name(), err)
+	}
+}
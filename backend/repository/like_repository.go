@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LikeRepository interface {
+	Create(ctx context.Context, like *domain.Like) error
+	Delete(ctx context.Context, userID, movieID string) (bool, error)
+	GetByUserID(ctx context.Context, userID string, page, size int) ([]domain.Like, int64, error)
+	CountByMovieID(ctx context.Context, movieID string) (int64, error)
+}
+
+type likeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLikeRepository(db *mongo.Database) LikeRepository {
+	collection := db.Collection("likes")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "movieId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "movieId", Value: 1}},
+		},
+	)
+
+	return &likeRepository{
+		collection: collection,
+	}
+}
+
+func (r *likeRepository) Create(ctx context.Context, like *domain.Like) error {
+	result, err := r.collection.InsertOne(ctx, like)
+	if err != nil {
+		return err
+	}
+	like.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Delete removes a like and reports whether one existed.
+func (r *likeRepository) Delete(ctx context.Context, userID, movieID string) (bool, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"userId": userObjID, "movieId": movieObjID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *likeRepository) GetByUserID(ctx context.Context, userID string, page, size int) ([]domain.Like, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	filter := bson.M{"userId": objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var likes []domain.Like
+	if err = cursor.All(ctx, &likes); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return likes, total, nil
+}
+
+// CountByMovieID counts the likes of a movie.
+func (r *likeRepository) CountByMovieID(ctx context.Context, movieID string) (int64, error) {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return 0, err
+	}
+	return r.collection.CountDocuments(ctx, bson.M{"movieId": objID})
+}
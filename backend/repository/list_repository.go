@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ListRepository interface {
+	Create(ctx context.Context, list *domain.MovieList) error
+	GetByID(ctx context.Context, id string) (*domain.MovieList, error)
+	GetByShareToken(ctx context.Context, token string) (*domain.MovieList, error)
+	GetByUserID(ctx context.Context, userID string, page, size int) ([]domain.MovieList, int64, error)
+	Update(ctx context.Context, id string, list *domain.MovieList) error
+	Delete(ctx context.Context, id string) error
+	AddEntries(ctx context.Context, id string, entries []domain.ListEntry) (bool, error)
+	RemoveEntries(ctx context.Context, id string, movieIDs []string) error
+	UpdateEntryNote(ctx context.Context, id, movieID, note string) (bool, error)
+	ReplaceEntries(ctx context.Context, id string, previous, entries []domain.ListEntry) (bool, error)
+	RemoveMovieFromAll(ctx context.Context, movieID string) error
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.MovieList, error)
+	ExistsByName(ctx context.Context, userID, name string) (bool, error)
+	ForEachByUserID(ctx context.Context, userID string, fn func(*domain.MovieList) error) error
+}
+
+type listRepository struct {
+	collection *mongo.Collection
+}
+
+func NewListRepository(db *mongo.Database) ListRepository {
+	collection := db.Collection("lists")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "updatedAt", Value: -1}},
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "shareToken", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "entries.movieId", Value: 1}},
+		},
+	)
+
+	return &listRepository{
+		collection: collection,
+	}
+}
+
+// Create stores a new list. A list without entries is stored with an empty
+// array, so that it compares equal to one whose entries were all removed.
+func (r *listRepository) Create(ctx context.Context, list *domain.MovieList) error {
+	if list.Entries == nil {
+		list.Entries = []domain.ListEntry{}
+	}
+	result, err := r.collection.InsertOne(ctx, list)
+	if err != nil {
+		return err
+	}
+	list.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *listRepository) GetByID(ctx context.Context, id string) (*domain.MovieList, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var list domain.MovieList
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (r *listRepository) GetByShareToken(ctx context.Context, token string) (*domain.MovieList, error) {
+	var list domain.MovieList
+	err := r.collection.FindOne(ctx, bson.M{"shareToken": token}).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+func (r *listRepository) GetByUserID(ctx context.Context, userID string, page, size int) ([]domain.MovieList, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+
+	filter := bson.M{"userId": objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var lists []domain.MovieList
+	if err = cursor.All(ctx, &lists); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return lists, total, nil
+}
+
+// Update changes a list's name, description and visibility. Entries are
+// managed through the dedicated entry methods.
+func (r *listRepository) Update(ctx context.Context, id string, list *domain.MovieList) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"name":        list.Name,
+			"description": list.Description,
+			"visibility":  list.Visibility,
+			"updatedAt":   list.UpdatedAt,
+		}},
+	)
+	return err
+}
+
+func (r *listRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// AddEntries appends entries to the end of the list, unless one of their
+// movies is already on it, and reports whether it did.
+func (r *listRepository) AddEntries(ctx context.Context, id string, entries []domain.ListEntry) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	movieIDs := make([]primitive.ObjectID, len(entries))
+	for i, entry := range entries {
+		movieIDs[i] = entry.MovieID
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "entries.movieId": bson.M{"$nin": movieIDs}},
+		bson.M{
+			"$push": bson.M{"entries": bson.M{"$each": entries}},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func (r *listRepository) RemoveEntries(ctx context.Context, id string, movieIDs []string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	movieObjIDs, err := toObjectIDs(movieIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$pull": bson.M{"entries": bson.M{"movieId": bson.M{"$in": movieObjIDs}}},
+			"$set":  bson.M{"updatedAt": time.Now()},
+		},
+	)
+	return err
+}
+
+// UpdateEntryNote sets the note on one entry and reports whether the movie is on the list.
+func (r *listRepository) UpdateEntryNote(ctx context.Context, id, movieID, note string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "entries.movieId": movieObjID},
+		bson.M{"$set": bson.M{
+			"entries.$.note": note,
+			"updatedAt":      time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// ReplaceEntries overwrites the list's entries, used to persist a new order.
+// It only does so while the list still holds the entries previous, as read
+// when the new ones were worked out, and reports whether it did. No
+// previous entries match an empty array, and a null or missing field too.
+func (r *listRepository) ReplaceEntries(ctx context.Context, id string, previous, entries []domain.ListEntry) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	var held interface{} = previous
+	if len(previous) == 0 {
+		held = bson.M{"$in": bson.A{bson.A{}, nil}}
+	}
+	if entries == nil {
+		entries = []domain.ListEntry{}
+	}
+
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID, "entries": held},
+		bson.M{"$set": bson.M{
+			"entries":   entries,
+			"updatedAt": time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// RemoveMovieFromAll pulls a movie out of every list that references it.
+func (r *listRepository) RemoveMovieFromAll(ctx context.Context, movieID string) error {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateMany(
+		ctx,
+		bson.M{"entries.movieId": objID},
+		bson.M{"$pull": bson.M{"entries": bson.M{"movieId": objID}}},
+	)
+	return err
+}
+
+// GetByIDs returns the lists matching ids without their entries.
+func (r *listRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.MovieList, error) {
+	opts := options.Find().SetProjection(bson.M{"entries": 0})
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var lists []domain.MovieList
+	if err = cursor.All(ctx, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// ExistsByName reports whether the user already has a list with this name.
+func (r *listRepository) ExistsByName(ctx context.Context, userID, name string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"userId": objID, "name": name}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ForEachByUserID streams the user's lists to fn, oldest first.
+func (r *listRepository) ForEachByUserID(ctx context.Context, userID string, fn func(*domain.MovieList) error) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": objID}, opts)
+	if err != nil {
+		return err
+	}
+	return forEach(ctx, cursor, fn)
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type MovieRedirectRepository interface {
+	Create(ctx context.Context, redirect *domain.MovieRedirect) error
+	GetByID(ctx context.Context, id string) (*domain.MovieRedirect, error)
+	Repoint(ctx context.Context, from, to primitive.ObjectID) error
+}
+
+type movieRedirectRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMovieRedirectRepository(db *mongo.Database) MovieRedirectRepository {
+	collection := db.Collection("movie_redirects")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "movieId", Value: 1}},
+		},
+	)
+
+	return &movieRedirectRepository{
+		collection: collection,
+	}
+}
+
+func (r *movieRedirectRepository) Create(ctx context.Context, redirect *domain.MovieRedirect) error {
+	_, err := r.collection.InsertOne(ctx, redirect)
+	return err
+}
+
+// GetByID returns the redirect for a merged-away movie ID.
+func (r *movieRedirectRepository) GetByID(ctx context.Context, id string) (*domain.MovieRedirect, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var redirect domain.MovieRedirect
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&redirect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redirect, nil
+}
+
+// Repoint moves redirects aimed at a movie that is itself being merged, so
+// redirects never chain.
+func (r *movieRedirectRepository) Repoint(ctx context.Context, from, to primitive.ObjectID) error {
+	_, err := r.collection.UpdateMany(
+		ctx,
+		bson.M{"movieId": from},
+		bson.M{"$set": bson.M{"movieId": to}},
+	)
+	return err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MovieReferenceRepository moves everything that refers to one movie over
+// to another, for merging duplicate movies, and deletes what users added
+// about a movie along with it.
+type MovieReferenceRepository interface {
+	Reassign(ctx context.Context, from, to primitive.ObjectID) (map[string]int64, error)
+	DeleteAll(ctx context.Context, movieID primitive.ObjectID) (map[string]int64, error)
+}
+
+// perUserMovieCollections hold at most one document per user and movie.
+var perUserMovieCollections = []string{"reviews", "likes", "watchlist"}
+
+// movieCollections may hold any number of documents per movie.
+var movieCollections = []string{"diary", "comments", "activities"}
+
+// movieContentCollections hold what users added about a movie, which
+// means nothing once the movie is deleted.
+var movieContentCollections = []string{"reviews", "likes", "watchlist", "diary", "comments"}
+
+type movieReferenceRepository struct {
+	db *mongo.Database
+}
+
+func NewMovieReferenceRepository(db *mongo.Database) MovieReferenceRepository {
+	return &movieReferenceRepository{db: db}
+}
+
+// Reassign points references to from at to. Where a user already has a
+// review, like or watchlist entry for to, or a list already holds to, the
+// reference to from is dropped instead. It returns how many references
+// moved per collection.
+func (r *movieReferenceRepository) Reassign(ctx context.Context, from, to primitive.ObjectID) (map[string]int64, error) {
+	moved := make(map[string]int64)
+
+	for _, name := range perUserMovieCollections {
+		collection := r.db.Collection(name)
+
+		userIDs, err := collection.Distinct(ctx, "userId", bson.M{"movieId": to})
+		if err != nil {
+			return nil, err
+		}
+		if len(userIDs) > 0 {
+			_, err = collection.DeleteMany(ctx, bson.M{"movieId": from, "userId": bson.M{"$in": userIDs}})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result, err := collection.UpdateMany(ctx, bson.M{"movieId": from}, bson.M{"$set": bson.M{"movieId": to}})
+		if err != nil {
+			return nil, err
+		}
+		moved[name] = result.ModifiedCount
+	}
+
+	for _, name := range movieCollections {
+		result, err := r.db.Collection(name).UpdateMany(ctx, bson.M{"movieId": from}, bson.M{"$set": bson.M{"movieId": to}})
+		if err != nil {
+			return nil, err
+		}
+		moved[name] = result.ModifiedCount
+	}
+
+	lists := r.db.Collection("lists")
+	_, err := lists.UpdateMany(
+		ctx,
+		bson.M{"entries.movieId": bson.M{"$all": bson.A{from, to}}},
+		bson.M{"$pull": bson.M{"entries": bson.M{"movieId": from}}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result, err := lists.UpdateMany(
+		ctx,
+		bson.M{"entries.movieId": from},
+		bson.M{"$set": bson.M{"entries.$[entry].movieId": to}},
+		options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: []interface{}{bson.M{"entry.movieId": from}},
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	moved["lists"] = result.ModifiedCount
+
+	return moved, nil
+}
+
+// DeleteAll deletes the reviews, likes, watchlist and diary entries and
+// comments about a movie. It returns how many were deleted per collection.
+func (r *movieReferenceRepository) DeleteAll(ctx context.Context, movieID primitive.ObjectID) (map[string]int64, error) {
+	deleted := make(map[string]int64)
+	for _, name := range movieContentCollections {
+		result, err := r.db.Collection(name).DeleteMany(ctx, bson.M{"movieId": movieID})
+		if err != nil {
+			return nil, err
+		}
+		deleted[name] = result.DeletedCount
+	}
+	return deleted, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+func TestMovieReferencesDeleteAll(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("delete", func(mt *mtest.T) {
+		movieID := primitive.NewObjectID()
+		for range movieContentCollections {
+			mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}))
+		}
+
+		deleted, err := (&movieReferenceRepository{db: mt.DB}).DeleteAll(context.Background(), movieID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events := mt.GetAllStartedEvents()
+		for _, name := range []string{"reviews", "likes", "watchlist", "diary", "comments"} {
+			deletes, _ := sentWrites(events, "delete", name)
+			if len(deletes) != 1 || deletes[0].Lookup("q", "movieId").ObjectID() != movieID {
+				t.Errorf("%s deletes = %v, want one of the movie's documents", name, deletes)
+			}
+			if deleted[name] != 2 {
+				t.Errorf("deleted[%s] = %d, want 2", name, deleted[name])
+			}
+		}
+	})
+}
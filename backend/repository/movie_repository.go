@@ -12,26 +12,63 @@ import (
 type MovieRepository interface {
 	Create(ctx context.Context, movie *domain.Movie) error
 	GetByID(ctx context.Context, id string) (*domain.Movie, error)
-	GetAll(ctx context.Context, page, size int) ([]domain.Movie, int64, error)
+	GetAll(ctx context.Context, page, size int, sortBy string) ([]domain.Movie, int64, error)
 	SearchByTitle(ctx context.Context, title string, page, size int) ([]domain.Movie, int64, error)
 	Update(ctx context.Context, id string, movie *domain.Movie) error
 	Delete(ctx context.Context, id string) error
 	GetByUserID(ctx context.Context, userID string, page, size int) ([]domain.Movie, int64, error)
+	UpdateRating(ctx context.Context, id string, average float64, count int64) error
+	GetByIDs(ctx context.Context, ids []string) ([]domain.Movie, error)
+	GetByCollectionID(ctx context.Context, collectionID string, page, size int) ([]domain.Movie, int64, error)
+	ClearCollection(ctx context.Context, collectionID string) error
+	IncrementLikes(ctx context.Context, id string, delta int) error
+	SetLikes(ctx context.Context, id string, count int64) error
+	GetCatalogue(ctx context.Context) ([]domain.Movie, error)
+	FindByTitleYear(ctx context.Context, title string, year int) (*domain.Movie, error)
+	FindByIMDbID(ctx context.Context, imdbID string) (*domain.Movie, error)
+	ForEachByUserID(ctx context.Context, userID string, fn func(*domain.Movie) error) error
+	SetPoster(ctx context.Context, id, key string) error
+	FillPoster(ctx context.Context, id, key, url string) (bool, error)
+	SetMetadata(ctx context.Context, id string, ref *domain.MetadataRef) error
+	SetCollection(ctx context.Context, id string, collectionID primitive.ObjectID) error
 }
 
 type movieRepository struct {
 	collection *mongo.Collection
 }
 
+// titleCollation compares titles ignoring case.
+var titleCollation = &options.Collation{Locale: "en", Strength: 2}
+
 func NewMovieRepository(db *mongo.Database) MovieRepository {
+	collection := db.Collection("movies")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "title", Value: 1}, {Key: "year", Value: 1}},
+			Options: options.Index().SetCollation(titleCollation),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "imdbId", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+		// Serves the popular sort of GetAll
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "likeCount", Value: -1}, {Key: "_id", Value: 1}},
+		},
+	)
+
 	return &movieRepository{
-		collection: db.Collection("movies"),
+		collection: collection,
 	}
 }
 
 func (r *movieRepository) Create(ctx context.Context, movie *domain.Movie) error {
-	_, err := r.collection.InsertOne(ctx, movie)
-	return err
+	result, err := r.collection.InsertOne(ctx, movie)
+	if err != nil {
+		return err
+	}
+	movie.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
 }
 
 func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
@@ -49,12 +86,16 @@ func (r *movieRepository) GetByID(ctx context.Context, id string) (*domain.Movie
 	return &movie, nil
 }
 
-func (r *movieRepository) GetAll(ctx context.Context, page, size int) ([]domain.Movie, int64, error) {
+func (r *movieRepository) GetAll(ctx context.Context, page, size int, sortBy string) ([]domain.Movie, int64, error) {
 	skip := int64((page - 1) * size)
 	opts := options.Find().
 		SetSkip(skip).
 		SetLimit(int64(size))
 
+	if sortBy == domain.MovieSortPopular {
+		opts.SetSort(bson.D{{Key: "likeCount", Value: -1}, {Key: "_id", Value: 1}})
+	}
+
 	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
 	if err != nil {
 		return nil, 0, err
@@ -80,12 +121,15 @@ func (r *movieRepository) SearchByTitle(ctx context.Context, title string, page,
 		SetSkip(skip).
 		SetLimit(int64(size))
 
-	filter := bson.M{
-		"title": bson.M{
-			"$regex":   title,
-			"$options": "i", // case insensitive
-		},
+	// Match the title in any language
+	pattern := bson.M{
+		"$regex":   title,
+		"$options": "i", // case insensitive
 	}
+	filter := bson.M{"$or": bson.A{
+		bson.M{"title": pattern},
+		bson.M{"translations.title": pattern},
+	}}
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -106,17 +150,44 @@ func (r *movieRepository) SearchByTitle(ctx context.Context, title string, page,
 	return movies, total, nil
 }
 
+// Update writes a movie's details, the fields it is created and edited
+// with. Optional details left empty are removed rather than skipped, so
+// an edit can clear them. The poster, metadata reference, owner and
+// collection are changed only by their own updates, so an edit made from
+// an older read cannot undo them, and counters are left alone.
 func (r *movieRepository) Update(ctx context.Context, id string, movie *domain.Movie) error {
 	objID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.collection.UpdateOne(
-		ctx,
-		bson.M{"_id": objID},
-		bson.M{"$set": movie},
-	)
+	set := bson.M{
+		"title":        movie.Title,
+		"description":  movie.Description,
+		"trailer":      movie.Trailer,
+		"actors":       movie.Actors,
+		"genres":       movie.Genres,
+		"translations": movie.Translations,
+	}
+	unset := bson.M{}
+	optional := func(key string, value interface{}, empty bool) {
+		if empty {
+			unset[key] = ""
+		} else {
+			set[key] = value
+		}
+	}
+	optional("crew", movie.Crew, len(movie.Crew) == 0)
+	optional("year", movie.Year, movie.Year == 0)
+	optional("imdbId", movie.IMDbID, movie.IMDbID == "")
+	optional("locale", movie.Locale, movie.Locale == "")
+	optional("trailerVideo", movie.TrailerVideo, movie.TrailerVideo == nil)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
 	return err
 }
 
@@ -160,4 +231,271 @@ func (r *movieRepository) GetByUserID(ctx context.Context, userID string, page,
 	}
 
 	return movies, total, nil
-}
\ No newline at end of file
+}
+
+// UpdateRating stores the aggregated review rating on a movie.
+func (r *movieRepository) UpdateRating(ctx context.Context, id string, average float64, count int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"averageRating": average,
+			"ratingCount":   count,
+		}},
+	)
+	return err
+}
+
+// GetByIDs returns the movies matching ids. Invalid or unknown IDs are skipped.
+func (r *movieRepository) GetByIDs(ctx context.Context, ids []string) ([]domain.Movie, error) {
+	objIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if objID, err := primitive.ObjectIDFromHex(id); err == nil {
+			objIDs = append(objIDs, objID)
+		}
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": objIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []domain.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+func (r *movieRepository) GetByCollectionID(ctx context.Context, collectionID string, page, size int) ([]domain.Movie, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size))
+
+	filter := bson.M{"collectionId": objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []domain.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return movies, total, nil
+}
+
+// ClearCollection detaches every movie from a collection, leaving each with
+// its original creator as sole owner.
+func (r *movieRepository) ClearCollection(ctx context.Context, collectionID string) error {
+	objID, err := primitive.ObjectIDFromHex(collectionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateMany(
+		ctx,
+		bson.M{"collectionId": objID},
+		bson.M{"$unset": bson.M{"collectionId": ""}},
+	)
+	return err
+}
+
+// IncrementLikes atomically adjusts a movie's like counter by delta.
+func (r *movieRepository) IncrementLikes(ctx context.Context, id string, delta int) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$inc": bson.M{"likeCount": delta}},
+	)
+	return err
+}
+
+// SetLikes stores a recounted like counter on a movie.
+func (r *movieRepository) SetLikes(ctx context.Context, id string, count int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{"likeCount": count}},
+	)
+	return err
+}
+
+// GetCatalogue returns every movie with only the fields used to compare
+// movies with each other.
+func (r *movieRepository) GetCatalogue(ctx context.Context) ([]domain.Movie, error) {
+	opts := options.Find().SetProjection(bson.M{
+		"title":       1,
+		"description": 1,
+		"actors":      1,
+		"genres":      1,
+		"crew":        1,
+		"year":        1,
+		"imdbId":      1,
+	})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []domain.Movie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+// FindByTitleYear finds a movie by title, ignoring case, and release year.
+// A zero year matches movies with no year recorded.
+func (r *movieRepository) FindByTitleYear(ctx context.Context, title string, year int) (*domain.Movie, error) {
+	filter := bson.M{"title": title, "year": year}
+	if year == 0 {
+		filter["year"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	var movie domain.Movie
+	err := r.collection.FindOne(ctx, filter, options.FindOne().SetCollation(titleCollation)).Decode(&movie)
+	if err != nil {
+		return nil, err
+	}
+
+	return &movie, nil
+}
+
+func (r *movieRepository) FindByIMDbID(ctx context.Context, imdbID string) (*domain.Movie, error) {
+	var movie domain.Movie
+	err := r.collection.FindOne(ctx, bson.M{"imdbId": imdbID}).Decode(&movie)
+	if err != nil {
+		return nil, err
+	}
+
+	return &movie, nil
+}
+
+// ForEachByUserID streams the user's movies to fn, oldest first.
+func (r *movieRepository) ForEachByUserID(ctx context.Context, userID string, fn func(*domain.Movie) error) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{"userId": objID}, opts)
+	if err != nil {
+		return err
+	}
+	return forEach(ctx, cursor, fn)
+}
+
+// SetPoster points a movie at an uploaded poster, replacing any external
+// poster URL.
+func (r *movieRepository) SetPoster(ctx context.Context, id, key string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{
+			"$set":   bson.M{"posterKey": key},
+			"$unset": bson.M{"poster": ""},
+		},
+	)
+	return err
+}
+
+// FillPoster gives a movie an uploaded poster key or an external poster
+// URL if it has neither, reporting whether it did.
+func (r *movieRepository) FillPoster(ctx context.Context, id, key, url string) (bool, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return false, err
+	}
+
+	set := bson.M{}
+	if key != "" {
+		set["posterKey"] = key
+	} else {
+		set["poster"] = url
+	}
+	result, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{
+			"_id":       objID,
+			"posterKey": bson.M{"$in": bson.A{"", nil}},
+			"poster":    bson.M{"$in": bson.A{"", nil}},
+		},
+		bson.M{"$set": set},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// SetMetadata records the provider entry a movie was last refreshed from.
+func (r *movieRepository) SetMetadata(ctx context.Context, id string, ref *domain.MetadataRef) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{"metadata": ref}},
+	)
+	return err
+}
+
+// SetCollection moves a movie into a collection, or out of any with a
+// zero collectionID.
+func (r *movieRepository) SetCollection(ctx context.Context, id string, collectionID primitive.ObjectID) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{"$set": bson.M{"collectionId": collectionID}}
+	if collectionID.IsZero() {
+		update = bson.M{"$unset": bson.M{"collectionId": ""}}
+	}
+	_, err = r.collection.UpdateOne(ctx, bson.M{"_id": objID}, update)
+	return err
+}
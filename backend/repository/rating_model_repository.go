@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RatingModelRepository interface {
+	GetByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]domain.RatingNeighbours, error)
+	ReplaceAll(ctx context.Context, model []domain.RatingNeighbours, computedAt time.Time) error
+}
+
+type ratingModelRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRatingModelRepository(db *mongo.Database) RatingModelRepository {
+	collection := db.Collection("rating_model")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "computedAt", Value: 1}},
+		},
+	)
+
+	return &ratingModelRepository{
+		collection: collection,
+	}
+}
+
+func (r *ratingModelRepository) GetByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]domain.RatingNeighbours, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": movieIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []domain.RatingNeighbours
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// ReplaceAll swaps in a newly built model. Rows from earlier builds that the
+// new model no longer has are removed afterwards.
+func (r *ratingModelRepository) ReplaceAll(ctx context.Context, model []domain.RatingNeighbours, computedAt time.Time) error {
+	for start := 0; start < len(model); start += similarityBatchSize {
+		end := start + similarityBatchSize
+		if end > len(model) {
+			end = len(model)
+		}
+
+		models := make([]mongo.WriteModel, 0, end-start)
+		for _, row := range model[start:end] {
+			models = append(models, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": row.MovieID}).
+				SetReplacement(row).
+				SetUpsert(true))
+		}
+
+		if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"computedAt": bson.M{"$lt": computedAt}})
+	return err
+}
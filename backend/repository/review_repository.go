@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *domain.Review) error
+	GetByUserAndMovie(ctx context.Context, userID, movieID string) (*domain.Review, error)
+	GetByMovieID(ctx context.Context, movieID string, page, size int) ([]domain.Review, int64, error)
+	GetLatestByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID, limit int) ([]domain.Review, error)
+	GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Review, error)
+	Update(ctx context.Context, id string, review *domain.Review) error
+	Delete(ctx context.Context, id string) error
+	AggregateRating(ctx context.Context, movieID string) (float64, int64, error)
+	GetAllRatings(ctx context.Context) ([]domain.Review, error)
+	GetAllByUserID(ctx context.Context, userID string) ([]domain.Review, error)
+	ForEachByUserID(ctx context.Context, userID string, fn func(*domain.ReviewExport) error) error
+}
+
+type reviewRepository struct {
+	collection *mongo.Collection
+}
+
+func NewReviewRepository(db *mongo.Database) ReviewRepository {
+	collection := db.Collection("reviews")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "movieId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "movieId", Value: 1}, {Key: "createdAt", Value: -1}},
+		},
+	)
+
+	return &reviewRepository{
+		collection: collection,
+	}
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *domain.Review) error {
+	result, err := r.collection.InsertOne(ctx, review)
+	if err != nil {
+		return err
+	}
+	review.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *reviewRepository) GetByUserAndMovie(ctx context.Context, userID, movieID string) (*domain.Review, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	var review domain.Review
+	err = r.collection.FindOne(ctx, bson.M{"userId": userObjID, "movieId": movieObjID}).Decode(&review)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *reviewRepository) GetByMovieID(ctx context.Context, movieID string, page, size int) ([]domain.Review, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	filter := bson.M{"movieId": objID}
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []domain.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// GetByIDs returns the reviews matching ids. Unknown IDs are skipped.
+func (r *reviewRepository) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Review, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []domain.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// GetLatestByMovieIDs returns the newest reviews of each of the movies, at
+// most limit per movie, newest first.
+func (r *reviewRepository) GetLatestByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID, limit int) ([]domain.Review, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"movieId": bson.M{"$in": movieIDs}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "createdAt", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$movieId",
+			"reviews": bson.M{"$push": "$$ROOT"},
+		}}},
+		{{Key: "$project", Value: bson.M{"reviews": bson.M{"$slice": bson.A{"$reviews", limit}}}}},
+		{{Key: "$unwind", Value: "$reviews"}},
+		{{Key: "$replaceRoot", Value: bson.M{"newRoot": "$reviews"}}},
+		{{Key: "$sort", Value: bson.D{{Key: "createdAt", Value: -1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []domain.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, id string, review *domain.Review) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": objID},
+		bson.M{"$set": bson.M{
+			"rating":    review.Rating,
+			"text":      review.Text,
+			"spoiler":   review.Spoiler,
+			"updatedAt": review.UpdatedAt,
+		}},
+	)
+	return err
+}
+
+func (r *reviewRepository) Delete(ctx context.Context, id string) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.collection.DeleteOne(ctx, bson.M{"_id": objID})
+	return err
+}
+
+// AggregateRating returns the average rating and number of reviews for a movie.
+func (r *reviewRepository) AggregateRating(ctx context.Context, movieID string) (float64, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"movieId": objID}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     nil,
+			"average": bson.M{"$avg": "$rating"},
+			"count":   bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Average float64 `bson:"average"`
+		Count   int64   `bson:"count"`
+	}
+	if err = cursor.All(ctx, &results); err != nil {
+		return 0, 0, err
+	}
+	if len(results) == 0 {
+		return 0, 0, nil
+	}
+
+	return results[0].Average, results[0].Count, nil
+}
+
+// GetAllRatings returns every review with only its user, movie and rating,
+// for building the recommendation model.
+func (r *reviewRepository) GetAllRatings(ctx context.Context) ([]domain.Review, error) {
+	opts := options.Find().SetProjection(bson.M{"userId": 1, "movieId": 1, "rating": 1})
+	return r.find(ctx, bson.M{}, opts)
+}
+
+func (r *reviewRepository) GetAllByUserID(ctx context.Context, userID string) ([]domain.Review, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetProjection(bson.M{"userId": 1, "movieId": 1, "rating": 1})
+	return r.find(ctx, bson.M{"userId": objID}, opts)
+}
+
+func (r *reviewRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]domain.Review, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var reviews []domain.Review
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+// ForEachByUserID streams the user's reviews, with their movie titles, to
+// fn, oldest first.
+func (r *reviewRepository) ForEachByUserID(ctx context.Context, userID string, fn func(*domain.ReviewExport) error) error {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return err
+	}
+
+	pipeline := append(mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"userId": objID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "createdAt", Value: 1}}}},
+	}, movieTitleLookup...)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	return forEach(ctx, cursor, fn)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// similarityBatchSize bounds how many neighbour documents go in one bulk write.
+const similarityBatchSize = 500
+
+type SimilarityRepository interface {
+	GetByMovieID(ctx context.Context, movieID string) (*domain.SimilarMovies, error)
+	GetByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]domain.SimilarMovies, error)
+	ReplaceAll(ctx context.Context, computed []domain.SimilarMovies, computedAt time.Time) error
+}
+
+type similarityRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSimilarityRepository(db *mongo.Database) SimilarityRepository {
+	collection := db.Collection("similar_movies")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "computedAt", Value: 1}},
+		},
+	)
+
+	return &similarityRepository{
+		collection: collection,
+	}
+}
+
+func (r *similarityRepository) GetByMovieID(ctx context.Context, movieID string) (*domain.SimilarMovies, error) {
+	objID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	var similar domain.SimilarMovies
+	err = r.collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&similar)
+	if err != nil {
+		return nil, err
+	}
+
+	return &similar, nil
+}
+
+func (r *similarityRepository) GetByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]domain.SimilarMovies, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": movieIDs}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var similar []domain.SimilarMovies
+	if err = cursor.All(ctx, &similar); err != nil {
+		return nil, err
+	}
+
+	return similar, nil
+}
+
+// ReplaceAll upserts the neighbours from one rebuild and then drops the
+// documents it did not touch, which belong to movies that have since been
+// deleted. Readers keep seeing the previous results until each document is
+// replaced.
+func (r *similarityRepository) ReplaceAll(ctx context.Context, computed []domain.SimilarMovies, computedAt time.Time) error {
+	for start := 0; start < len(computed); start += similarityBatchSize {
+		end := start + similarityBatchSize
+		if end > len(computed) {
+			end = len(computed)
+		}
+
+		models := make([]mongo.WriteModel, 0, end-start)
+		for _, similar := range computed[start:end] {
+			models = append(models, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": similar.MovieID}).
+				SetReplacement(similar).
+				SetUpsert(true))
+		}
+
+		if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err := r.collection.DeleteMany(ctx, bson.M{"computedAt": bson.M{"$lt": computedAt}})
+	return err
+}
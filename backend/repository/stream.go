@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// forEach passes each document from cursor to fn, decoding one at a time
+// so large result sets are never held in memory. It stops at the first
+// error from fn.
+func forEach[T any](ctx context.Context, cursor *mongo.Cursor, fn func(*T) error) error {
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// movieTitleLookup adds a movieTitle field with the title of the movie
+// a document's movieId refers to.
+var movieTitleLookup = mongo.Pipeline{
+	{{Key: "$lookup", Value: map[string]interface{}{
+		"from":         "movies",
+		"localField":   "movieId",
+		"foreignField": "_id",
+		"as":           "movie",
+	}}},
+	{{Key: "$addFields", Value: map[string]interface{}{
+		"movieTitle": map[string]interface{}{"$first": "$movie.title"},
+	}}},
+	{{Key: "$project", Value: map[string]interface{}{"movie": 0}}},
+}
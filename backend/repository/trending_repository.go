@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// eventRetention is how long hourly event counts are kept; it must cover
+// the longest trending window.
+const eventRetention = 35 * 24 * time.Hour
+
+type TrendingRepository interface {
+	AddEventCounts(ctx context.Context, counts []domain.MovieEventCount) error
+	ScoreMovies(ctx context.Context, since, now time.Time, halfLife time.Duration, weights map[string]float64, limit int) ([]domain.TrendingMovie, error)
+	SaveList(ctx context.Context, list *domain.TrendingList) error
+	GetList(ctx context.Context, window string) (*domain.TrendingList, error)
+}
+
+// trendingRepository keeps hourly per-movie event counters in one
+// collection and the rankings computed from them in another.
+type trendingRepository struct {
+	events *mongo.Collection
+	lists  *mongo.Collection
+}
+
+func NewTrendingRepository(db *mongo.Database) TrendingRepository {
+	events := db.Collection("movie_events")
+	ensureIndexes(events,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "movieId", Value: 1}, {Key: "hour", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "hour", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(eventRetention.Seconds())),
+		},
+	)
+
+	return &trendingRepository{
+		events: events,
+		lists:  db.Collection("trending"),
+	}
+}
+
+// AddEventCounts adds counts to the hourly counters with one bulk write.
+func (r *trendingRepository) AddEventCounts(ctx context.Context, counts []domain.MovieEventCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for _, count := range counts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"movieId": count.MovieID, "hour": count.Hour}).
+			SetUpdate(bson.M{"$inc": bson.M{"counts." + count.Type: count.Count}}).
+			SetUpsert(true))
+	}
+
+	_, err := r.events.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// ScoreMovies sums each movie's weighted event counts since the given
+// time, halving the weight of an hour's events for every halfLife of age.
+func (r *trendingRepository) ScoreMovies(ctx context.Context, since, now time.Time, halfLife time.Duration, weights map[string]float64, limit int) ([]domain.TrendingMovie, error) {
+	weighted := bson.A{}
+	for eventType, weight := range weights {
+		weighted = append(weighted, bson.M{"$multiply": bson.A{
+			bson.M{"$ifNull": bson.A{"$counts." + eventType, 0}},
+			weight,
+		}})
+	}
+
+	decay := bson.M{"$exp": bson.M{"$multiply": bson.A{
+		decayRate(halfLife),
+		bson.M{"$subtract": bson.A{now, "$hour"}},
+	}}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"hour": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$movieId",
+			"score": bson.M{"$sum": bson.M{"$multiply": bson.A{bson.M{"$add": weighted}, decay}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{"_id": 0, "movieId": "$_id", "score": 1}}},
+	}
+
+	cursor, err := r.events.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var movies []domain.TrendingMovie
+	if err = cursor.All(ctx, &movies); err != nil {
+		return nil, err
+	}
+
+	return movies, nil
+}
+
+func (r *trendingRepository) SaveList(ctx context.Context, list *domain.TrendingList) error {
+	_, err := r.lists.ReplaceOne(ctx, bson.M{"_id": list.Window}, list, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *trendingRepository) GetList(ctx context.Context, window string) (*domain.TrendingList, error) {
+	var list domain.TrendingList
+	err := r.lists.FindOne(ctx, bson.M{"_id": window}).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+}
+
+// decayRate is the exponent, per millisecond of age, that halves a weight
+// every halfLife. Millisecond because that is what date subtraction yields
+// in an aggregation.
+func decayRate(halfLife time.Duration) float64 {
+	return -math.Ln2 / float64(halfLife.Milliseconds())
+}
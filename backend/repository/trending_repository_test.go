@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayRate(t *testing.T) {
+	tests := []struct {
+		halfLife time.Duration
+		age      time.Duration
+		want     float64
+	}{
+		{halfLife: 6 * time.Hour, age: 0, want: 1},
+		{halfLife: 6 * time.Hour, age: 6 * time.Hour, want: 0.5},
+		{halfLife: 6 * time.Hour, age: 24 * time.Hour, want: 1.0 / 16},
+		{halfLife: 36 * time.Hour, age: 3 * time.Hour, want: math.Pow(0.5, 1.0/12)},
+		{halfLife: 7 * 24 * time.Hour, age: 14 * 24 * time.Hour, want: 0.25},
+	}
+
+	for _, tt := range tests {
+		// The pipeline multiplies the rate by the age in milliseconds and
+		// takes the exponential.
+		got := math.Exp(decayRate(tt.halfLife) * float64(tt.age.Milliseconds()))
+		if math.Abs(got-tt.want) > 1e-12 {
+			t.Errorf("decay after %v with a %v half-life = %v, want %v", tt.age, tt.halfLife, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UserDataRepository reads and erases everything stored about a user across
+// collections. It works on raw documents so that an account export carries
+// every stored field, not just the ones the API shows.
+type UserDataRepository interface {
+	Sections() []string
+	ForEachDocument(ctx context.Context, section string, userID primitive.ObjectID, fn func(map[string]interface{}) error) error
+	Erase(ctx context.Context, userID primitive.ObjectID) (map[string]int64, error)
+}
+
+// userDataSource is where one section of a user's data is stored. field
+// is the field referring to the user.
+type userDataSource struct {
+	section    string
+	collection string
+	field      string
+}
+
+// userDataSources lists every collection holding personal data. A new
+// collection that refers to users belongs here, and in Erase.
+var userDataSources = []userDataSource{
+	{"profile", "users", "_id"},
+	{"movies", "movies", "userId"},
+	{"reviews", "reviews", "userId"},
+	{"watchlist", "watchlist", "userId"},
+	{"diary", "diary", "userId"},
+	{"lists", "lists", "userId"},
+	{"likes", "likes", "userId"},
+	{"comments", "comments", "userId"},
+	{"following", "follows", "followerId"},
+	{"followers", "follows", "followeeId"},
+	{"collections", "collections", "members.userId"},
+	{"activity", "activities", "userId"},
+	{"imports", "import_jobs", "userId"},
+}
+
+// userDataProjections hides secrets that are not the user's data as such.
+var userDataProjections = map[string]bson.M{
+	"users": {"password": 0},
+}
+
+type userDataRepository struct {
+	db *mongo.Database
+}
+
+func NewUserDataRepository(db *mongo.Database) UserDataRepository {
+	return &userDataRepository{db: db}
+}
+
+// collection returns a handle that decodes nested documents as maps, so
+// they encode to JSON as objects.
+func (r *userDataRepository) collection(name string) *mongo.Collection {
+	return r.db.Collection(name, options.Collection().SetBSONOptions(&options.BSONOptions{
+		DefaultDocumentM: true,
+	}))
+}
+
+func (r *userDataRepository) Sections() []string {
+	sections := make([]string, len(userDataSources))
+	for i, source := range userDataSources {
+		sections[i] = source.section
+	}
+	return sections
+}
+
+// ForEachDocument streams the user's documents in one section to fn.
+func (r *userDataRepository) ForEachDocument(ctx context.Context, section string, userID primitive.ObjectID, fn func(map[string]interface{}) error) error {
+	for _, source := range userDataSources {
+		if source.section != section {
+			continue
+		}
+
+		opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+		if projection, ok := userDataProjections[source.collection]; ok {
+			opts.SetProjection(projection)
+		}
+
+		cursor, err := r.collection(source.collection).Find(ctx, bson.M{source.field: userID}, opts)
+		if err != nil {
+			return err
+		}
+		return forEach(ctx, cursor, func(doc *bson.M) error { return fn(*doc) })
+	}
+	return fmt.Errorf("unknown user data section %q", section)
+}
+
+// Erase deletes the user's personal data. Content other users depend on
+// is anonymized instead: movies stay in the catalogue without an owner,
+// and comments become tombstones so threads stay intact. Collections the
+// user owns are deleted, releasing their movies, and the user is dropped
+// from the others. Like counters are kept in step with deleted likes;
+// ratings of reviewed movies must be recomputed by the caller. Erase is
+// safe to run again after a failure. It returns the number of documents
+// changed per collection.
+func (r *userDataRepository) Erase(ctx context.Context, userID primitive.ObjectID) (map[string]int64, error) {
+	erased := make(map[string]int64)
+	byUser := bson.M{"userId": userID}
+
+	// Each like is deleted before its movie's counter is decremented, and
+	// only the call that deleted it decrements, so a retry never
+	// decrements twice; a failure in between leaves the count one high
+	likes := r.db.Collection("likes")
+	movies := r.db.Collection("movies")
+	cursor, err := likes.Find(ctx, byUser)
+	if err != nil {
+		return nil, err
+	}
+	err = forEach(ctx, cursor, func(like *domain.Like) error {
+		err := likes.FindOneAndDelete(ctx, bson.M{"_id": like.ID}).Err()
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := movies.UpdateOne(ctx, bson.M{"_id": like.MovieID}, bson.M{"$inc": bson.M{"likeCount": -1}}); err != nil {
+			return err
+		}
+		erased["likes"]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"reviews", "watchlist", "diary", "lists", "activities", "import_jobs"} {
+		result, err := r.db.Collection(name).DeleteMany(ctx, byUser)
+		if err != nil {
+			return nil, err
+		}
+		erased[name] = result.DeletedCount
+	}
+
+	result, err := r.db.Collection("follows").DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"followerId": userID},
+		bson.M{"followeeId": userID},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	erased["follows"] = result.DeletedCount
+
+	updated, err := r.db.Collection("comments").UpdateMany(ctx, byUser, bson.M{
+		"$set": bson.M{
+			"userId":    primitive.NilObjectID,
+			"body":      "",
+			"deleted":   true,
+			"deletedBy": domain.CommentDeletedByAuthor,
+			"flagged":   false,
+		},
+		"$unset": bson.M{"flagReason": ""},
+	})
+	if err != nil {
+		return nil, err
+	}
+	erased["comments"] = updated.ModifiedCount
+
+	if erased["collections"], err = r.eraseCollections(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	updated, err = movies.UpdateMany(ctx, byUser, bson.M{"$set": bson.M{"userId": primitive.NilObjectID}})
+	if err != nil {
+		return nil, err
+	}
+	erased["movies"] = updated.ModifiedCount
+
+	result, err = r.db.Collection("users").DeleteOne(ctx, bson.M{"_id": userID})
+	if err != nil {
+		return nil, err
+	}
+	erased["users"] = result.DeletedCount
+
+	return erased, nil
+}
+
+// eraseCollections deletes the collections the user owns, detaching their
+// movies first, and removes the user from the rest.
+func (r *userDataRepository) eraseCollections(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	collections := r.db.Collection("collections")
+	movies := r.db.Collection("movies")
+
+	cursor, err := collections.Find(ctx, bson.M{"ownerId": userID}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	err = forEach(ctx, cursor, func(collection *domain.Collection) error {
+		if _, err := movies.UpdateMany(ctx, bson.M{"collectionId": collection.ID}, bson.M{"$unset": bson.M{"collectionId": ""}}); err != nil {
+			return err
+		}
+		if _, err := collections.DeleteOne(ctx, bson.M{"_id": collection.ID}); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := collections.UpdateMany(
+		ctx,
+		bson.M{"members.userId": userID},
+		bson.M{"$pull": bson.M{"members": bson.M{"userId": userID}}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return count + result.ModifiedCount, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+)
+
+// sentWrites returns the statements of the update or delete commands sent
+// to a collection, in order, each with the index of its command among all
+// those sent.
+func sentWrites(events []*event.CommandStartedEvent, command, collection string) (statements []bson.Raw, at []int) {
+	field := map[string]string{"update": "updates", "delete": "deletes"}[command]
+	for i, e := range events {
+		if e.CommandName != command || e.Command.Lookup(command).StringValue() != collection {
+			continue
+		}
+		values, _ := e.Command.Lookup(field).Array().Values()
+		for _, value := range values {
+			statements = append(statements, value.Document())
+			at = append(at, i)
+		}
+	}
+	return statements, at
+}
+
+func TestUserDataErase(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("erase", func(mt *mtest.T) {
+		userID, collectionID := primitive.NewObjectID(), primitive.NewObjectID()
+		liked, erasedEarlier := primitive.NewObjectID(), primitive.NewObjectID()
+		like := bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "userId", Value: userID}, {Key: "movieId", Value: liked}}
+		goneLike := bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "userId", Value: userID}, {Key: "movieId", Value: erasedEarlier}}
+		written := func(n int) bson.D {
+			return mtest.CreateSuccessResponse(bson.E{Key: "n", Value: n}, bson.E{Key: "nModified", Value: n})
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "test.likes", mtest.FirstBatch, like, goneLike),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: like}),
+			written(1), // likeCount of the liked movie
+			// The second like was deleted by an earlier, failed run
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}),
+			written(0), written(0), written(0), written(0), written(0), written(0),
+			written(0), // follows
+			written(2), // comments
+			mtest.CreateCursorResponse(0, "test.collections", mtest.FirstBatch, bson.D{{Key: "_id", Value: collectionID}}),
+			written(3), // movies released from the owned collection
+			written(1), // the owned collection
+			written(0), // memberships
+			written(1), // movies left without an owner
+			written(1), // the user
+		)
+
+		erased, err := (&userDataRepository{db: mt.DB}).Erase(context.Background(), userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if erased["likes"] != 1 || erased["comments"] != 2 || erased["collections"] != 1 || erased["users"] != 1 {
+			t.Errorf("erased = %v, want 1 like, 2 comments, 1 collection and 1 user", erased)
+		}
+		events := mt.GetAllStartedEvents()
+
+		movieUpdates, movieUpdatesAt := sentWrites(events, "update", "movies")
+		var decremented []primitive.ObjectID
+		var releasedAt int
+		for i, update := range movieUpdates {
+			if inc, err := update.LookupErr("u", "$inc", "likeCount"); err == nil {
+				if inc.AsInt64() != -1 {
+					t.Errorf("likeCount changed by %d, want -1", inc.AsInt64())
+				}
+				decremented = append(decremented, update.Lookup("q", "_id").ObjectID())
+			}
+			if id, err := update.LookupErr("q", "collectionId"); err == nil && id.ObjectID() == collectionID {
+				if _, err := update.LookupErr("u", "$unset", "collectionId"); err != nil {
+					t.Errorf("movies in the owned collection were not released: %v", update)
+				}
+				releasedAt = movieUpdatesAt[i]
+			}
+		}
+		if len(decremented) != 1 || decremented[0] != liked {
+			t.Errorf("likeCount decremented on %v, want only %s", decremented, liked.Hex())
+		}
+
+		collectionDeletes, collectionDeletesAt := sentWrites(events, "delete", "collections")
+		if len(collectionDeletes) != 1 || collectionDeletes[0].Lookup("q", "_id").ObjectID() != collectionID {
+			t.Fatalf("collections deleted = %v, want the owned one", collectionDeletes)
+		}
+		if releasedAt == 0 || releasedAt > collectionDeletesAt[0] {
+			t.Error("the owned collection was deleted before its movies were released")
+		}
+
+		commentUpdates, _ := sentWrites(events, "update", "comments")
+		if len(commentUpdates) != 1 {
+			t.Fatalf("%d comment updates, want 1", len(commentUpdates))
+		}
+		set := commentUpdates[0].Lookup("u", "$set").Document()
+		if set.Lookup("body").StringValue() != "" || !set.Lookup("deleted").Boolean() || set.Lookup("userId").ObjectID() != primitive.NilObjectID || set.Lookup("deletedBy").StringValue() != domain.CommentDeletedByAuthor {
+			t.Errorf("comments were not tombstoned: %v", set)
+		}
+	})
+}
+
+func TestUserDataExportOmitsPassword(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("profile", func(mt *mtest.T) {
+		userID := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "test.users", mtest.FirstBatch, bson.D{{Key: "_id", Value: userID}, {Key: "username", Value: "ada"}}))
+
+		var docs int
+		err := (&userDataRepository{db: mt.DB}).ForEachDocument(context.Background(), "profile", userID, func(map[string]interface{}) error {
+			docs++
+			return nil
+		})
+		if err != nil || docs != 1 {
+			t.Fatalf("ForEachDocument = %d documents, %v; want 1", docs, err)
+		}
+
+		find := mt.GetStartedEvent()
+		if excluded, err := find.Command.LookupErr("projection", "password"); err != nil || excluded.AsInt64() != 0 {
+			t.Errorf("profile projection = %v, want password excluded", find.Command.Lookup("projection"))
+		}
+	})
+}
+
+func TestErasureStartAndComplete(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	mt.Run("cancelled", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+
+		started, err := (&erasureRepository{collection: mt.Coll}).Start(context.Background(), primitive.NewObjectID())
+		if err != nil || started {
+			t.Errorf("Start on a cancelled request = %v, %v; want false", started, err)
+		}
+	})
+	mt.Run("complete", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+
+		repo := &erasureRepository{collection: mt.Coll}
+		if err := repo.Complete(context.Background(), primitive.NewObjectID(), time.Now(), map[string]int64{"reviews": 2}); err != nil {
+			t.Fatal(err)
+		}
+		updates, _ := sentWrites(mt.GetAllStartedEvents(), "update", mt.Coll.Name())
+		if len(updates) != 1 {
+			t.Fatalf("%d updates, want 1", len(updates))
+		}
+		if status := updates[0].Lookup("u", "$set", "status").StringValue(); status != domain.ErasureStatusCompleted {
+			t.Errorf("status = %q, want %q", status, domain.ErasureStatusCompleted)
+		}
+		if _, err := updates[0].LookupErr("u", "$unset", "reviewedMovies"); err != nil {
+			t.Error("the reviewed movies were kept on the completed request")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type WatchlistRepository interface {
+	Add(ctx context.Context, entry *domain.WatchlistEntry) error
+	Remove(ctx context.Context, userID, movieID string) (bool, error)
+	Contains(ctx context.Context, userID, movieID string) (bool, error)
+	GetByUserID(ctx context.Context, userID string, from, to time.Time, page, size int) ([]domain.WatchlistEntry, int64, error)
+}
+
+type watchlistRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWatchlistRepository(db *mongo.Database) WatchlistRepository {
+	collection := db.Collection("watchlist")
+	ensureIndexes(collection,
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "movieId", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		mongo.IndexModel{
+			Keys: bson.D{{Key: "userId", Value: 1}, {Key: "addedAt", Value: -1}},
+		},
+	)
+
+	return &watchlistRepository{
+		collection: collection,
+	}
+}
+
+func (r *watchlistRepository) Add(ctx context.Context, entry *domain.WatchlistEntry) error {
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Remove deletes a movie from the user's watchlist and reports whether it was there.
+func (r *watchlistRepository) Remove(ctx context.Context, userID, movieID string) (bool, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"userId": userObjID, "movieId": movieObjID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+func (r *watchlistRepository) GetByUserID(ctx context.Context, userID string, from, to time.Time, page, size int) ([]domain.WatchlistEntry, int64, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	skip := int64((page - 1) * size)
+	opts := options.Find().
+		SetSkip(skip).
+		SetLimit(int64(size)).
+		SetSort(bson.D{{Key: "addedAt", Value: -1}})
+
+	filter := bson.M{"userId": objID}
+	addDateRange(filter, "addedAt", from, to)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var entries []domain.WatchlistEntry
+	if err = cursor.All(ctx, &entries); err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+func (r *watchlistRepository) Contains(ctx context.Context, userID, movieID string) (bool, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return false, err
+	}
+	movieObjID, err := primitive.ObjectIDFromHex(movieID)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := r.collection.CountDocuments(ctx, bson.M{"userId": userObjID, "movieId": movieObjID}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -2,19 +2,48 @@ package router
 
 import (
 	"github.com/AfomiaTadesse/Afomia_M/backend/controller"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
 	"github.com/AfomiaTadesse/Afomia_M/backend/middleware"
+	"github.com/AfomiaTadesse/Afomia_M/backend/openapi"
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter(
 	userCtrl *controller.UserController,
 	movieCtrl *controller.MovieController,
+	reviewCtrl *controller.ReviewController,
+	watchlistCtrl *controller.WatchlistController,
+	diaryCtrl *controller.DiaryController,
+	listCtrl *controller.ListController,
+	collectionCtrl *controller.CollectionController,
+	likeCtrl *controller.LikeController,
+	commentCtrl *controller.CommentController,
+	followCtrl *controller.FollowController,
+	feedCtrl *controller.FeedController,
+	recommendationCtrl *controller.RecommendationController,
+	trendingCtrl *controller.TrendingController,
+	importCtrl *controller.ImportController,
+	exportCtrl *controller.ExportController,
+	accountCtrl *controller.AccountController,
+	adminCtrl *controller.AdminController,
+	posterCtrl *controller.PosterController,
+	metadataCtrl *controller.MetadataController,
+	graphqlCtrl *controller.GraphQLController,
+	locales *i18n.Locales,
 	jwtSecret string, 
-
+	validateResponses bool,
+	maxBodySize int64,
 ) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.TraceMiddleware(), middleware.ErrorMiddleware())
+	router.NoRoute(controller.NoRoute)
+
+	// Requests are checked against the API description before any handler
+	// runs; the description is only complete once every route is added
+	validator := openapi.NewValidator(validateResponses, maxBodySize)
 
 	api := router.Group("/api/v1")
+	api.Use(middleware.LocaleMiddleware(locales), validator.Middleware())
 	{
 		// User routes (no auth required)
 		userRoutes := api.Group("/users")
@@ -23,6 +52,89 @@ func SetupRouter(
 			userRoutes.POST("/login", userCtrl.Login)
 		}
 
+		// Current user's tracking routes (auth required)
+		meRoutes := api.Group("/users/me")
+		meRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			meRoutes.GET("/watchlist", watchlistCtrl.GetWatchlist)
+			meRoutes.POST("/watchlist", watchlistCtrl.AddToWatchlist)
+			meRoutes.DELETE("/watchlist/:movieId", watchlistCtrl.RemoveFromWatchlist)
+
+			meRoutes.GET("/diary", diaryCtrl.GetDiary)
+			meRoutes.GET("/diary/export", diaryCtrl.ExportDiary)
+			meRoutes.POST("/diary", diaryCtrl.LogViewing)
+			meRoutes.DELETE("/diary/:entryId", diaryCtrl.DeleteEntry)
+
+			meRoutes.GET("/lists", listCtrl.GetMyLists)
+			meRoutes.GET("/collections", collectionCtrl.GetMyCollections)
+			meRoutes.GET("/likes", likeCtrl.GetLikedMovies)
+			meRoutes.GET("/feed", feedCtrl.GetFeed)
+			meRoutes.GET("/recommendations", recommendationCtrl.GetRecommendations)
+			meRoutes.GET("/export", exportCtrl.Export)
+
+			meRoutes.POST("/data-export", accountCtrl.RequestDataExport)
+			meRoutes.GET("/data-export/:exportId", accountCtrl.GetDataExport)
+			meRoutes.GET("/data-export/:exportId/download", accountCtrl.DownloadDataExport)
+			meRoutes.GET("/erasure", accountCtrl.GetErasure)
+			meRoutes.POST("/erasure", accountCtrl.RequestErasure)
+			meRoutes.DELETE("/erasure", accountCtrl.CancelErasure)
+		}
+
+		// Follow routes (auth required); ":id" may be "me" for the list endpoints
+		socialRoutes := api.Group("/users/:id")
+		socialRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			socialRoutes.POST("/follow", followCtrl.Follow)
+			socialRoutes.DELETE("/follow", followCtrl.Unfollow)
+			socialRoutes.GET("/followers", followCtrl.GetFollowers)
+			socialRoutes.GET("/following", followCtrl.GetFollowing)
+		}
+
+		// Shared collection routes (auth required)
+		collectionRoutes := api.Group("/collections")
+		collectionRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			collectionRoutes.POST("/", collectionCtrl.CreateCollection)
+			collectionRoutes.POST("/join/:token", collectionCtrl.JoinByInvite)
+			collectionRoutes.GET("/:id", collectionCtrl.GetCollection)
+			collectionRoutes.PUT("/:id", collectionCtrl.UpdateCollection)
+			collectionRoutes.DELETE("/:id", collectionCtrl.DeleteCollection)
+			collectionRoutes.GET("/:id/movies", collectionCtrl.GetCollectionMovies)
+			collectionRoutes.POST("/:id/members", collectionCtrl.InviteMember)
+			collectionRoutes.PUT("/:id/members/:userId", collectionCtrl.UpdateMemberRole)
+			collectionRoutes.DELETE("/:id/members/:userId", collectionCtrl.RemoveMember)
+			collectionRoutes.POST("/:id/invite-link", collectionCtrl.CreateInviteLink)
+			collectionRoutes.DELETE("/:id/invite-link", collectionCtrl.RevokeInviteLink)
+		}
+
+		// List routes (auth required)
+		listRoutes := api.Group("/lists")
+		listRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			listRoutes.POST("/", listCtrl.CreateList)
+			listRoutes.GET("/:id", listCtrl.GetList)
+			listRoutes.PUT("/:id", listCtrl.UpdateList)
+			listRoutes.DELETE("/:id", listCtrl.DeleteList)
+			listRoutes.POST("/:id/entries", listCtrl.AddEntries)
+			listRoutes.DELETE("/:id/entries", listCtrl.RemoveEntries)
+			listRoutes.PUT("/:id/entries/:movieId", listCtrl.UpdateEntry)
+			listRoutes.PUT("/:id/order", listCtrl.ReorderList)
+		}
+
+		// Admin routes (auth required; the usecases check the admin role)
+		adminRoutes := api.Group("/admin")
+		adminRoutes.Use(middleware.AuthMiddleware(jwtSecret))
+		{
+			adminRoutes.GET("/movies/duplicates", adminCtrl.GetDuplicates)
+			adminRoutes.POST("/movies/merge", adminCtrl.MergeMovies)
+		}
+
+		// Shared list links are read-only and public (no auth required)
+		api.GET("/shared/lists/:token", listCtrl.GetSharedList)
+
+		// Poster images are public so they can be used in <img> tags
+		api.GET("/posters/:dir/:file", posterCtrl.GetPoster)
+
 		// Movie routes (auth required)
 		movieRoutes := api.Group("/movies")
 		movieRoutes.Use(middleware.AuthMiddleware(jwtSecret))
@@ -30,11 +142,53 @@ func SetupRouter(
 			movieRoutes.POST("/", movieCtrl.CreateMovie)
 			movieRoutes.GET("/", movieCtrl.GetMovies)
 			movieRoutes.GET("/search", movieCtrl.SearchMovies)
+			movieRoutes.GET("/trending", trendingCtrl.GetTrending)
+			movieRoutes.GET("/metadata", metadataCtrl.LookupMetadata)
+			movieRoutes.POST("/import", importCtrl.StartImport)
+			movieRoutes.POST("/import/letterboxd", importCtrl.ImportLetterboxd)
+			movieRoutes.POST("/import/imdb", importCtrl.ImportIMDb)
+			movieRoutes.GET("/import/:jobId", importCtrl.GetImportJob)
 			movieRoutes.GET("/:id", movieCtrl.GetMovieByID)
 			movieRoutes.PUT("/:id", movieCtrl.UpdateMovie)
 			movieRoutes.DELETE("/:id", movieCtrl.DeleteMovie)
+			movieRoutes.POST("/:id/poster", posterCtrl.UploadPoster)
+			movieRoutes.POST("/:id/refresh-metadata", metadataCtrl.RefreshMetadata)
+
+			// Reviews are keyed by user+movie, so "me" addresses the caller's own review
+			movieRoutes.GET("/:id/reviews", reviewCtrl.GetMovieReviews)
+			movieRoutes.POST("/:id/reviews", reviewCtrl.CreateReview)
+			movieRoutes.PUT("/:id/reviews/me", reviewCtrl.UpdateReview)
+			movieRoutes.DELETE("/:id/reviews/me", reviewCtrl.DeleteReview)
+
+			movieRoutes.POST("/:id/like", likeCtrl.LikeMovie)
+			movieRoutes.DELETE("/:id/like", likeCtrl.UnlikeMovie)
+
+			movieRoutes.GET("/:id/comments", commentCtrl.GetThreads)
+			movieRoutes.POST("/:id/comments", commentCtrl.CreateComment)
+			movieRoutes.GET("/:id/comments/:commentId/replies", commentCtrl.GetReplies)
+			movieRoutes.PUT("/:id/comments/:commentId", commentCtrl.UpdateComment)
+			movieRoutes.DELETE("/:id/comments/:commentId", commentCtrl.DeleteComment)
+
+			movieRoutes.GET("/:id/similar", recommendationCtrl.GetSimilarMovies)
 		}
 	}
 
+	// The API description is generated from the routes above, so it cannot
+	// drift from them; a route without a description is a programming error
+	doc, err := openapi.Generate(router.Routes())
+	if err != nil {
+		panic(err)
+	}
+	validator.SetDocument(doc)
+	specHandler, err := openapi.Handler(doc)
+	if err != nil {
+		panic(err)
+	}
+	router.GET("/openapi.json", specHandler)
+	router.GET("/docs", openapi.DocsHandler)
+
+	// GraphQL has a schema of its own, so it sits outside the API description
+	router.POST("/graphql", middleware.LocaleMiddleware(locales), middleware.AuthMiddleware(jwtSecret), graphqlCtrl.Query)
+
 	return router
 }
\ No newline at end of file
@@ -0,0 +1,30 @@
+// Package storage holds binary objects such as uploaded images outside the
+// database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrBlobNotFound is returned by Get when no blob has the key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores blobs under slash-separated keys, e.g.
+// "posters/abc/small.jpg". Keys are chosen by the caller and never contain
+// "..".
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (*Blob, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Blob is a stored object being read. The caller must close Body.
+type Blob struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
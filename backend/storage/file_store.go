@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// fileStore keeps blobs as files under a directory. Content types are
+// derived from the key's extension.
+type fileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) BlobStore {
+	return &fileStore{dir: dir}
+}
+
+func (s *fileStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+// Put writes the blob to a temporary file first, so readers never see a
+// partial blob.
+func (s *fileStore) Put(ctx context.Context, key, contentType string, data []byte) error {
+	target := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(target+".part", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(target+".part", target)
+}
+
+func (s *fileStore) Get(ctx context.Context, key string) (*Blob, error) {
+	file, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrBlobNotFound
+		}
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &Blob{
+		Body:        file,
+		ContentType: contentType,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+	}, nil
+}
+
+func (s *fileStore) Delete(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccountSweeper carries out account erasures once their grace period has
+// passed and removes data export archives once they expire.
+type AccountSweeper interface {
+	Run(ctx context.Context)
+}
+
+type accountSweeper struct {
+	userDataRepo repository.UserDataRepository
+	exportRepo   repository.DataExportRepository
+	erasureRepo  repository.ErasureRepository
+	reviewRepo   repository.ReviewRepository
+	movieRepo    repository.MovieRepository
+	exportDir    string
+	interval     time.Duration
+}
+
+func NewAccountSweeper(userDataRepo repository.UserDataRepository, exportRepo repository.DataExportRepository, erasureRepo repository.ErasureRepository, reviewRepo repository.ReviewRepository, movieRepo repository.MovieRepository, exportDir string, interval time.Duration) AccountSweeper {
+	return &accountSweeper{
+		userDataRepo: userDataRepo,
+		exportRepo:   exportRepo,
+		erasureRepo:  erasureRepo,
+		reviewRepo:   reviewRepo,
+		movieRepo:    movieRepo,
+		exportDir:    exportDir,
+		interval:     interval,
+	}
+}
+
+// Run sweeps at startup and then every interval. It returns when ctx is
+// cancelled.
+func (s *accountSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.eraseDue(ctx); err != nil {
+			log.Printf("failed to run account erasures: %v", err)
+		}
+		if err := s.expireExports(ctx); err != nil {
+			log.Printf("failed to expire data exports: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *accountSweeper) eraseDue(ctx context.Context) error {
+	due, err := s.erasureRepo.GetDue(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, request := range due {
+		started, err := s.erasureRepo.Start(ctx, request.ID)
+		if err != nil {
+			return err
+		}
+		if !started {
+			continue
+		}
+		if err := s.erase(ctx, request); err != nil {
+			log.Printf("failed to erase user %s: %v", request.UserID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// erase removes the user's data and export archives, refreshes the ratings
+// of movies they reviewed and completes the audit record. The reviewed
+// movies are recorded before anything is deleted, so a run that fails
+// part way leaves them for the rerun to refresh.
+func (s *accountSweeper) erase(ctx context.Context, request domain.ErasureRequest) error {
+	reviews, err := s.reviewRepo.GetAllByUserID(ctx, request.UserID.Hex())
+	if err != nil {
+		return err
+	}
+	reviewed := make(map[primitive.ObjectID]bool, len(request.ReviewedMovies)+len(reviews))
+	movieIDs := append([]primitive.ObjectID(nil), request.ReviewedMovies...)
+	for _, movieID := range movieIDs {
+		reviewed[movieID] = true
+	}
+	for _, review := range reviews {
+		if !reviewed[review.MovieID] {
+			reviewed[review.MovieID] = true
+			movieIDs = append(movieIDs, review.MovieID)
+		}
+	}
+	if len(movieIDs) > len(request.ReviewedMovies) {
+		if err := s.erasureRepo.RecordReviewedMovies(ctx, request.ID, movieIDs); err != nil {
+			return err
+		}
+	}
+
+	exports, err := s.exportRepo.GetByUserID(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if err := removeFile(dataExportPath(s.exportDir, export.ID)); err != nil {
+			return err
+		}
+	}
+	deletedExports, err := s.exportRepo.DeleteByUserID(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+
+	erased, err := s.userDataRepo.Erase(ctx, request.UserID)
+	if err != nil {
+		return err
+	}
+	erased["data_exports"] = deletedExports
+
+	for _, movieID := range movieIDs {
+		if err := refreshMovieRating(ctx, s.reviewRepo, s.movieRepo, movieID.Hex()); err != nil {
+			return err
+		}
+	}
+
+	return s.erasureRepo.Complete(ctx, request.ID, time.Now(), erased)
+}
+
+func (s *accountSweeper) expireExports(ctx context.Context) error {
+	expired, err := s.exportRepo.GetExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, export := range expired {
+		if err := removeFile(dataExportPath(s.exportDir, export.ID)); err != nil {
+			return err
+		}
+		export.Status = domain.DataExportStatusExpired
+		if err := s.exportRepo.Save(ctx, &export); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeFile deletes a file, treating one that is already gone as removed.
+func removeFile(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEraseDueRerunsAfterAFailure(t *testing.T) {
+	ctx := context.Background()
+	userID, otherID := primitive.NewObjectID(), primitive.NewObjectID()
+	movie := &domain.Movie{Title: "Alien", AverageRating: 4, RatingCount: 2}
+	movies := newFakeMovieRepo(movie)
+	reviews := &fakeReviewRepo{reviews: []*domain.Review{
+		{ID: primitive.NewObjectID(), UserID: userID, MovieID: movie.ID, Rating: 5},
+		{ID: primitive.NewObjectID(), UserID: otherID, MovieID: movie.ID, Rating: 3},
+	}}
+	request := &domain.ErasureRequest{UserID: userID, Status: domain.ErasureStatusScheduled, ScheduledFor: time.Now().Add(-time.Hour)}
+	erasures := newFakeErasureRepo(request)
+	export := domain.DataExport{ID: primitive.NewObjectID(), UserID: userID}
+	exports := &fakeDataExportRepo{exports: []domain.DataExport{export}}
+	exportDir := t.TempDir()
+	if err := os.WriteFile(dataExportPath(exportDir, export.ID), []byte("zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	userData := &fakeUserDataRepo{reviews: reviews, failures: 1}
+	sweeper := NewAccountSweeper(userData, exports, erasures, reviews, movies, exportDir, time.Hour).(*accountSweeper)
+
+	// The first run deletes the user's reviews, then fails
+	if err := sweeper.eraseDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != domain.ErasureStatusRunning {
+		t.Fatalf("status after a failed run = %q, want %q", request.Status, domain.ErasureStatusRunning)
+	}
+	if len(request.ReviewedMovies) != 1 || request.ReviewedMovies[0] != movie.ID {
+		t.Fatalf("reviewed movies recorded = %v, want [%s]", request.ReviewedMovies, movie.ID.Hex())
+	}
+	if _, err := os.Stat(dataExportPath(exportDir, export.ID)); !os.IsNotExist(err) {
+		t.Errorf("export archive still exists after erasure: %v", err)
+	}
+
+	// The rerun finds no reviews left, but still refreshes the movie
+	if err := sweeper.eraseDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != domain.ErasureStatusCompleted {
+		t.Fatalf("status after the rerun = %q, want %q", request.Status, domain.ErasureStatusCompleted)
+	}
+	if movie.AverageRating != 3 || movie.RatingCount != 1 {
+		t.Errorf("movie rating = %v from %d reviews, want 3 from 1", movie.AverageRating, movie.RatingCount)
+	}
+	if request.ReviewedMovies != nil {
+		t.Errorf("reviewed movies kept after completion: %v", request.ReviewedMovies)
+	}
+
+	// A completed erasure is not due again
+	userData.failures = 1
+	if err := sweeper.eraseDue(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != domain.ErasureStatusCompleted || userData.failures != 1 {
+		t.Error("a completed erasure was run again")
+	}
+}
+
+func TestEraseDueSkipsCancelledRequests(t *testing.T) {
+	userID := primitive.NewObjectID()
+	reviews := &fakeReviewRepo{reviews: []*domain.Review{{ID: primitive.NewObjectID(), UserID: userID, MovieID: primitive.NewObjectID(), Rating: 4}}}
+	request := &domain.ErasureRequest{UserID: userID, Status: domain.ErasureStatusCancelled, ScheduledFor: time.Now().Add(-time.Hour)}
+	sweeper := NewAccountSweeper(&fakeUserDataRepo{reviews: reviews}, &fakeDataExportRepo{}, newFakeErasureRepo(request), reviews, newFakeMovieRepo(), t.TempDir(), time.Hour).(*accountSweeper)
+
+	if err := sweeper.eraseDue(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if request.Status != domain.ErasureStatusCancelled || len(reviews.reviews) != 1 {
+		t.Errorf("a cancelled erasure ran: status %q, %d reviews left", request.Status, len(reviews.reviews))
+	}
+}
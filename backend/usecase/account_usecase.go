@@ -0,0 +1,304 @@
+package usecase
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AccountUsecase handles data-subject requests: a downloadable archive of
+// everything stored about the user, and erasure of their account.
+type AccountUsecase interface {
+	RequestDataExport(userID string) (*domain.BaseResponse, error)
+	GetDataExport(id, userID string) (*domain.BaseResponse, error)
+	GetDataExportFile(id, userID string) (string, error)
+	RequestErasure(userID string) (*domain.BaseResponse, error)
+	GetErasure(userID string) (*domain.BaseResponse, error)
+	CancelErasure(userID string) (*domain.BaseResponse, error)
+}
+
+type accountUsecase struct {
+	userRepo     repository.UserRepository
+	userDataRepo repository.UserDataRepository
+	exportRepo   repository.DataExportRepository
+	erasureRepo  repository.ErasureRepository
+	exportDir    string
+	exportTTL    time.Duration
+	gracePeriod  time.Duration
+}
+
+func NewAccountUsecase(userRepo repository.UserRepository, userDataRepo repository.UserDataRepository, exportRepo repository.DataExportRepository, erasureRepo repository.ErasureRepository, exportDir string, exportTTL, gracePeriod time.Duration) AccountUsecase {
+	return &accountUsecase{
+		userRepo:     userRepo,
+		userDataRepo: userDataRepo,
+		exportRepo:   exportRepo,
+		erasureRepo:  erasureRepo,
+		exportDir:    exportDir,
+		exportTTL:    exportTTL,
+		gracePeriod:  gracePeriod,
+	}
+}
+
+// RequestDataExport starts building the user's archive in the background.
+// The returned export can be polled until it has a download link. A user
+// has at most one archive being built at a time.
+func (uc *accountUsecase) RequestDataExport(userID string) (*domain.BaseResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	running, err := uc.exportRepo.FindRunning(context.Background(), objID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if running != nil {
+		return nil, domain.Conflict(domain.CodeExportInProgress, "An export is already being prepared",
+			domain.NewErrorDetail(domain.CodeExportInProgress, "export "+running.ID.Hex()+" is still being prepared", "id", running.ID.Hex()))
+	}
+
+	export := &domain.DataExport{
+		UserID:    objID,
+		Status:    domain.DataExportStatusRunning,
+		CreatedAt: time.Now(),
+	}
+	if err := uc.exportRepo.Create(context.Background(), export); err != nil {
+		return nil, err
+	}
+
+	go uc.runDataExport(*export)
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Export started",
+		Object:  export,
+	}, nil
+}
+
+func (uc *accountUsecase) GetDataExport(id, userID string) (*domain.BaseResponse, error) {
+	export, err := uc.exportRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeExportNotFound, "Export not found"))
+	}
+	if export.UserID.Hex() != userID {
+		return nil, domain.NotFound(domain.CodeExportNotFound, "Export not found")
+	}
+
+	if export.Status == domain.DataExportStatusCompleted {
+		export.DownloadURL = "/api/v1/users/me/data-export/" + export.ID.Hex() + "/download"
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Export retrieved successfully",
+		Object:  export,
+	}, nil
+}
+
+// GetDataExportFile returns the path of a finished archive the user may
+// download.
+func (uc *accountUsecase) GetDataExportFile(id, userID string) (string, error) {
+	notFound := domain.NotFound(domain.CodeExportNotFound, "Export not found")
+	export, err := uc.exportRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return "", lookupError(err, notFound)
+	}
+	if export.UserID.Hex() != userID || export.Status != domain.DataExportStatusCompleted {
+		return "", notFound
+	}
+
+	return dataExportPath(uc.exportDir, export.ID), nil
+}
+
+// runDataExport writes the archive next to its final path and moves it in
+// place once complete, so a download never sees a partial file.
+func (uc *accountUsecase) runDataExport(export domain.DataExport) {
+	ctx := context.Background()
+	path := dataExportPath(uc.exportDir, export.ID)
+
+	size, err := uc.writeDataArchive(ctx, export.UserID, path+".part")
+	if err == nil {
+		err = os.Rename(path+".part", path)
+	}
+
+	finished := time.Now()
+	export.FinishedAt = &finished
+	if err != nil {
+		log.Printf("data export %s failed: %v", export.ID.Hex(), err)
+		os.Remove(path + ".part")
+		export.Status = domain.DataExportStatusFailed
+		export.Error = "Internal error while building the archive"
+	} else {
+		expires := finished.Add(uc.exportTTL)
+		export.Status = domain.DataExportStatusCompleted
+		export.Size = size
+		export.ExpiresAt = &expires
+	}
+
+	if err := uc.exportRepo.Save(ctx, &export); err != nil {
+		log.Printf("failed to save data export %s: %v", export.ID.Hex(), err)
+	}
+}
+
+// writeDataArchive writes a ZIP holding a JSON array per section of the
+// user's data, streaming documents from the database, and returns its size.
+func (uc *accountUsecase) writeDataArchive(ctx context.Context, userID primitive.ObjectID, path string) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return 0, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for _, section := range uc.userDataRepo.Sections() {
+		w, err := zw.Create(section + ".json")
+		if err != nil {
+			return 0, err
+		}
+		if err := uc.writeDataSection(ctx, w, section, userID); err != nil {
+			return 0, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return 0, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), file.Close()
+}
+
+func (uc *accountUsecase) writeDataSection(ctx context.Context, w io.Writer, section string, userID primitive.ObjectID) error {
+	separator := "[\n"
+	err := uc.userDataRepo.ForEachDocument(ctx, section, userID, func(doc map[string]interface{}) error {
+		data, err := json.MarshalIndent(doc, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, separator+"  "); err != nil {
+			return err
+		}
+		separator = ",\n"
+		_, err = w.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	closing := "\n]\n"
+	if separator == "[\n" {
+		closing = "[]\n"
+	}
+	_, err = io.WriteString(w, closing)
+	return err
+}
+
+// RequestErasure schedules the user's data for erasure after the grace
+// period. Asking again while an erasure is scheduled returns it unchanged.
+func (uc *accountUsecase) RequestErasure(userID string) (*domain.BaseResponse, error) {
+	user, err := uc.userRepo.FindByID(context.Background(), userID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeUserNotFound, "User not found"))
+	}
+
+	scheduled, err := uc.erasureRepo.FindScheduled(context.Background(), user.ID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if scheduled != nil {
+		return &domain.BaseResponse{
+			Success: true,
+			Message: "Account erasure is already scheduled",
+			Object:  scheduled,
+		}, nil
+	}
+
+	now := time.Now()
+	request := &domain.ErasureRequest{
+		UserID:       user.ID,
+		Status:       domain.ErasureStatusScheduled,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(uc.gracePeriod),
+	}
+	if err := uc.erasureRepo.Create(context.Background(), request); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Account erasure scheduled",
+		Object:  request,
+	}, nil
+}
+
+func (uc *accountUsecase) GetErasure(userID string) (*domain.BaseResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	request, err := uc.erasureRepo.GetLatest(context.Background(), objID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.NotFound(domain.CodeErasureNotRequested, "No account erasure has been requested")
+		}
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Account erasure retrieved successfully",
+		Object:  request,
+	}, nil
+}
+
+// CancelErasure withdraws a scheduled erasure during its grace period.
+func (uc *accountUsecase) CancelErasure(userID string) (*domain.BaseResponse, error) {
+	objID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	request, err := uc.erasureRepo.FindScheduled(context.Background(), objID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if request == nil {
+		return nil, domain.NotFound(domain.CodeErasureNotScheduled, "No account erasure is scheduled")
+	}
+
+	cancelled, err := uc.erasureRepo.Cancel(context.Background(), request.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, domain.Conflict(domain.CodeErasureStarted, "Account erasure has already started")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Account erasure cancelled",
+	}, nil
+}
+
+// dataExportPath is where a finished archive is stored.
+func dataExportPath(dir string, id primitive.ObjectID) string {
+	return filepath.Join(dir, id.Hex()+".zip")
+}
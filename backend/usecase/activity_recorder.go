@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ActivityRecorder writes the activity log that follower feeds are built
+// from. Recording is best-effort: a failure is logged and never fails the
+// action being recorded.
+type ActivityRecorder interface {
+	Record(activityType, userID string, movieID primitive.ObjectID, refID *primitive.ObjectID)
+	ForgetMovie(movieID string)
+}
+
+type activityRecorder struct {
+	activityRepo repository.ActivityRepository
+}
+
+func NewActivityRecorder(activityRepo repository.ActivityRepository) ActivityRecorder {
+	return &activityRecorder{activityRepo: activityRepo}
+}
+
+// Record logs an activity. refID is the list for movie_listed events and
+// the review for movie_reviewed events.
+func (r *activityRecorder) Record(activityType, userID string, movieID primitive.ObjectID, refID *primitive.ObjectID) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		log.Printf("failed to record %s activity: %v", activityType, err)
+		return
+	}
+
+	activity := &domain.Activity{
+		UserID:    userObjID,
+		Type:      activityType,
+		MovieID:   movieID,
+		CreatedAt: time.Now(),
+	}
+	switch activityType {
+	case domain.ActivityMovieListed:
+		activity.ListID = refID
+	case domain.ActivityMovieReviewed:
+		activity.ReviewID = refID
+	}
+
+	if err := r.activityRepo.Create(context.Background(), activity); err != nil {
+		log.Printf("failed to record %s activity: %v", activityType, err)
+	}
+}
+
+// ForgetMovie drops all activity about a deleted movie.
+func (r *activityRecorder) ForgetMovie(movieID string) {
+	if err := r.activityRepo.DeleteByMovieID(context.Background(), movieID); err != nil {
+		log.Printf("failed to remove activity for movie %s: %v", movieID, err)
+	}
+}
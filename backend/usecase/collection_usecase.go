@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CollectionUsecase interface {
+	CreateCollection(req *domain.CreateCollectionRequest) (*domain.BaseResponse, error)
+	GetMyCollections(userID string, page, size int) (*domain.PaginatedResponse, error)
+	GetCollection(id, userID string) (*domain.BaseResponse, error)
+	GetCollectionMovies(id, userID string, page, size int) (*domain.PaginatedResponse, error)
+	UpdateCollection(id, userID string, req *domain.UpdateCollectionRequest) (*domain.BaseResponse, error)
+	DeleteCollection(id, userID string) (*domain.BaseResponse, error)
+	InviteMember(id, userID string, req *domain.InviteMemberRequest) (*domain.BaseResponse, error)
+	UpdateMemberRole(id, memberID, userID string, req *domain.UpdateMemberRoleRequest) (*domain.BaseResponse, error)
+	RemoveMember(id, memberID, userID string) (*domain.BaseResponse, error)
+	CreateInviteLink(id, userID string, req *domain.CreateInviteLinkRequest) (*domain.BaseResponse, error)
+	RevokeInviteLink(id, userID string) (*domain.BaseResponse, error)
+	JoinByInvite(token, userID string) (*domain.BaseResponse, error)
+}
+
+type collectionUsecase struct {
+	collectionRepo repository.CollectionRepository
+	movieRepo      repository.MovieRepository
+	userRepo       repository.UserRepository
+}
+
+func NewCollectionUsecase(collectionRepo repository.CollectionRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository) CollectionUsecase {
+	return &collectionUsecase{
+		collectionRepo: collectionRepo,
+		movieRepo:      movieRepo,
+		userRepo:       userRepo,
+	}
+}
+
+func (uc *collectionUsecase) CreateCollection(req *domain.CreateCollectionRequest) (*domain.BaseResponse, error) {
+	user, err := uc.userRepo.FindByID(context.Background(), req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeFieldEmpty, "name must not be empty", "field", "name"))
+	}
+
+	now := time.Now()
+	collection := &domain.Collection{
+		Name:        name,
+		Description: req.Description,
+		OwnerID:     user.ID,
+		Members: []domain.CollectionMember{{
+			UserID:   user.ID,
+			Username: user.Username,
+			Role:     domain.CollectionRoleOwner,
+			JoinedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.collectionRepo.Create(context.Background(), collection); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Collection created successfully",
+		Object:  collection,
+	}, nil
+}
+
+func (uc *collectionUsecase) GetMyCollections(userID string, page, size int) (*domain.PaginatedResponse, error) {
+	collections, total, err := uc.collectionRepo.GetByMemberID(context.Background(), userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Invite links are only shown to owners
+	for i := range collections {
+		if collections[i].OwnerID.Hex() != userID {
+			collections[i].InviteToken = ""
+			collections[i].InviteRole = ""
+		}
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Collections retrieved successfully",
+		Object:     collections,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+func (uc *collectionUsecase) GetCollection(id, userID string) (*domain.BaseResponse, error) {
+	collection, err := uc.getCollectionAs(id, userID, domain.CollectionRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.OwnerID.Hex() != userID {
+		collection.InviteToken = ""
+		collection.InviteRole = ""
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Collection retrieved successfully",
+		Object:  collection,
+	}, nil
+}
+
+func (uc *collectionUsecase) GetCollectionMovies(id, userID string, page, size int) (*domain.PaginatedResponse, error) {
+	if _, err := uc.getCollectionAs(id, userID, domain.CollectionRoleViewer); err != nil {
+		return nil, err
+	}
+
+	movies, total, err := uc.movieRepo.GetByCollectionID(context.Background(), id, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Movies retrieved successfully",
+		Object:     movies,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+func (uc *collectionUsecase) UpdateCollection(id, userID string, req *domain.UpdateCollectionRequest) (*domain.BaseResponse, error) {
+	collection, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeFieldEmpty, "name must not be empty", "field", "name"))
+	}
+
+	collection.Name = name
+	collection.Description = req.Description
+	collection.UpdatedAt = time.Now()
+
+	if err := uc.collectionRepo.Update(context.Background(), id, collection); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Collection updated successfully",
+		Object:  collection,
+	}, nil
+}
+
+// DeleteCollection removes the collection but keeps its movies; each one
+// reverts to being managed by its creator alone.
+func (uc *collectionUsecase) DeleteCollection(id, userID string) (*domain.BaseResponse, error) {
+	if _, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := uc.movieRepo.ClearCollection(context.Background(), id); err != nil {
+		return nil, err
+	}
+	if err := uc.collectionRepo.Delete(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Collection deleted successfully",
+	}, nil
+}
+
+func (uc *collectionUsecase) InviteMember(id, userID string, req *domain.InviteMemberRequest) (*domain.BaseResponse, error) {
+	if _, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := validateMemberRole(req.Role); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	invitee, err := uc.userRepo.FindByUsername(context.Background(), req.Username)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeUserNotFound, "User not found"))
+	}
+
+	member := domain.CollectionMember{
+		UserID:   invitee.ID,
+		Username: invitee.Username,
+		Role:     req.Role,
+		JoinedAt: time.Now(),
+	}
+
+	added, err := uc.collectionRepo.AddMember(context.Background(), id, member)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, domain.Conflict(domain.CodeUserAlreadyMember, "User is already a member of this collection")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Member added successfully",
+		Object:  member,
+	}, nil
+}
+
+func (uc *collectionUsecase) UpdateMemberRole(id, memberID, userID string, req *domain.UpdateMemberRoleRequest) (*domain.BaseResponse, error) {
+	collection, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMemberRole(req.Role); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	if collection.OwnerID.Hex() == memberID {
+		return nil, domain.Conflict(domain.CodeOwnerRoleFixed, "The owner's role cannot be changed")
+	}
+
+	if !primitive.IsValidObjectID(memberID) {
+		return nil, domain.NotFound(domain.CodeMemberNotFound, "Member not found")
+	}
+
+	found, err := uc.collectionRepo.UpdateMemberRole(context.Background(), id, memberID, req.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.NotFound(domain.CodeMemberNotFound, "Member not found")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Member role updated successfully",
+	}, nil
+}
+
+// RemoveMember lets the owner remove anyone but themselves, and lets any
+// other member leave.
+func (uc *collectionUsecase) RemoveMember(id, memberID, userID string) (*domain.BaseResponse, error) {
+	requiredRole := domain.CollectionRoleOwner
+	if memberID == userID {
+		requiredRole = domain.CollectionRoleViewer
+	}
+
+	collection, err := uc.getCollectionAs(id, userID, requiredRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if collection.OwnerID.Hex() == memberID {
+		return nil, domain.Conflict(domain.CodeOwnerCannotLeave, "The owner cannot leave the collection")
+	}
+
+	if !primitive.IsValidObjectID(memberID) {
+		return nil, domain.NotFound(domain.CodeMemberNotFound, "Member not found")
+	}
+
+	removed, err := uc.collectionRepo.RemoveMember(context.Background(), id, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, domain.NotFound(domain.CodeMemberNotFound, "Member not found")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Member removed successfully",
+	}, nil
+}
+
+// CreateInviteLink issues a new invite token, replacing any previous one.
+func (uc *collectionUsecase) CreateInviteLink(id, userID string, req *domain.CreateInviteLinkRequest) (*domain.BaseResponse, error) {
+	collection, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateMemberRole(req.Role); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.collectionRepo.SetInvite(context.Background(), id, token, req.Role); err != nil {
+		return nil, err
+	}
+	collection.InviteToken = token
+	collection.InviteRole = req.Role
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Invite link created successfully",
+		Object:  collection,
+	}, nil
+}
+
+func (uc *collectionUsecase) RevokeInviteLink(id, userID string) (*domain.BaseResponse, error) {
+	if _, err := uc.getCollectionAs(id, userID, domain.CollectionRoleOwner); err != nil {
+		return nil, err
+	}
+
+	if err := uc.collectionRepo.SetInvite(context.Background(), id, "", ""); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Invite link revoked successfully",
+	}, nil
+}
+
+func (uc *collectionUsecase) JoinByInvite(token, userID string) (*domain.BaseResponse, error) {
+	collection, err := uc.collectionRepo.GetByInviteToken(context.Background(), token)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeInvalidInvite, "Invite link is invalid or has been revoked"))
+	}
+
+	user, err := uc.userRepo.FindByID(context.Background(), userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID")
+	}
+
+	member := domain.CollectionMember{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     collection.InviteRole,
+		JoinedAt: time.Now(),
+	}
+
+	added, err := uc.collectionRepo.AddMember(context.Background(), collection.ID.Hex(), member)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, domain.Conflict(domain.CodeAlreadyMember, "You are already a member of this collection")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Joined collection successfully",
+		Object:  member,
+	}, nil
+}
+
+// getCollectionAs loads a collection and checks the user holds at least the
+// given role in it.
+func (uc *collectionUsecase) getCollectionAs(id, userID, minRole string) (*domain.Collection, error) {
+	collection, err := uc.collectionRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeCollectionNotFound, "Collection not found"))
+	}
+
+	role := memberRole(collection, userID)
+	if role == "" {
+		// Non-members cannot tell a private collection from a missing one
+		return nil, domain.NotFound(domain.CodeCollectionNotFound, "Collection not found")
+	}
+	if roleRank(role) < roleRank(minRole) {
+		return nil, domain.Forbidden(domain.CodeCollectionManageForbidden, "You are not authorized to manage this collection")
+	}
+
+	return collection, nil
+}
+
+func roleRank(role string) int {
+	switch role {
+	case domain.CollectionRoleOwner:
+		return 3
+	case domain.CollectionRoleEditor:
+		return 2
+	case domain.CollectionRoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// validateMemberRole accepts the roles that can be granted. Ownership is
+// fixed at creation and cannot be handed out.
+func validateMemberRole(role string) error {
+	if role != domain.CollectionRoleEditor && role != domain.CollectionRoleViewer {
+		return domain.NewCodedError(domain.CodeFieldNotOneOf, "role must be editor or viewer", "field", "role", "values", "editor, viewer")
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxCommentLength   = 2000
+	defaultCommentPage = 20
+	maxCommentPage     = 100
+)
+
+type CommentUsecase interface {
+	CreateComment(req *domain.CreateCommentRequest) (*domain.BaseResponse, error)
+	UpdateComment(movieID, commentID, userID string, req *domain.UpdateCommentRequest) (*domain.BaseResponse, error)
+	DeleteComment(movieID, commentID, userID string) (*domain.BaseResponse, error)
+	GetThreads(movieID, cursor string, limit int) (*domain.CursorResponse, error)
+	GetReplies(movieID, commentID, cursor string, limit int) (*domain.CursorResponse, error)
+}
+
+type commentUsecase struct {
+	commentRepo repository.CommentRepository
+	movieRepo   repository.MovieRepository
+	userRepo    repository.UserRepository
+	filter      ContentFilter
+}
+
+func NewCommentUsecase(commentRepo repository.CommentRepository, movieRepo repository.MovieRepository, userRepo repository.UserRepository, filter ContentFilter) CommentUsecase {
+	return &commentUsecase{
+		commentRepo: commentRepo,
+		movieRepo:   movieRepo,
+		userRepo:    userRepo,
+		filter:      filter,
+	}
+}
+
+func (uc *commentUsecase) CreateComment(req *domain.CreateCommentRequest) (*domain.BaseResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if err := validateCommentBody(body); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	movie, err := uc.movieRepo.GetByID(context.Background(), req.MovieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	comment := &domain.Comment{
+		MovieID:   movie.ID,
+		UserID:    userID,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+
+	if req.ParentID != "" {
+		parent, err := uc.commentRepo.GetByID(context.Background(), req.ParentID)
+		if err != nil {
+			return nil, lookupError(err, domain.NotFound(domain.CodeParentCommentNotFound, "Parent comment not found"))
+		}
+		if parent.MovieID != movie.ID || parent.Deleted {
+			return nil, domain.NotFound(domain.CodeParentCommentNotFound, "Parent comment not found")
+		}
+
+		// Every reply points at the thread's root, however deeply it is nested
+		threadID := parent.ID
+		if parent.ThreadID != nil {
+			threadID = *parent.ThreadID
+		}
+		comment.ParentID = &parent.ID
+		comment.ThreadID = &threadID
+	}
+
+	if err := uc.applyFilter(comment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.commentRepo.Create(context.Background(), comment); err != nil {
+		return nil, err
+	}
+
+	if comment.ThreadID != nil {
+		if err := uc.commentRepo.IncrementReplies(context.Background(), comment.ThreadID.Hex(), 1); err != nil {
+			return nil, err
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Comment posted successfully",
+		Object:  comment,
+	}, nil
+}
+
+func (uc *commentUsecase) UpdateComment(movieID, commentID, userID string, req *domain.UpdateCommentRequest) (*domain.BaseResponse, error) {
+	comment, err := uc.getMovieComment(movieID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID.Hex() != userID {
+		return nil, domain.Forbidden(domain.CodeCommentEditForbidden, "You are not authorized to edit this comment")
+	}
+	if comment.Deleted {
+		return nil, domain.Conflict(domain.CodeCommentDeleted, "Deleted comments cannot be edited")
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if err := validateCommentBody(body); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	now := time.Now()
+	comment.Body = body
+	comment.Edited = true
+	comment.EditedAt = &now
+	comment.Flagged = false
+	comment.FlagReason = ""
+
+	if err := uc.applyFilter(comment); err != nil {
+		return nil, err
+	}
+
+	if err := uc.commentRepo.UpdateBody(context.Background(), commentID, comment); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Comment updated successfully",
+		Object:  comment,
+	}, nil
+}
+
+// DeleteComment lets authors delete their own comments and moderators delete
+// anyone's. A moderator deletion always leaves a tombstone; an author's
+// comment is removed outright unless replies still hang off it.
+func (uc *commentUsecase) DeleteComment(movieID, commentID, userID string) (*domain.BaseResponse, error) {
+	comment, err := uc.getMovieComment(movieID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Deleted {
+		return nil, domain.NotFound(domain.CodeCommentNotFound, "Comment not found")
+	}
+
+	if comment.UserID.Hex() != userID {
+		user, err := uc.userRepo.FindByID(context.Background(), userID)
+		if err != nil {
+			return nil, lookupError(err, domain.Forbidden(domain.CodeCommentDeleteForbidden, "You are not authorized to delete this comment"))
+		}
+		if !isModerator(user) {
+			return nil, domain.Forbidden(domain.CodeCommentDeleteForbidden, "You are not authorized to delete this comment")
+		}
+
+		if err := uc.commentRepo.Tombstone(context.Background(), commentID, domain.CommentDeletedByModerator); err != nil {
+			return nil, err
+		}
+		return &domain.BaseResponse{
+			Success: true,
+			Message: "Comment removed by moderator",
+		}, nil
+	}
+
+	hasReplies, err := uc.commentRepo.HasReplies(context.Background(), commentID)
+	if err != nil {
+		return nil, err
+	}
+	if hasReplies {
+		if err := uc.commentRepo.Tombstone(context.Background(), commentID, domain.CommentDeletedByAuthor); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := uc.commentRepo.Delete(context.Background(), commentID); err != nil {
+			return nil, err
+		}
+		if comment.ThreadID != nil {
+			if err := uc.commentRepo.IncrementReplies(context.Background(), comment.ThreadID.Hex(), -1); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Comment deleted successfully",
+	}, nil
+}
+
+func (uc *commentUsecase) GetThreads(movieID, cursor string, limit int) (*domain.CursorResponse, error) {
+	if !primitive.IsValidObjectID(movieID) {
+		return nil, domain.NotFound(domain.CodeMovieNotFound, "Movie not found")
+	}
+	if cursor != "" && !primitive.IsValidObjectID(cursor) {
+		return nil, domain.Validation(domain.CodeInvalidCursor, "Invalid cursor")
+	}
+	if _, err := uc.movieRepo.GetByID(context.Background(), movieID); err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	limit = clampCommentLimit(limit)
+	comments, err := uc.commentRepo.GetThreads(context.Background(), movieID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page, next := splitCommentPage(comments, limit)
+	return &domain.CursorResponse{
+		Success:    true,
+		Message:    "Comments retrieved successfully",
+		Object:     page,
+		NextCursor: next,
+	}, nil
+}
+
+// GetReplies pages through every reply in the thread rooted at commentID.
+// Replies carry their parentId so clients can rebuild the tree.
+func (uc *commentUsecase) GetReplies(movieID, commentID, cursor string, limit int) (*domain.CursorResponse, error) {
+	root, err := uc.getMovieComment(movieID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if root.ThreadID != nil {
+		return nil, domain.Validation(domain.CodeRepliesOnThread, "Replies are listed on the thread's top-level comment")
+	}
+	if cursor != "" && !primitive.IsValidObjectID(cursor) {
+		return nil, domain.Validation(domain.CodeInvalidCursor, "Invalid cursor")
+	}
+
+	limit = clampCommentLimit(limit)
+	comments, err := uc.commentRepo.GetReplies(context.Background(), commentID, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page, next := splitCommentPage(comments, limit)
+	return &domain.CursorResponse{
+		Success:    true,
+		Message:    "Replies retrieved successfully",
+		Object:     page,
+		NextCursor: next,
+	}, nil
+}
+
+// applyFilter runs the content filter over the comment. Rejected comments
+// fail validation; flagged ones are marked and saved as usual.
+func (uc *commentUsecase) applyFilter(comment *domain.Comment) error {
+	result, err := uc.filter.Check(comment.Body)
+	if err != nil {
+		return err
+	}
+
+	switch result.Action {
+	case FilterReject:
+		return domain.Validation(domain.CodeCommentRejected, "Comment was rejected by the content filter", domain.NewErrorDetail(domain.CodeBlockedWord, result.Reason))
+	case FilterFlag:
+		comment.Flagged = true
+		comment.FlagReason = result.Reason
+	}
+	return nil
+}
+
+func (uc *commentUsecase) getMovieComment(movieID, commentID string) (*domain.Comment, error) {
+	comment, err := uc.commentRepo.GetByID(context.Background(), commentID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeCommentNotFound, "Comment not found"))
+	}
+	if comment.MovieID.Hex() != movieID {
+		return nil, domain.NotFound(domain.CodeCommentNotFound, "Comment not found")
+	}
+	return comment, nil
+}
+
+func isModerator(user *domain.User) bool {
+	return user.Role == domain.UserRoleModerator || user.Role == domain.UserRoleAdmin
+}
+
+func validateCommentBody(body string) error {
+	if body == "" {
+		return domain.NewCodedError(domain.CodeFieldEmpty, "body must not be empty", "field", "body")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return domain.NewCodedError(domain.CodeFieldTooLong, "body must be at most 2000 characters long", "field", "body", "max", "2000")
+	}
+	return nil
+}
+
+func clampCommentLimit(limit int) int {
+	if limit <= 0 {
+		return defaultCommentPage
+	}
+	if limit > maxCommentPage {
+		return maxCommentPage
+	}
+	return limit
+}
+
+// splitCommentPage trims the extra look-ahead item fetched to detect whether
+// another page exists, and returns the cursor for that page.
+func splitCommentPage(comments []domain.Comment, limit int) ([]domain.Comment, string) {
+	if comments == nil {
+		comments = []domain.Comment{}
+	}
+	if len(comments) <= limit {
+		return comments, ""
+	}
+	comments = comments[:limit]
+	return comments, comments[limit-1].ID.Hex()
+}
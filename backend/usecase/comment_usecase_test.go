@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type commentFixture struct {
+	uc       CommentUsecase
+	comments *fakeCommentRepo
+	movieID  string
+	author   string
+	other    string
+	mod      string
+}
+
+func newCommentFixture(t *testing.T) *commentFixture {
+	t.Helper()
+	movie := &domain.Movie{Title: "Heat"}
+	author := &domain.User{}
+	other := &domain.User{}
+	mod := &domain.User{Role: domain.UserRoleModerator}
+	comments := &fakeCommentRepo{}
+	return &commentFixture{
+		uc:       NewCommentUsecase(comments, newFakeMovieRepo(movie), newFakeUserRepo(author, other, mod), NewKeywordFilter([]string{"spam"}, []string{"spoiler"})),
+		comments: comments,
+		movieID:  movie.ID.Hex(),
+		author:   author.ID.Hex(),
+		other:    other.ID.Hex(),
+		mod:      mod.ID.Hex(),
+	}
+}
+
+func (f *commentFixture) post(t *testing.T, userID, parentID, body string) *domain.Comment {
+	t.Helper()
+	response, err := f.uc.CreateComment(&domain.CreateCommentRequest{Body: body, ParentID: parentID, UserID: userID, MovieID: f.movieID})
+	if err != nil {
+		t.Fatalf("CreateComment(%q): %v", body, err)
+	}
+	return response.Object.(*domain.Comment)
+}
+
+func TestCreateCommentThreadsRepliesOnTheRoot(t *testing.T) {
+	f := newCommentFixture(t)
+	root := f.post(t, f.author, "", "What an ending")
+	reply := f.post(t, f.other, root.ID.Hex(), "Agreed")
+	nested := f.post(t, f.author, reply.ID.Hex(), "Thanks")
+
+	if *reply.ThreadID != root.ID || *nested.ThreadID != root.ID {
+		t.Errorf("thread IDs = %s, %s; want both %s", reply.ThreadID.Hex(), nested.ThreadID.Hex(), root.ID.Hex())
+	}
+	if *nested.ParentID != reply.ID {
+		t.Errorf("nested parent = %s, want %s", nested.ParentID.Hex(), reply.ID.Hex())
+	}
+	if stored := f.comments.byID(root.ID.Hex()); stored.ReplyCount != 2 {
+		t.Errorf("root reply count = %d, want 2", stored.ReplyCount)
+	}
+}
+
+func TestCreateCommentFilters(t *testing.T) {
+	f := newCommentFixture(t)
+
+	flagged := f.post(t, f.author, "", "Big spoiler ahead")
+	if !flagged.Flagged || flagged.FlagReason == "" {
+		t.Errorf("flagged, reason = %v, %q; want a flagged comment with a reason", flagged.Flagged, flagged.FlagReason)
+	}
+
+	_, err := f.uc.CreateComment(&domain.CreateCommentRequest{Body: "Buy SPAM now", UserID: f.author, MovieID: f.movieID})
+	if code := errorCode(err); code != domain.CodeCommentRejected {
+		t.Errorf("blocked comment code = %q, want %q", code, domain.CodeCommentRejected)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		deleter    func(f *commentFixture) string
+		withReply  bool
+		code       string
+		tombstoned string // who the tombstone names, if one is left
+		removed    bool
+	}{
+		{name: "author without replies removes the comment", deleter: func(f *commentFixture) string { return f.author }, removed: true},
+		{name: "author with replies leaves a tombstone", deleter: func(f *commentFixture) string { return f.author }, withReply: true, tombstoned: domain.CommentDeletedByAuthor},
+		{name: "moderator always leaves a tombstone", deleter: func(f *commentFixture) string { return f.mod }, tombstoned: domain.CommentDeletedByModerator},
+		{name: "another user may not delete it", deleter: func(f *commentFixture) string { return f.other }, code: domain.CodeCommentDeleteForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCommentFixture(t)
+			root := f.post(t, f.other, "", "Opening thoughts")
+			comment := f.post(t, f.author, root.ID.Hex(), "About the ending")
+			if tt.withReply {
+				f.post(t, f.other, comment.ID.Hex(), "Indeed")
+			}
+			replies := f.comments.byID(root.ID.Hex()).ReplyCount
+
+			_, err := f.uc.DeleteComment(f.movieID, comment.ID.Hex(), tt.deleter(f))
+			if code := errorCode(err); code != tt.code {
+				t.Fatalf("DeleteComment code = %q, want %q", code, tt.code)
+			}
+
+			stored := f.comments.byID(comment.ID.Hex())
+			switch {
+			case tt.removed:
+				if stored != nil {
+					t.Fatal("comment is still stored, want it removed")
+				}
+				if got := f.comments.byID(root.ID.Hex()).ReplyCount; got != replies-1 {
+					t.Errorf("root reply count = %d, want %d", got, replies-1)
+				}
+			case tt.tombstoned != "":
+				if !stored.Deleted || stored.DeletedBy != tt.tombstoned || stored.Body != "" {
+					t.Errorf("tombstone = deleted %v by %q with body %q; want deleted by %q with no body", stored.Deleted, stored.DeletedBy, stored.Body, tt.tombstoned)
+				}
+				if _, err := f.uc.DeleteComment(f.movieID, comment.ID.Hex(), tt.deleter(f)); errorCode(err) != domain.CodeCommentNotFound {
+					t.Errorf("deleting a tombstone again = %v, want %q", err, domain.CodeCommentNotFound)
+				}
+			default:
+				if stored == nil || stored.Deleted {
+					t.Error("comment was deleted, want it kept")
+				}
+			}
+		})
+	}
+}
+
+func TestTombstonedCommentsCannotBeEdited(t *testing.T) {
+	f := newCommentFixture(t)
+	comment := f.post(t, f.author, "", "First")
+	f.post(t, f.other, comment.ID.Hex(), "Reply")
+
+	if _, err := f.uc.DeleteComment(f.movieID, comment.ID.Hex(), f.author); err != nil {
+		t.Fatal(err)
+	}
+	_, err := f.uc.UpdateComment(f.movieID, comment.ID.Hex(), f.author, &domain.UpdateCommentRequest{Body: "Second"})
+	if code := errorCode(err); code != domain.CodeCommentDeleted {
+		t.Errorf("editing a tombstone code = %q, want %q", code, domain.CodeCommentDeleted)
+	}
+
+	_, err = f.uc.CreateComment(&domain.CreateCommentRequest{Body: "Late reply", ParentID: comment.ID.Hex(), UserID: f.other, MovieID: f.movieID})
+	if code := errorCode(err); code != domain.CodeParentCommentNotFound {
+		t.Errorf("replying to a tombstone code = %q, want %q", code, domain.CodeParentCommentNotFound)
+	}
+}
+
+func TestCommentPaging(t *testing.T) {
+	f := newCommentFixture(t)
+	var threads []string
+	for i := 0; i < 5; i++ {
+		threads = append(threads, f.post(t, f.author, "", "Thread").ID.Hex())
+	}
+	var replies []string
+	for i := 0; i < 5; i++ {
+		replies = append(replies, f.post(t, f.other, threads[0], "Reply").ID.Hex())
+	}
+
+	// Threads come newest first, replies oldest first
+	newestFirst := make([]string, len(threads))
+	for i, id := range threads {
+		newestFirst[len(threads)-1-i] = id
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		fetch func(cursor string, limit int) (*domain.CursorResponse, error)
+		want  []string
+		pages int
+	}{
+		{name: "threads two at a time", limit: 2, fetch: func(cursor string, limit int) (*domain.CursorResponse, error) {
+			return f.uc.GetThreads(f.movieID, cursor, limit)
+		}, want: newestFirst, pages: 3},
+		{name: "threads on one page", limit: 5, fetch: func(cursor string, limit int) (*domain.CursorResponse, error) {
+			return f.uc.GetThreads(f.movieID, cursor, limit)
+		}, want: newestFirst, pages: 1},
+		{name: "replies three at a time", limit: 3, fetch: func(cursor string, limit int) (*domain.CursorResponse, error) {
+			return f.uc.GetReplies(f.movieID, threads[0], cursor, limit)
+		}, want: replies, pages: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			cursor, pages := "", 0
+			for {
+				response, err := tt.fetch(cursor, tt.limit)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pages++
+				for _, comment := range response.Object.([]domain.Comment) {
+					got = append(got, comment.ID.Hex())
+				}
+				if response.NextCursor == "" || pages > len(tt.want) {
+					break
+				}
+				cursor = response.NextCursor
+			}
+
+			if pages != tt.pages {
+				t.Errorf("pages = %d, want %d", pages, tt.pages)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d comments, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("comment %d = %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCommentPagingRejectsBadInput(t *testing.T) {
+	f := newCommentFixture(t)
+	_, err := f.uc.GetThreads(f.movieID, "page-2", 10)
+	if code := errorCode(err); code != domain.CodeInvalidCursor {
+		t.Errorf("GetThreads with a bad cursor code = %q, want %q", code, domain.CodeInvalidCursor)
+	}
+	for _, movieID := range []string{primitive.NewObjectID().Hex() + "x", primitive.NewObjectID().Hex()} {
+		_, err = f.uc.GetThreads(movieID, "", 10)
+		if code := errorCode(err); code != domain.CodeMovieNotFound {
+			t.Errorf("GetThreads(%q) code = %q, want %q", movieID, code, domain.CodeMovieNotFound)
+		}
+	}
+}
+
+func TestValidateCommentBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{name: "empty", body: "", want: domain.CodeFieldEmpty},
+		{name: "at the limit", body: strings.Repeat("a", maxCommentLength)},
+		{name: "over the limit", body: strings.Repeat("a", maxCommentLength+1), want: domain.CodeFieldTooLong},
+		{name: "characters are counted, not bytes", body: strings.Repeat("ሰ", maxCommentLength)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := errorCode(validateCommentBody(tt.body)); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+		})
+	}
+}
+
+func TestClampCommentLimit(t *testing.T) {
+	tests := []struct{ limit, want int }{
+		{limit: -1, want: defaultCommentPage},
+		{limit: 0, want: defaultCommentPage},
+		{limit: 1, want: 1},
+		{limit: maxCommentPage, want: maxCommentPage},
+		{limit: maxCommentPage + 1, want: maxCommentPage},
+	}
+	for _, tt := range tests {
+		if got := clampCommentLimit(tt.limit); got != tt.want {
+			t.Errorf("clampCommentLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSplitCommentPage(t *testing.T) {
+	comments := make([]domain.Comment, 4)
+	for i := range comments {
+		comments[i].ID = primitive.NewObjectID()
+	}
+
+	tests := []struct {
+		name  string
+		in    []domain.Comment
+		limit int
+		size  int
+		next  string
+	}{
+		{name: "nothing", in: nil, limit: 3, size: 0},
+		{name: "fewer than the limit", in: comments[:2], limit: 3, size: 2},
+		{name: "exactly the limit", in: comments[:3], limit: 3, size: 3},
+		{name: "one past the limit", in: comments, limit: 3, size: 3, next: comments[2].ID.Hex()},
+	}
+	for _, tt := range tests {
+		page, next := splitCommentPage(tt.in, tt.limit)
+		if page == nil || len(page) != tt.size || next != tt.next {
+			t.Errorf("%s: page of %d, next %q; want %d, %q", tt.name, len(page), next, tt.size, tt.next)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"strings"
+)
+
+// Content filter verdicts.
+const (
+	FilterAllow  = "allow"
+	FilterFlag   = "flag"
+	FilterReject = "reject"
+)
+
+// FilterResult is a content filter's verdict on a piece of text. Reason is
+// set for flag and reject verdicts.
+type FilterResult struct {
+	Action string
+	Reason string
+}
+
+// ContentFilter inspects user-written text before it is saved. Rejected text
+// is refused; flagged text is saved but marked for moderator review.
+type ContentFilter interface {
+	Check(text string) (FilterResult, error)
+}
+
+type keywordFilter struct {
+	blocked []string
+	flagged []string
+}
+
+// NewKeywordFilter returns a filter that rejects text containing any blocked
+// word and flags text containing any flagged word. Matching is case-insensitive.
+func NewKeywordFilter(blocked, flagged []string) ContentFilter {
+	return &keywordFilter{
+		blocked: normalizeWords(blocked),
+		flagged: normalizeWords(flagged),
+	}
+}
+
+func (f *keywordFilter) Check(text string) (FilterResult, error) {
+	lower := strings.ToLower(text)
+
+	for _, word := range f.blocked {
+		if strings.Contains(lower, word) {
+			return FilterResult{Action: FilterReject, Reason: "contains blocked word"}, nil
+		}
+	}
+	for _, word := range f.flagged {
+		if strings.Contains(lower, word) {
+			return FilterResult{Action: FilterFlag, Reason: "contains flagged word: " + word}, nil
+		}
+	}
+
+	return FilterResult{Action: FilterAllow}, nil
+}
+
+func normalizeWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return normalized
+}
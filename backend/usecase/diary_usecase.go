@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const dateLayout = "2006-01-02"
+
+type DiaryUsecase interface {
+	LogViewing(req *domain.LogViewingRequest) (*domain.BaseResponse, error)
+	DeleteEntry(id, userID string) (*domain.BaseResponse, error)
+	GetDiary(userID, from, to string, page, size int) (*domain.PaginatedResponse, error)
+	ExportDiary(userID, from, to string) ([]domain.DiaryExportRow, error)
+}
+
+type diaryUsecase struct {
+	diaryRepo repository.DiaryRepository
+	movieRepo repository.MovieRepository
+}
+
+func NewDiaryUsecase(diaryRepo repository.DiaryRepository, movieRepo repository.MovieRepository) DiaryUsecase {
+	return &diaryUsecase{
+		diaryRepo: diaryRepo,
+		movieRepo: movieRepo,
+	}
+}
+
+func (uc *diaryUsecase) LogViewing(req *domain.LogViewingRequest) (*domain.BaseResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	watchedOn, err := parseWatchedOn(req.WatchedOn)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	var rating float64
+	if req.Rating != nil {
+		if err := validateRating(*req.Rating); err != nil {
+			return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+		}
+		rating = *req.Rating
+	}
+
+	movie, err := uc.movieRepo.GetByID(context.Background(), req.MovieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	entry := &domain.DiaryEntry{
+		UserID:    userID,
+		MovieID:   movie.ID,
+		WatchedOn: watchedOn,
+		Rewatch:   req.Rewatch,
+		Rating:    rating,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.diaryRepo.Create(context.Background(), entry); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Viewing logged successfully",
+		Object:  entry,
+	}, nil
+}
+
+func (uc *diaryUsecase) DeleteEntry(id, userID string) (*domain.BaseResponse, error) {
+	entry, err := uc.diaryRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeDiaryEntryNotFound, "Diary entry not found"))
+	}
+	if entry.UserID.Hex() != userID {
+		return nil, domain.NotFound(domain.CodeDiaryEntryNotFound, "Diary entry not found")
+	}
+
+	if err := uc.diaryRepo.Delete(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Diary entry deleted successfully",
+	}, nil
+}
+
+func (uc *diaryUsecase) GetDiary(userID, from, to string, page, size int) (*domain.PaginatedResponse, error) {
+	fromDate, toDate, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidDateFilter, "Invalid date filter", domain.ErrorDetails(err)...)
+	}
+
+	entries, total, err := uc.diaryRepo.GetByUserID(context.Background(), userID, fromDate, toDate, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Diary retrieved successfully",
+		Object:     entries,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+// ExportDiary returns the user's diary in chronological order with movie
+// titles resolved, ready to be written out as CSV or JSON.
+func (uc *diaryUsecase) ExportDiary(userID, from, to string) ([]domain.DiaryExportRow, error) {
+	fromDate, toDate, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidDateFilter, "Invalid date filter", domain.ErrorDetails(err)...)
+	}
+
+	entries, err := uc.diaryRepo.GetAllByUserID(context.Background(), userID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	movieIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		movieIDs = append(movieIDs, entry.MovieID.Hex())
+	}
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	titles := make(map[primitive.ObjectID]string, len(movies))
+	for _, movie := range movies {
+		titles[movie.ID] = movie.Title
+	}
+
+	rows := make([]domain.DiaryExportRow, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, domain.DiaryExportRow{
+			WatchedOn: entry.WatchedOn.Format(dateLayout),
+			MovieID:   entry.MovieID.Hex(),
+			Title:     titles[entry.MovieID],
+			Rewatch:   entry.Rewatch,
+			Rating:    entry.Rating,
+		})
+	}
+
+	return rows, nil
+}
+
+// parseWatchedOn parses a YYYY-MM-DD viewing date, defaulting to today.
+func parseWatchedOn(value string) (time.Time, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if value == "" {
+		return today, nil
+	}
+
+	watchedOn, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, domain.NewCodedError(domain.CodeDateInvalid, "watchedOn must be a date in YYYY-MM-DD format", "field", "watchedOn")
+	}
+	if watchedOn.After(today) {
+		return time.Time{}, domain.NewCodedError(domain.CodeDateInFuture, "watchedOn cannot be in the future", "field", "watchedOn")
+	}
+	return watchedOn, nil
+}
+
+// parseDateRange parses optional YYYY-MM-DD bounds. The upper bound is
+// inclusive, so it is moved to the last instant of that day.
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	var fromDate, toDate time.Time
+	var err error
+
+	if from != "" {
+		if fromDate, err = time.Parse(dateLayout, from); err != nil {
+			return time.Time{}, time.Time{}, domain.NewCodedError(domain.CodeDateInvalid, "from must be a date in YYYY-MM-DD format", "field", "from")
+		}
+	}
+	if to != "" {
+		if toDate, err = time.Parse(dateLayout, to); err != nil {
+			return time.Time{}, time.Time{}, domain.NewCodedError(domain.CodeDateInvalid, "to must be a date in YYYY-MM-DD format", "field", "to")
+		}
+		toDate = toDate.Add(24*time.Hour - time.Nanosecond)
+	}
+	if !fromDate.IsZero() && !toDate.IsZero() && fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, domain.NewCodedError(domain.CodeDateRangeOrder, "from must not be after to")
+	}
+
+	return fromDate, toDate, nil
+}
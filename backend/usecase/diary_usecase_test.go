@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestParseWatchedOn(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	tests := []struct {
+		value string
+		want  time.Time
+		code  string
+	}{
+		{value: "", want: today},
+		{value: "2024-02-29", want: onDay("2024-02-29")},
+		{value: today.Format(dateLayout), want: today},
+		{value: today.AddDate(0, 0, 1).Format(dateLayout), code: domain.CodeDateInFuture},
+		{value: "2024-02-30", code: domain.CodeDateInvalid},
+		{value: "29/02/2024", code: domain.CodeDateInvalid},
+	}
+	for _, tt := range tests {
+		got, err := parseWatchedOn(tt.value)
+		if code := errorCode(err); code != tt.code || !got.Equal(tt.want) {
+			t.Errorf("parseWatchedOn(%q) = %v, %q; want %v, %q", tt.value, got, code, tt.want, tt.code)
+		}
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		wantFrom time.Time
+		wantTo   time.Time
+		code     string
+	}{
+		{name: "no bounds"},
+		{name: "the upper bound includes its whole day", from: "2024-03-01", to: "2024-03-31", wantFrom: onDay("2024-03-01"), wantTo: onDay("2024-04-01").Add(-time.Nanosecond)},
+		{name: "a single day", from: "2024-03-01", to: "2024-03-01", wantFrom: onDay("2024-03-01"), wantTo: onDay("2024-03-02").Add(-time.Nanosecond)},
+		{name: "only a lower bound", from: "2024-03-01", wantFrom: onDay("2024-03-01")},
+		{name: "bounds out of order", from: "2024-03-02", to: "2024-03-01", code: domain.CodeDateRangeOrder},
+		{name: "a bad lower bound", from: "March", code: domain.CodeDateInvalid},
+		{name: "a bad upper bound", to: "2024-13-01", code: domain.CodeDateInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseDateRange(tt.from, tt.to)
+			if code := errorCode(err); code != tt.code {
+				t.Fatalf("code = %q, want %q", code, tt.code)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("range = %v to %v, want %v to %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestLogViewing(t *testing.T) {
+	movie := &domain.Movie{Title: "Alien"}
+	diary := &fakeDiaryRepo{}
+	uc := NewDiaryUsecase(diary, newFakeMovieRepo(movie))
+	userID := primitive.NewObjectID().Hex()
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(dateLayout)
+
+	tests := []struct {
+		name string
+		req  domain.LogViewingRequest
+		want string
+	}{
+		{name: "a dated viewing", req: domain.LogViewingRequest{MovieID: movie.ID.Hex(), WatchedOn: "2024-03-01"}},
+		{name: "the same day again, as a rewatch", req: domain.LogViewingRequest{MovieID: movie.ID.Hex(), WatchedOn: "2024-03-01", Rewatch: true}},
+		{name: "a viewing tomorrow", req: domain.LogViewingRequest{MovieID: movie.ID.Hex(), WatchedOn: tomorrow}, want: domain.CodeValidationFailed},
+		{name: "an unknown movie", req: domain.LogViewingRequest{MovieID: primitive.NewObjectID().Hex()}, want: domain.CodeMovieNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.req.UserID = userID
+			_, err := uc.LogViewing(&tt.req)
+			if code := errorCode(err); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+		})
+	}
+	if len(diary.entries) != 2 || !diary.entries[0].WatchedOn.Equal(onDay("2024-03-01")) {
+		t.Errorf("diary = %v, want two viewings on 2024-03-01", diary.entries)
+	}
+}
+
+func TestDeleteDiaryEntry(t *testing.T) {
+	owner, other := primitive.NewObjectID(), primitive.NewObjectID()
+	entry := domain.DiaryEntry{ID: primitive.NewObjectID(), UserID: owner, MovieID: primitive.NewObjectID(), WatchedOn: onDay("2024-03-01")}
+	diary := &fakeDiaryRepo{entries: []domain.DiaryEntry{entry}}
+	uc := NewDiaryUsecase(diary, newFakeMovieRepo())
+
+	tests := []struct {
+		name   string
+		userID string
+		want   string
+	}{
+		{name: "another user", userID: other.Hex(), want: domain.CodeDiaryEntryNotFound},
+		{name: "the owner", userID: owner.Hex()},
+		{name: "the owner again", userID: owner.Hex(), want: domain.CodeDiaryEntryNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept := len(diary.entries)
+			_, err := uc.DeleteEntry(entry.ID.Hex(), tt.userID)
+			if code := errorCode(err); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+			if tt.want != "" && len(diary.entries) != kept {
+				t.Error("a refused delete removed the entry")
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultDuplicateLimit = 50
+	maxDuplicateLimit     = 200
+)
+
+// ErrAdminRequired is returned when a non-admin calls an admin operation.
+var ErrAdminRequired error = domain.Forbidden(domain.CodeAdminRequired, "Admin access required")
+
+// DuplicateUsecase finds movies entered more than once and merges them.
+// Both operations are for admins only.
+type DuplicateUsecase interface {
+	FindDuplicates(userID string, limit int) (*domain.BaseResponse, error)
+	MergeMovies(userID string, req *domain.MergeMoviesRequest) (*domain.BaseResponse, error)
+}
+
+type duplicateUsecase struct {
+	userRepo      repository.UserRepository
+	movieRepo     repository.MovieRepository
+	reviewRepo    repository.ReviewRepository
+	likeRepo      repository.LikeRepository
+	redirectRepo  repository.MovieRedirectRepository
+	referenceRepo repository.MovieReferenceRepository
+	similarity    SimilarityIndexer
+	posters       PosterUsecase
+}
+
+func NewDuplicateUsecase(userRepo repository.UserRepository, movieRepo repository.MovieRepository, reviewRepo repository.ReviewRepository, likeRepo repository.LikeRepository, redirectRepo repository.MovieRedirectRepository, referenceRepo repository.MovieReferenceRepository, similarity SimilarityIndexer, posters PosterUsecase) DuplicateUsecase {
+	return &duplicateUsecase{
+		userRepo:      userRepo,
+		movieRepo:     movieRepo,
+		reviewRepo:    reviewRepo,
+		likeRepo:      likeRepo,
+		redirectRepo:  redirectRepo,
+		referenceRepo: referenceRepo,
+		similarity:    similarity,
+		posters:       posters,
+	}
+}
+
+// FindDuplicates lists the likeliest groups of duplicate movies, at most
+// limit of them.
+func (uc *duplicateUsecase) FindDuplicates(userID string, limit int) (*domain.BaseResponse, error) {
+	if err := uc.requireAdmin(userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDuplicateLimit
+	}
+	if limit > maxDuplicateLimit {
+		limit = maxDuplicateLimit
+	}
+
+	catalogue, err := uc.movieRepo.GetCatalogue(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	found := findDuplicateGroups(catalogue)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	var ids []string
+	for _, group := range found {
+		for _, i := range group.members {
+			ids = append(ids, catalogue[i].ID.Hex())
+		}
+	}
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), ids)
+	if err != nil {
+		return nil, err
+	}
+	moviesByID := make(map[primitive.ObjectID]domain.Movie, len(movies))
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	groups := make([]domain.DuplicateGroup, 0, len(found))
+	for _, group := range found {
+		result := domain.DuplicateGroup{Score: group.score}
+		for _, i := range group.members {
+			if movie, ok := moviesByID[catalogue[i].ID]; ok {
+				result.Movies = append(result.Movies, movie)
+			}
+		}
+		if len(result.Movies) < 2 {
+			continue
+		}
+		result.CanonicalID = suggestCanonical(result.Movies).Hex()
+		groups = append(groups, result)
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Duplicate movies retrieved successfully",
+		Object:  groups,
+	}, nil
+}
+
+// MergeMovies folds each duplicate into the canonical movie: its reviews,
+// likes, list entries, watchlist and diary entries, comments and activity
+// move over, details the canonical movie lacks are copied, and the
+// duplicate is deleted, leaving a redirect from its ID. Each duplicate is
+// finished before the next is started, and one that already redirects to
+// the canonical movie counts as merged, so a merge that failed part way
+// can be retried with the same request.
+func (uc *duplicateUsecase) MergeMovies(userID string, req *domain.MergeMoviesRequest) (*domain.BaseResponse, error) {
+	if err := uc.requireAdmin(userID); err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+
+	canonical, err := uc.movieRepo.GetByID(ctx, req.CanonicalID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeCanonicalMovieNotFound, "Canonical movie not found"))
+	}
+
+	result := domain.MergeResult{Moved: map[string]int64{}}
+	var duplicates []*domain.Movie
+	seen := map[string]bool{}
+	for _, id := range req.DuplicateIDs {
+		if id == req.CanonicalID {
+			return nil, domain.Validation(domain.CodeMergeIntoSelf, "A movie cannot be merged into itself")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		duplicate, err := uc.movieRepo.GetByID(ctx, id)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if redirect, redirectErr := uc.redirectRepo.GetByID(ctx, id); redirectErr == nil && redirect.MovieID == canonical.ID {
+				result.Merged = append(result.Merged, id)
+				continue
+			}
+		}
+		if err != nil {
+			return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found", domain.NewErrorDetail(domain.CodeUnknownMovie, "no movie with ID "+id, "id", id)))
+		}
+		duplicates = append(duplicates, duplicate)
+	}
+
+	merged := *canonical
+	for _, duplicate := range duplicates {
+		fillMissingDetails(&merged, duplicate)
+	}
+	if err := uc.movieRepo.Update(ctx, canonical.ID.Hex(), movieDetails(&merged)); err != nil {
+		return nil, err
+	}
+	// Take a duplicate's poster only if the canonical movie still has
+	// none, so one uploaded meanwhile is kept and the duplicate's deleted
+	if merged.PosterKey != canonical.PosterKey || merged.Poster != canonical.Poster {
+		filled, err := uc.movieRepo.FillPoster(ctx, canonical.ID.Hex(), merged.PosterKey, merged.Poster)
+		if err != nil {
+			return nil, err
+		}
+		if !filled {
+			merged.PosterKey, merged.Poster = "", ""
+		}
+	}
+
+	adminID, _ := primitive.ObjectIDFromHex(userID)
+	for _, duplicate := range duplicates {
+		moved, err := uc.referenceRepo.Reassign(ctx, duplicate.ID, canonical.ID)
+		if err != nil {
+			return nil, err
+		}
+		for kind, count := range moved {
+			result.Moved[kind] += count
+		}
+		if err := uc.redirectRepo.Repoint(ctx, duplicate.ID, canonical.ID); err != nil {
+			return nil, err
+		}
+		err = uc.redirectRepo.Create(ctx, &domain.MovieRedirect{
+			ID:       duplicate.ID,
+			MovieID:  canonical.ID,
+			MergedBy: adminID,
+			MergedAt: time.Now(),
+		})
+		// The redirect is left from an earlier attempt that failed
+		// before deleting the duplicate
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+		if err := uc.movieRepo.Delete(ctx, duplicate.ID.Hex()); err != nil {
+			return nil, err
+		}
+		if duplicate.PosterKey != "" && duplicate.PosterKey != merged.PosterKey {
+			uc.posters.DeletePoster(duplicate.PosterKey)
+		}
+		result.Merged = append(result.Merged, duplicate.ID.Hex())
+	}
+
+	if err := refreshMovieRating(ctx, uc.reviewRepo, uc.movieRepo, canonical.ID.Hex()); err != nil {
+		return nil, err
+	}
+	// Likes are recounted rather than added up as they move, so a merge
+	// retried after a failure does not count them twice
+	likes, err := uc.likeRepo.CountByMovieID(ctx, canonical.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.movieRepo.SetLikes(ctx, canonical.ID.Hex(), likes); err != nil {
+		return nil, err
+	}
+	uc.similarity.MovieChanged()
+
+	if result.Movie, err = uc.movieRepo.GetByID(ctx, canonical.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movies merged successfully",
+		Object:  result,
+	}, nil
+}
+
+func (uc *duplicateUsecase) requireAdmin(userID string) error {
+	user, err := uc.userRepo.FindByID(context.Background(), userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if err != nil || user.Role != domain.UserRoleAdmin {
+		return ErrAdminRequired
+	}
+	return nil
+}
+
+// suggestCanonical picks the movie to keep from a duplicate group: the one
+// with the most reviews and likes, then the oldest.
+func suggestCanonical(movies []domain.Movie) primitive.ObjectID {
+	best := movies[0]
+	for _, movie := range movies[1:] {
+		weight, bestWeight := movie.RatingCount+movie.LikeCount, best.RatingCount+best.LikeCount
+		if weight > bestWeight || (weight == bestWeight && movie.ID.Timestamp().Before(best.ID.Timestamp())) {
+			best = movie
+		}
+	}
+	return best.ID
+}
+
+// fillMissingDetails copies details the canonical movie lacks from a
+// duplicate, and adds names the duplicate lists that it does not.
+func fillMissingDetails(canonical, duplicate *domain.Movie) {
+	if canonical.Description == "" {
+		canonical.Description = duplicate.Description
+	}
+	if canonical.PosterKey == "" && canonical.Poster == "" {
+		canonical.PosterKey = duplicate.PosterKey
+		canonical.Poster = duplicate.Poster
+	}
+	if canonical.Trailer == "" {
+		canonical.Trailer = duplicate.Trailer
+		canonical.TrailerVideo = duplicate.TrailerVideo
+	}
+	if canonical.Year == 0 {
+		canonical.Year = duplicate.Year
+	}
+	if canonical.IMDbID == "" {
+		canonical.IMDbID = duplicate.IMDbID
+	}
+	for _, translation := range duplicate.Translations {
+		if translation.Locale != canonical.Locale && !hasTranslation(canonical, translation.Locale) {
+			canonical.Translations = append(canonical.Translations, translation)
+		}
+	}
+	canonical.Actors = mergeNames(canonical.Actors, duplicate.Actors)
+	canonical.Genres = mergeNames(canonical.Genres, duplicate.Genres)
+	canonical.Crew = mergeNames(canonical.Crew, duplicate.Crew)
+}
+
+func hasTranslation(movie *domain.Movie, locale string) bool {
+	for _, translation := range movie.Translations {
+		if translation.Locale == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// movieDetails copies the fields of a movie that MovieRepository.Update
+// writes.
+func movieDetails(movie *domain.Movie) *domain.Movie {
+	return &domain.Movie{
+		Title:        movie.Title,
+		Description:  movie.Description,
+		Trailer:      movie.Trailer,
+		TrailerVideo: movie.TrailerVideo,
+		Locale:       movie.Locale,
+		Translations: movie.Translations,
+		Actors:       movie.Actors,
+		Genres:       movie.Genres,
+		Crew:         movie.Crew,
+		Year:         movie.Year,
+		IMDbID:       movie.IMDbID,
+	}
+}
+
+// mergeNames appends the names in extra not already in names, ignoring case.
+func mergeNames(names, extra []string) []string {
+	have := nameTerms(names)
+	for _, name := range extra {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := have[key]; ok || key == "" {
+			continue
+		}
+		have[key] = 1
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// minDuplicateScore is the pair score above which two movies are
+	// reported as the same film.
+	minDuplicateScore = 0.8
+
+	// minDuplicateTitleSimilarity filters pairs before the full score is
+	// computed; titles further apart are never the same film.
+	minDuplicateTitleSimilarity = 0.75
+
+	// maxDuplicatePostings skips title words shared by so many movies that
+	// comparing them all would cost more than it finds.
+	maxDuplicatePostings = 200
+
+	// titlePrefixLength is how many opening letters of a title are indexed.
+	titlePrefixLength = 4
+)
+
+// leadingArticles are dropped from normalized titles, so "The Matrix" and
+// "Matrix" compare equal.
+var leadingArticles = map[string]bool{"the": true, "a": true, "an": true}
+
+// duplicateCandidate is a movie prepared for comparison.
+type duplicateCandidate struct {
+	movie  domain.Movie
+	title  string
+	tokens []string
+	cast   map[string]float64
+}
+
+// duplicateGroup is a set of movies, by catalogue index, believed to be
+// the same film. score is the weakest link holding the group together.
+type duplicateGroup struct {
+	members []int
+	score   float64
+}
+
+// findDuplicateGroups finds groups of movies that look like the same film,
+// strongest groups first. Candidate pairs come from an inverted index on
+// title words and opening letters, so movies sharing neither are never
+// compared.
+func findDuplicateGroups(movies []domain.Movie) []duplicateGroup {
+	candidates := make([]duplicateCandidate, len(movies))
+	postings := map[string][]int{}
+	for i, movie := range movies {
+		title := normalizeTitle(movie.Title)
+		tokens := strings.Fields(title)
+		candidates[i] = duplicateCandidate{
+			movie:  movie,
+			title:  title,
+			tokens: tokens,
+			cast:   nameTerms(movie.Actors),
+		}
+		for _, token := range uniqueStrings(tokens) {
+			postings[token] = append(postings[token], i)
+		}
+		// Titles also meet on their opening letters, catching typos in
+		// one-word titles
+		if prefix := []rune(strings.ReplaceAll(title, " ", "")); len(prefix) >= titlePrefixLength {
+			key := "prefix:" + string(prefix[:titlePrefixLength])
+			postings[key] = append(postings[key], i)
+		}
+	}
+
+	parent := make([]int, len(movies))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	// Scores of the pairs that joined each group, keyed by member
+	linkScores := map[int]float64{}
+	compared := map[[2]int]bool{}
+	for _, ids := range postings {
+		if len(ids) < 2 || len(ids) > maxDuplicatePostings {
+			continue
+		}
+		for x := 0; x < len(ids); x++ {
+			for y := x + 1; y < len(ids); y++ {
+				pair := [2]int{ids[x], ids[y]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				score, ok := duplicateScore(&candidates[pair[0]], &candidates[pair[1]])
+				if !ok {
+					continue
+				}
+				a, b := find(pair[0]), find(pair[1])
+				if a != b {
+					parent[b] = a
+				}
+				for _, i := range pair {
+					if current, seen := linkScores[i]; !seen || score < current {
+						linkScores[i] = score
+					}
+				}
+			}
+		}
+	}
+
+	byRoot := map[int]*duplicateGroup{}
+	for i := range movies {
+		if _, linked := linkScores[i]; !linked {
+			continue
+		}
+		root := find(i)
+		group, ok := byRoot[root]
+		if !ok {
+			group = &duplicateGroup{score: 1}
+			byRoot[root] = group
+		}
+		group.members = append(group.members, i)
+		if linkScores[i] < group.score {
+			group.score = linkScores[i]
+		}
+	}
+
+	groups := make([]duplicateGroup, 0, len(byRoot))
+	for _, group := range byRoot {
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].score != groups[j].score {
+			return groups[i].score > groups[j].score
+		}
+		return len(groups[i].members) > len(groups[j].members)
+	})
+	return groups
+}
+
+// duplicateScore rates how likely two movies are the same film, from title
+// similarity, release year and shared cast. Missing years or cast count as
+// neutral. Different IMDb IDs or release years more than a year apart
+// always mean different films.
+func duplicateScore(a, b *duplicateCandidate) (float64, bool) {
+	if a.movie.IMDbID != "" && b.movie.IMDbID != "" {
+		if a.movie.IMDbID != b.movie.IMDbID {
+			return 0, false
+		}
+		return 1, true
+	}
+
+	titleScore := titleSimilarity(a.title, b.title)
+	if titleScore < minDuplicateTitleSimilarity {
+		return 0, false
+	}
+
+	yearScore := 0.5
+	if a.movie.Year != 0 && b.movie.Year != 0 {
+		switch diff := a.movie.Year - b.movie.Year; {
+		case diff == 0:
+			yearScore = 1
+		case diff == 1 || diff == -1:
+			yearScore = 0.5
+		default:
+			return 0, false
+		}
+	}
+
+	castScore := 0.5
+	if len(a.cast) > 0 && len(b.cast) > 0 {
+		shared := 0
+		for name := range a.cast {
+			if _, ok := b.cast[name]; ok {
+				shared++
+			}
+		}
+		castScore = float64(shared) / float64(len(a.cast)+len(b.cast)-shared)
+		if shared > 0 && castScore < 0.5 {
+			castScore = 0.5
+		}
+	}
+
+	score := 0.6*titleScore + 0.2*yearScore + 0.2*castScore
+	return score, score >= minDuplicateScore
+}
+
+// normalizeTitle lowercases a title, strips accents and punctuation and
+// drops a leading article.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case r == '&':
+			b.WriteString(" and ")
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	if len(words) > 1 && leadingArticles[words[0]] {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// titleSimilarity is 1 minus the edit distance between two titles relative
+// to the longer one.
+func titleSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance is the Levenshtein distance between two strings.
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0:0]
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := []struct{ title, want string }{
+		{title: "The Matrix", want: "matrix"},
+		{title: "  Heat  ", want: "heat"},
+		{title: "The", want: "the"},
+		{title: "Amélie", want: "amelie"},
+		{title: "Fast & Furious", want: "fast and furious"},
+		{title: "Mission: Impossible – Fallout", want: "mission impossible fallout"},
+		{title: "A Quiet Place Part II", want: "quiet place part ii"},
+		{title: "Blade Runner 2049", want: "blade runner 2049"},
+	}
+	for _, tt := range tests {
+		if got := normalizeTitle(tt.title); got != tt.want {
+			t.Errorf("normalizeTitle(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{a: "heat", b: "heat", want: 1},
+		{a: "", b: "", want: 1},
+		{a: "gladiator", b: "gladiatr", want: 1 - 1.0/9},
+		{a: "kitten", b: "sitting", want: 1 - 3.0/7},
+		{a: "heat", b: "", want: 0},
+		{a: "amelie", b: "amélie", want: 1 - 1.0/6},
+	}
+	for _, tt := range tests {
+		got := titleSimilarity(tt.a, tt.b)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("titleSimilarity(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+		if reverse := titleSimilarity(tt.b, tt.a); reverse != got {
+			t.Errorf("titleSimilarity(%q, %q) = %v, not symmetric with %v", tt.b, tt.a, reverse, got)
+		}
+	}
+}
+
+func TestDuplicateScore(t *testing.T) {
+	candidate := func(movie domain.Movie) *duplicateCandidate {
+		return &duplicateCandidate{movie: movie, title: normalizeTitle(movie.Title), cast: nameTerms(movie.Actors)}
+	}
+
+	tests := []struct {
+		name  string
+		a, b  domain.Movie
+		score float64 // 0 when not a duplicate
+	}{
+		{
+			name:  "matching IMDb IDs settle it",
+			a:     domain.Movie{Title: "Heat", IMDbID: "tt0113277"},
+			b:     domain.Movie{Title: "Heat (Director's Cut)", IMDbID: "tt0113277"},
+			score: 1,
+		},
+		{
+			name: "different IMDb IDs settle it",
+			a:    domain.Movie{Title: "Heat", Year: 1995, IMDbID: "tt0113277"},
+			b:    domain.Movie{Title: "Heat", Year: 1995, IMDbID: "tt0090003"},
+		},
+		{
+			name:  "same title, year and cast",
+			a:     domain.Movie{Title: "The Matrix", Year: 1999, Actors: []string{"Keanu Reeves"}},
+			b:     domain.Movie{Title: "Matrix", Year: 1999, Actors: []string{"keanu reeves"}},
+			score: 1,
+		},
+		{
+			name:  "a missing cast is neutral",
+			a:     domain.Movie{Title: "The Matrix", Year: 1999, Actors: []string{"Keanu Reeves"}},
+			b:     domain.Movie{Title: "Matrix", Year: 1999},
+			score: 0.9,
+		},
+		{
+			name:  "a typo in the title",
+			a:     domain.Movie{Title: "Gladiator", Year: 2000},
+			b:     domain.Movie{Title: "Gladiatr", Year: 2000},
+			score: 0.6*(1-1.0/9) + 0.2 + 0.1,
+		},
+		{
+			name:  "one shared actor counts for half",
+			a:     domain.Movie{Title: "Heat", Year: 1995, Actors: []string{"Al Pacino", "Robert De Niro", "Val Kilmer"}},
+			b:     domain.Movie{Title: "Heat", Year: 1995, Actors: []string{"Al Pacino", "Jon Voight", "Tom Sizemore"}},
+			score: 0.6 + 0.2 + 0.1,
+		},
+		{
+			name: "years more than one apart",
+			a:    domain.Movie{Title: "Heat", Year: 1995},
+			b:    domain.Movie{Title: "Heat", Year: 1986},
+		},
+		{
+			name: "sequels",
+			a:    domain.Movie{Title: "Terminator 2", Year: 1991, Actors: []string{"Arnold Schwarzenegger"}},
+			b:    domain.Movie{Title: "Terminator 3", Year: 2003, Actors: []string{"Arnold Schwarzenegger"}},
+		},
+		{
+			name: "no cast in common and a year apart",
+			a:    domain.Movie{Title: "Heat", Year: 1995, Actors: []string{"Al Pacino"}},
+			b:    domain.Movie{Title: "Heat", Year: 1996, Actors: []string{"Don Johnson"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := duplicateScore(candidate(tt.a), candidate(tt.b))
+			if ok != (tt.score > 0) {
+				t.Fatalf("duplicate = %v (score %v), want %v", ok, score, tt.score > 0)
+			}
+			if ok && math.Abs(score-tt.score) > 1e-9 {
+				t.Errorf("score = %v, want %v", score, tt.score)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateGroups(t *testing.T) {
+	movies := []domain.Movie{
+		0: {Title: "The Matrix", Year: 1999, Actors: []string{"Keanu Reeves"}},
+		1: {Title: "Matrix", Year: 1999},
+		2: {Title: "Gladiator", Year: 2000, Actors: []string{"Russell Crowe"}},
+		3: {Title: "Gladiatr", Year: 2000},
+		4: {Title: "Heat", Year: 1995},
+		5: {Title: "Heat", Year: 1986},
+		6: {Title: "Gladiator", Year: 2000, Actors: []string{"Russell Crowe"}},
+		7: {Title: "Ronin", Year: 1998},
+	}
+
+	groups := findDuplicateGroups(movies)
+
+	// The Gladiator group has a perfect pair, but is only as strong as its
+	// typo; the Matrix pair outranks it
+	want := []duplicateGroup{
+		{members: []int{0, 1}, score: 0.9},
+		{members: []int{2, 3, 6}, score: 0.6*(1-1.0/9) + 0.2 + 0.1},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups %v, want %d", len(groups), groups, len(want))
+	}
+	for i := range want {
+		if !sameOrder(groups[i].members, want[i].members) || math.Abs(groups[i].score-want[i].score) > 1e-9 {
+			t.Errorf("group %d = %v at %v, want %v at %v", i, groups[i].members, groups[i].score, want[i].members, want[i].score)
+		}
+	}
+
+	if groups := findDuplicateGroups(nil); len(groups) != 0 {
+		t.Errorf("an empty catalogue has %d groups", len(groups))
+	}
+}
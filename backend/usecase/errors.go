@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// lookupError reports a failed lookup by ID. A malformed ID is invalid and
+// a missing record is reported as missing, usually a NotFound error;
+// anything else, such as the database being unreachable, is returned as
+// is.
+func lookupError(err error, missing *domain.Error) error {
+	switch {
+	case errors.Is(err, primitive.ErrInvalidHex):
+		return domain.Validation(domain.CodeInvalidID, "Invalid ID", domain.ErrorDetails(err)...)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return missing
+	}
+	return err
+}
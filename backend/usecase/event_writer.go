@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// eventBufferSize is how many events can wait to be counted before new
+	// ones are dropped.
+	eventBufferSize = 4096
+
+	// maxPendingCounts flushes early once this many distinct counters are
+	// waiting to be written.
+	maxPendingCounts = 1000
+)
+
+// EventWriter records movie engagement for the trending scores. Record
+// never blocks the request: events are counted in memory and written in
+// batches by Run. Counting is best-effort, so events can be dropped when
+// the buffer is full or lost if the process stops between flushes.
+type EventWriter interface {
+	Record(movieID primitive.ObjectID, eventType string)
+	Run(ctx context.Context)
+}
+
+type movieEvent struct {
+	movieID   primitive.ObjectID
+	eventType string
+	at        time.Time
+}
+
+type eventKey struct {
+	movieID   primitive.ObjectID
+	eventType string
+	hour      time.Time
+}
+
+type bufferedEventWriter struct {
+	trendingRepo  repository.TrendingRepository
+	flushInterval time.Duration
+	events        chan movieEvent
+	dropped       atomic.Int64
+}
+
+func NewEventWriter(trendingRepo repository.TrendingRepository, flushInterval time.Duration) EventWriter {
+	return &bufferedEventWriter{
+		trendingRepo:  trendingRepo,
+		flushInterval: flushInterval,
+		events:        make(chan movieEvent, eventBufferSize),
+	}
+}
+
+func (w *bufferedEventWriter) Record(movieID primitive.ObjectID, eventType string) {
+	select {
+	case w.events <- movieEvent{movieID: movieID, eventType: eventType, at: time.Now()}:
+	default:
+		// Counted rather than logged, as a full buffer means a burst
+		w.dropped.Add(1)
+	}
+}
+
+// Run folds events into hourly counters and writes them every flush
+// interval, or sooner when many are pending. It flushes once more and
+// returns when ctx is cancelled.
+func (w *bufferedEventWriter) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	pending := map[eventKey]int64{}
+	for {
+		select {
+		case event := <-w.events:
+			key := eventKey{movieID: event.movieID, eventType: event.eventType, hour: event.at.UTC().Truncate(time.Hour)}
+			pending[key]++
+			if len(pending) >= maxPendingCounts {
+				pending = w.flush(ctx, pending)
+			}
+		case <-ticker.C:
+			pending = w.flush(ctx, pending)
+		case <-ctx.Done():
+			// The parent context is gone, so give the last write its own
+			w.flush(context.Background(), pending)
+			return
+		}
+	}
+}
+
+// flush writes the pending counts and returns an empty map to collect the
+// next batch in. It also reports how many events were dropped since the
+// last flush.
+func (w *bufferedEventWriter) flush(ctx context.Context, pending map[eventKey]int64) map[eventKey]int64 {
+	if dropped := w.dropped.Swap(0); dropped > 0 {
+		log.Printf("event buffer full, dropped %d movie events", dropped)
+	}
+	if len(pending) == 0 {
+		return pending
+	}
+
+	counts := make([]domain.MovieEventCount, 0, len(pending))
+	for key, count := range pending {
+		counts = append(counts, domain.MovieEventCount{
+			MovieID: key.movieID,
+			Type:    key.eventType,
+			Hour:    key.hour,
+			Count:   count,
+		})
+	}
+
+	if err := w.trendingRepo.AddEventCounts(ctx, counts); err != nil {
+		log.Printf("failed to write %d movie event counts: %v", len(counts), err)
+	}
+	return map[eventKey]int64{}
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportEncoder writes export records as they arrive. Records are grouped
+// in sections ("movies", "reviews", ...) which are opened one at a time.
+type exportEncoder interface {
+	Begin(section string) error
+	Encode(record interface{}) error
+	End() error
+	Close() error
+}
+
+func newExportEncoder(req *domain.ExportRequest, w io.Writer) exportEncoder {
+	switch {
+	case req.Format == domain.ExportFormatJSON:
+		return &jsonExportEncoder{w: w}
+	case req.Format == domain.ExportFormatNDJSON:
+		return &ndjsonExportEncoder{enc: json.NewEncoder(w)}
+	case req.Zipped():
+		return &csvExportEncoder{zw: zip.NewWriter(w)}
+	default:
+		return &csvExportEncoder{w: w}
+	}
+}
+
+// ndjsonExportEncoder writes one {"type": section, "data": record} object
+// per line.
+type ndjsonExportEncoder struct {
+	enc     *json.Encoder
+	section string
+}
+
+func (e *ndjsonExportEncoder) Begin(section string) error {
+	e.section = section
+	return nil
+}
+
+func (e *ndjsonExportEncoder) Encode(record interface{}) error {
+	return e.enc.Encode(struct {
+		Type string      `json:"type"`
+		Data interface{} `json:"data"`
+	}{e.section, record})
+}
+
+func (e *ndjsonExportEncoder) End() error   { return nil }
+func (e *ndjsonExportEncoder) Close() error { return nil }
+
+// jsonExportEncoder writes a single object holding an array per section,
+// encoding each record as soon as it is read.
+type jsonExportEncoder struct {
+	w        io.Writer
+	sections int
+	records  int
+}
+
+func (e *jsonExportEncoder) Begin(section string) error {
+	prefix := ","
+	if e.sections == 0 {
+		prefix = "{"
+	}
+	e.sections++
+	e.records = 0
+
+	name, _ := json.Marshal(section)
+	_, err := io.WriteString(e.w, prefix+string(name)+":[")
+	return err
+}
+
+func (e *jsonExportEncoder) Encode(record interface{}) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if e.records > 0 {
+		data = append([]byte{','}, data...)
+	}
+	e.records++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportEncoder) End() error {
+	_, err := io.WriteString(e.w, "]")
+	return err
+}
+
+func (e *jsonExportEncoder) Close() error {
+	if e.sections == 0 {
+		_, err := io.WriteString(e.w, "{}")
+		return err
+	}
+	_, err := io.WriteString(e.w, "}")
+	return err
+}
+
+// csvExportEncoder writes a ZIP archive with one CSV file per section, or
+// without an archive writes its only section to w as plain CSV.
+type csvExportEncoder struct {
+	w       io.Writer
+	zw      *zip.Writer
+	csv     *csv.Writer
+	section string
+}
+
+func (e *csvExportEncoder) Begin(section string) error {
+	file := e.w
+	if e.zw != nil {
+		var err error
+		if file, err = e.zw.Create(section + ".csv"); err != nil {
+			return err
+		}
+	}
+	e.section = section
+	e.csv = csv.NewWriter(file)
+	return e.csv.Write(exportCSVHeaders[section])
+}
+
+func (e *csvExportEncoder) Encode(record interface{}) error {
+	for _, row := range exportCSVRows(record) {
+		if err := e.csv.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *csvExportEncoder) End() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+func (e *csvExportEncoder) Close() error {
+	if e.zw == nil {
+		return nil
+	}
+	return e.zw.Close()
+}
+
+// exportCSVHeaders name columns after the JSON fields. The importer reads
+// movies.csv back for the details POST /movies takes, such as title, year
+// and cast; it ignores the ID, poster, collection and counters, so those
+// do not survive a round trip.
+var exportCSVHeaders = map[string][]string{
+	domain.ExportMovies:  {"id", "title", "year", "imdbId", "description", "genres", "actors", "crew", "poster", "trailer", "collectionId", "averageRating", "ratingCount", "likeCount"},
+	domain.ExportReviews: {"id", "movieId", "movieTitle", "rating", "text", "spoiler", "createdAt", "updatedAt"},
+	domain.ExportLists:   {"listId", "name", "description", "visibility", "position", "movieId", "note", "addedAt"},
+	domain.ExportDiary:   {"id", "watchedOn", "movieId", "movieTitle", "rewatch", "rating"},
+}
+
+// exportCSVRows flattens a record into CSV rows. Lists produce a row per
+// entry, or a single row with empty entry columns when they are empty.
+func exportCSVRows(record interface{}) [][]string {
+	switch r := record.(type) {
+	case *domain.Movie:
+		return [][]string{{
+			r.ID.Hex(), r.Title, formatYear(r.Year), r.IMDbID, r.Description,
+			joinValues(r.Genres), joinValues(r.Actors), joinValues(r.Crew),
+			r.PosterURL(), r.Trailer, formatObjectID(r.CollectionID),
+			strconv.FormatFloat(r.AverageRating, 'f', 2, 64),
+			strconv.FormatInt(r.RatingCount, 10),
+			strconv.FormatInt(r.LikeCount, 10),
+		}}
+	case *domain.ReviewExport:
+		return [][]string{{
+			r.ID.Hex(), r.MovieID.Hex(), r.MovieTitle,
+			strconv.FormatFloat(r.Rating, 'f', 1, 64), r.Text,
+			strconv.FormatBool(r.Spoiler),
+			r.CreatedAt.UTC().Format(time.RFC3339), r.UpdatedAt.UTC().Format(time.RFC3339),
+		}}
+	case *domain.DiaryEntryExport:
+		rating := ""
+		if r.Rating > 0 {
+			rating = strconv.FormatFloat(r.Rating, 'f', 1, 64)
+		}
+		return [][]string{{
+			r.ID.Hex(), r.WatchedOn.Format(dateLayout), r.MovieID.Hex(), r.MovieTitle,
+			strconv.FormatBool(r.Rewatch), rating,
+		}}
+	case *domain.MovieList:
+		if len(r.Entries) == 0 {
+			return [][]string{{r.ID.Hex(), r.Name, r.Description, r.Visibility, "", "", "", ""}}
+		}
+		rows := make([][]string, 0, len(r.Entries))
+		for i, entry := range r.Entries {
+			rows = append(rows, []string{
+				r.ID.Hex(), r.Name, r.Description, r.Visibility,
+				strconv.Itoa(i + 1), entry.MovieID.Hex(), entry.Note,
+				entry.AddedAt.UTC().Format(time.RFC3339),
+			})
+		}
+		return rows
+	}
+	return nil
+}
+
+// joinValues packs a multi-valued field into one CSV cell the way the
+// importer reads it back.
+func joinValues(values []string) string {
+	return strings.Join(values, importListSeparator)
+}
+
+func formatYear(year int) string {
+	if year == 0 {
+		return ""
+	}
+	return strconv.Itoa(year)
+}
+
+func formatObjectID(id primitive.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
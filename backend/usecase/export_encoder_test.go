@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// exportSection is a section and the records written to it.
+type exportSection struct {
+	name    string
+	records []interface{}
+}
+
+func exportFixture() []exportSection {
+	added := time.Date(2026, 2, 3, 4, 5, 6, 0, time.UTC)
+	return []exportSection{
+		{name: domain.ExportMovies, records: []interface{}{
+			&domain.Movie{ID: primitive.NewObjectID(), Title: "Heat, the film", Year: 1995, Description: "Line one\nline \"two\"", Genres: []string{"Crime", "Thriller"}, Actors: []string{"Al Pacino"}, Trailer: importTrailer, AverageRating: 4.25, RatingCount: 4},
+			&domain.Movie{ID: primitive.NewObjectID(), Title: "Ronin", Description: "Cars.", Genres: []string{"Action"}, Actors: []string{"Robert De Niro"}, Trailer: importTrailer},
+		}},
+		{name: domain.ExportReviews},
+		{name: domain.ExportLists, records: []interface{}{
+			&domain.MovieList{ID: primitive.NewObjectID(), Name: "Empty", Visibility: domain.ListVisibilityPrivate},
+			&domain.MovieList{ID: primitive.NewObjectID(), Name: "Heists", Visibility: domain.ListVisibilityPublic, Entries: []domain.ListEntry{
+				{MovieID: primitive.NewObjectID(), Note: "first", AddedAt: added},
+				{MovieID: primitive.NewObjectID(), AddedAt: added},
+			}},
+		}},
+	}
+}
+
+func writeExport(t *testing.T, req *domain.ExportRequest, sections []exportSection) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := newExportEncoder(req, &buf)
+	for _, section := range sections {
+		if err := enc.Begin(section.name); err != nil {
+			t.Fatal(err)
+		}
+		for _, record := range section.records {
+			if err := enc.Encode(record); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestJSONExportEncoder(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []exportSection
+		counts   map[string]int
+	}{
+		{name: "every section", sections: exportFixture(), counts: map[string]int{"movies": 2, "reviews": 0, "lists": 2}},
+		{name: "no sections", sections: nil, counts: map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := writeExport(t, &domain.ExportRequest{Format: domain.ExportFormatJSON}, tt.sections)
+
+			var doc map[string][]json.RawMessage
+			if err := json.Unmarshal(data, &doc); err != nil {
+				t.Fatalf("export is not a JSON object: %v\n%s", err, data)
+			}
+			if len(doc) != len(tt.counts) {
+				t.Errorf("got %d sections, want %d", len(doc), len(tt.counts))
+			}
+			for section, want := range tt.counts {
+				records, ok := doc[section]
+				if !ok || records == nil || len(records) != want {
+					t.Errorf("%s has %d records (present %v), want an array of %d", section, len(records), ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONExportEncoder(t *testing.T) {
+	data := writeExport(t, &domain.ExportRequest{Format: domain.ExportFormatNDJSON}, exportFixture())
+
+	var types []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var line struct {
+			Type string          `json:"type"`
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		if len(line.Data) == 0 {
+			t.Errorf("%s line has no data", line.Type)
+		}
+		types = append(types, line.Type)
+	}
+	if got := strings.Join(types, ","); got != "movies,movies,lists,lists" {
+		t.Errorf("line types = %s, want movies,movies,lists,lists", got)
+	}
+}
+
+func readCSV(t *testing.T, r io.Reader) [][]string {
+	t.Helper()
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestCSVExportEncoderWritesMoviesAlonePlain(t *testing.T) {
+	sections := exportFixture()[:1]
+	data := writeExport(t, &domain.ExportRequest{Format: domain.ExportFormatCSV}, sections)
+
+	rows := readCSV(t, bytes.NewReader(data))
+	if len(rows) != 3 || strings.Join(rows[0], ",") != strings.Join(exportCSVHeaders[domain.ExportMovies], ",") {
+		t.Fatalf("rows = %q, want the movie header and two movies", rows)
+	}
+
+	// The importer reads the file back into the movies it came from
+	records, err := parseImportFile(domain.ImportFormatCSV, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, record := range records {
+		movie := sections[0].records[i].(*domain.Movie)
+		req, problems := movieRequestFromRecord(record.fields, nil, "user")
+		if len(problems) > 0 {
+			t.Errorf("movie %d does not import: %q", i, problems)
+			continue
+		}
+		if req.Title != movie.Title || req.Year != movie.Year || req.Description != movie.Description ||
+			strings.Join(req.Genres, ",") != strings.Join(movie.Genres, ",") || strings.Join(req.Actors, ",") != strings.Join(movie.Actors, ",") {
+			t.Errorf("movie %d imports as %+v, want %+v", i, req, movie)
+		}
+	}
+}
+
+func TestCSVExportEncoderZipsSections(t *testing.T) {
+	sections := exportFixture()
+	data := writeExport(t, &domain.ExportRequest{Format: domain.ExportFormatCSV, Include: []string{domain.ExportReviews, domain.ExportLists}}, sections)
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string][][]string{}
+	for _, file := range archive.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = readCSV(t, rc)
+		rc.Close()
+	}
+
+	// Header and data rows per file: an empty list still gets a row, and
+	// a list gets one per entry
+	want := map[string]int{"movies.csv": 3, "reviews.csv": 1, "lists.csv": 4}
+	if len(files) != len(want) {
+		t.Errorf("files = %d, want %d", len(files), len(want))
+	}
+	for name, rows := range want {
+		if len(files[name]) != rows {
+			t.Errorf("%s has %d rows, want %d", name, len(files[name]), rows)
+		}
+	}
+
+	lists := files["lists.csv"]
+	if len(lists) == 4 {
+		if lists[1][4] != "" || lists[1][5] != "" {
+			t.Errorf("empty list row = %q, want empty entry columns", lists[1])
+		}
+		if lists[2][4] != "1" || lists[2][6] != "first" || lists[3][4] != "2" || lists[3][7] != "2026-02-03T04:05:06Z" {
+			t.Errorf("list entry rows = %q, %q", lists[2], lists[3])
+		}
+	}
+}
+
+func TestExportCSVRows(t *testing.T) {
+	watched := time.Date(2026, 5, 6, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		record interface{}
+		want   []string
+	}{
+		{
+			name:   "a movie with no year or collection",
+			record: &domain.Movie{Title: "Heat", Genres: []string{"Crime", "Drama"}, AverageRating: 3.456},
+			want:   []string{primitive.NilObjectID.Hex(), "Heat", "", "", "", "Crime|Drama", "", "", "", "", "", "3.46", "0", "0"},
+		},
+		{
+			name:   "an unrated diary entry",
+			record: &domain.DiaryEntryExport{DiaryEntry: domain.DiaryEntry{WatchedOn: watched}, MovieTitle: "Heat"},
+			want:   []string{primitive.NilObjectID.Hex(), "2026-05-06", primitive.NilObjectID.Hex(), "Heat", "false", ""},
+		},
+		{
+			name:   "a rated rewatch",
+			record: &domain.DiaryEntryExport{DiaryEntry: domain.DiaryEntry{WatchedOn: watched, Rewatch: true, Rating: 4.5}, MovieTitle: "Heat"},
+			want:   []string{primitive.NilObjectID.Hex(), "2026-05-06", primitive.NilObjectID.Hex(), "Heat", "true", "4.5"},
+		},
+	}
+
+	for _, tt := range tests {
+		rows := exportCSVRows(tt.record)
+		if len(rows) != 1 || strings.Join(rows[0], ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s: rows = %q, want %q", tt.name, rows, tt.want)
+		}
+	}
+}
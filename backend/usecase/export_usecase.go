@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"slices"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type ExportUsecase interface {
+	ValidateExport(req *domain.ExportRequest) error
+	Export(ctx context.Context, req *domain.ExportRequest, w io.Writer) error
+}
+
+type exportUsecase struct {
+	movieRepo  repository.MovieRepository
+	reviewRepo repository.ReviewRepository
+	listRepo   repository.ListRepository
+	diaryRepo  repository.DiaryRepository
+}
+
+func NewExportUsecase(movieRepo repository.MovieRepository, reviewRepo repository.ReviewRepository, listRepo repository.ListRepository, diaryRepo repository.DiaryRepository) ExportUsecase {
+	return &exportUsecase{
+		movieRepo:  movieRepo,
+		reviewRepo: reviewRepo,
+		listRepo:   listRepo,
+		diaryRepo:  diaryRepo,
+	}
+}
+
+// ValidateExport checks an export request before anything is written, since
+// a failure once streaming has started can no longer change the status. It
+// returns nil when the request is valid.
+func (uc *exportUsecase) ValidateExport(req *domain.ExportRequest) error {
+	NormalizeRequest(req)
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return domain.Validation(domain.CodeInvalidExport, "Invalid export request", ValidationDetails(err)...)
+	}
+	return nil
+}
+
+// Export streams the user's movies, then each included section in a fixed
+// order, to w. Documents are read from the database one at a time, so
+// memory use does not grow with the size of the export.
+func (uc *exportUsecase) Export(ctx context.Context, req *domain.ExportRequest, w io.Writer) error {
+	enc := newExportEncoder(req, w)
+
+	sections := []struct {
+		name   string
+		stream func(emit func(interface{}) error) error
+	}{
+		{domain.ExportMovies, func(emit func(interface{}) error) error {
+			return uc.movieRepo.ForEachByUserID(ctx, req.UserID, func(m *domain.Movie) error { return emit(m) })
+		}},
+		{domain.ExportReviews, func(emit func(interface{}) error) error {
+			return uc.reviewRepo.ForEachByUserID(ctx, req.UserID, func(r *domain.ReviewExport) error { return emit(r) })
+		}},
+		{domain.ExportLists, func(emit func(interface{}) error) error {
+			return uc.listRepo.ForEachByUserID(ctx, req.UserID, func(l *domain.MovieList) error { return emit(l) })
+		}},
+		{domain.ExportDiary, func(emit func(interface{}) error) error {
+			return uc.diaryRepo.ForEachByUserID(ctx, req.UserID, func(d *domain.DiaryEntryExport) error { return emit(d) })
+		}},
+	}
+
+	for _, section := range sections {
+		if section.name != domain.ExportMovies && !slices.Contains(req.Include, section.name) {
+			continue
+		}
+		if err := enc.Begin(section.name); err != nil {
+			return err
+		}
+		if err := section.stream(enc.Encode); err != nil {
+			return err
+		}
+		if err := enc.End(); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
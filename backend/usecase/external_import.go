@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of entries found in exports from other services.
+const (
+	externalRating    = "rating"
+	externalDiary     = "diary"
+	externalWatched   = "watched"
+	externalWatchlist = "watchlist"
+)
+
+// externalItem is one film entry from a Letterboxd or IMDb export.
+type externalItem struct {
+	source  string
+	row     int
+	kind    string
+	title   string
+	year    int
+	imdbID  string
+	genres  []string
+	crew    []string
+	rating  float64
+	date    time.Time
+	rewatch bool
+	err     error
+}
+
+// externalList is a list from a Letterboxd export with its films in order.
+type externalList struct {
+	source      string
+	name        string
+	description string
+	entries     []externalItem
+}
+
+type externalExport struct {
+	items []externalItem
+	lists []externalList
+}
+
+// letterboxdFiles are the files read from a Letterboxd export, in the order
+// they are imported. Diary entries go before watched films so a film with
+// a dated diary entry is not logged again without a date.
+var letterboxdFiles = []struct {
+	name string
+	kind string
+}{
+	{name: "ratings.csv", kind: externalRating},
+	{name: "diary.csv", kind: externalDiary},
+	{name: "watched.csv", kind: externalWatched},
+	{name: "watchlist.csv", kind: externalWatchlist},
+}
+
+// maxExportCSVSize caps how much CSV is extracted from one export ZIP, so
+// a small, highly compressed archive cannot exhaust memory.
+const maxExportCSVSize = 64 << 20
+
+// errExportTooLarge is returned when an export ZIP holds more CSV than
+// maxExportCSVSize.
+var errExportTooLarge = fmt.Errorf("the ZIP holds more than %d MB of CSV", maxExportCSVSize>>20)
+
+// imdbSkippedTypes are IMDb title types that are not films.
+var imdbSkippedTypes = map[string]bool{
+	"tvSeries":       true,
+	"tvMiniSeries":   true,
+	"tvEpisode":      true,
+	"podcastSeries":  true,
+	"podcastEpisode": true,
+	"videoGame":      true,
+}
+
+// parseLetterboxdExport reads the CSV files from a Letterboxd export ZIP.
+func parseLetterboxdExport(data []byte) (*externalExport, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("expected a Letterboxd export ZIP: %w", err)
+	}
+
+	files := map[string]*zip.File{}
+	var listFiles []*zip.File
+	for _, file := range archive.File {
+		name := strings.TrimPrefix(file.Name, "./")
+		if strings.HasPrefix(name, "lists/") && strings.HasSuffix(name, ".csv") {
+			listFiles = append(listFiles, file)
+			continue
+		}
+		files[name] = file
+	}
+
+	export := &externalExport{}
+	found := false
+	remaining := int64(maxExportCSVSize)
+	for _, spec := range letterboxdFiles {
+		file, ok := files[spec.name]
+		if !ok {
+			continue
+		}
+		found = true
+
+		rows, size, err := readZippedCSV(file, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", spec.name, err)
+		}
+		remaining -= size
+		for i, row := range csvRowsToMaps(rows) {
+			export.items = append(export.items, letterboxdItem(spec.name, spec.kind, i+1, row))
+		}
+	}
+
+	sort.Slice(listFiles, func(i, j int) bool { return listFiles[i].Name < listFiles[j].Name })
+	for _, file := range listFiles {
+		found = true
+		rows, size, err := readZippedCSV(file, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file.Name, err)
+		}
+		remaining -= size
+		export.lists = append(export.lists, letterboxdList(file.Name, rows))
+	}
+
+	if !found {
+		return nil, errors.New("no Letterboxd files found in the ZIP")
+	}
+	return export, nil
+}
+
+func letterboxdItem(source, kind string, row int, fields map[string]string) externalItem {
+	item := externalItem{
+		source: source,
+		row:    row,
+		kind:   kind,
+		title:  fields["Name"],
+	}
+
+	var problems []string
+	if item.title == "" {
+		problems = append(problems, "Name is required")
+	}
+	if year, err := externalYear(fields["Year"]); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		item.year = year
+	}
+
+	date := fields["Date"]
+	if kind == externalDiary {
+		date = fields["Watched Date"]
+		item.rewatch = strings.EqualFold(fields["Rewatch"], "Yes")
+	}
+	if parsed, err := externalDate(date); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		item.date = parsed
+	}
+
+	if rating := fields["Rating"]; rating != "" {
+		value, err := strconv.ParseFloat(rating, 64)
+		if err == nil {
+			err = validateRating(value)
+		}
+		if err != nil {
+			problems = append(problems, "invalid rating "+strconv.Quote(rating))
+		} else {
+			item.rating = value
+		}
+	}
+	if kind == externalRating && item.rating == 0 && len(problems) == 0 {
+		problems = append(problems, "Rating is required")
+	}
+
+	if len(problems) > 0 {
+		item.err = errors.New(strings.Join(problems, "; "))
+	}
+	return item
+}
+
+// letterboxdList reads a list file: a header block describing the list,
+// then a table of its films starting at the "Position" header row.
+func letterboxdList(source string, rows [][]string) externalList {
+	list := externalList{source: path.Base(source)}
+
+	for i, row := range rows {
+		if len(row) == 0 {
+			continue
+		}
+		switch row[0] {
+		case "Date":
+			if i+1 < len(rows) {
+				meta := zipRow(row, rows[i+1])
+				list.name = meta["Name"]
+				list.description = meta["Description"]
+			}
+		case "Position":
+			type positioned struct {
+				position int
+				item     externalItem
+			}
+			var entries []positioned
+			for n, fields := range csvRowsToMaps(rows[i:]) {
+				position, err := strconv.Atoi(fields["Position"])
+				if err != nil {
+					position = n + 1
+				}
+				entries = append(entries, positioned{position: position, item: letterboxdItem(list.source, "", n+1, fields)})
+			}
+			sort.SliceStable(entries, func(a, b int) bool {
+				return entries[a].position < entries[b].position
+			})
+			for _, entry := range entries {
+				list.entries = append(list.entries, entry.item)
+			}
+		}
+	}
+
+	if list.name == "" {
+		list.name = strings.TrimSuffix(list.source, ".csv")
+	}
+	return list
+}
+
+// parseIMDbExport reads an IMDb ratings or watchlist CSV export, telling
+// them apart by their columns.
+func parseIMDbExport(data []byte) (*externalExport, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("expected an IMDb CSV export: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the IMDb export is empty")
+	}
+
+	header := map[string]bool{}
+	for _, column := range rows[0] {
+		header[column] = true
+	}
+
+	var source, kind string
+	switch {
+	case header["Const"] && header["Your Rating"]:
+		source, kind = "ratings.csv", externalRating
+	case header["Const"] && header["Position"]:
+		source, kind = "watchlist.csv", externalWatchlist
+	default:
+		return nil, errors.New("expected an IMDb ratings or watchlist export")
+	}
+
+	export := &externalExport{}
+	for i, fields := range csvRowsToMaps(rows) {
+		export.items = append(export.items, imdbItem(source, kind, i+1, fields))
+	}
+	return export, nil
+}
+
+func imdbItem(source, kind string, row int, fields map[string]string) externalItem {
+	item := externalItem{
+		source: source,
+		row:    row,
+		kind:   kind,
+		title:  fields["Title"],
+		imdbID: fields["Const"],
+		genres: splitNames(fields["Genres"]),
+		crew:   splitNames(fields["Directors"]),
+	}
+
+	var problems []string
+	if item.title == "" {
+		problems = append(problems, "Title is required")
+	}
+	if imdbSkippedTypes[fields["Title Type"]] {
+		problems = append(problems, "not a film ("+fields["Title Type"]+")")
+	}
+	if year, err := externalYear(fields["Year"]); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		item.year = year
+	}
+
+	date := fields["Created"]
+	if kind == externalRating {
+		date = fields["Date Rated"]
+
+		// IMDb rates 1-10; halving gives our half-star scale
+		score, err := strconv.Atoi(fields["Your Rating"])
+		if err != nil || score < 1 || score > 10 {
+			problems = append(problems, "invalid rating "+strconv.Quote(fields["Your Rating"]))
+		} else {
+			item.rating = float64(score) / 2
+		}
+	}
+	if parsed, err := externalDate(date); err != nil {
+		problems = append(problems, err.Error())
+	} else {
+		item.date = parsed
+	}
+
+	if len(problems) > 0 {
+		item.err = errors.New(strings.Join(problems, "; "))
+	}
+	return item
+}
+
+// readZippedCSV reads the rows of a CSV file in a ZIP, along with its
+// uncompressed size. It fails with errExportTooLarge if the file is larger
+// than limit, whatever size its header claims.
+func readZippedCSV(file *zip.File, limit int64) ([][]string, int64, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, 0, errExportTooLarge
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if int64(len(data)) > limit {
+		return nil, 0, errExportTooLarge
+	}
+
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	return rows, int64(len(data)), err
+}
+
+// csvRowsToMaps keys each data row by the header row.
+func csvRowsToMaps(rows [][]string) []map[string]string {
+	if len(rows) == 0 {
+		return nil
+	}
+	maps := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		maps = append(maps, zipRow(rows[0], row))
+	}
+	return maps
+}
+
+func zipRow(header, row []string) map[string]string {
+	fields := make(map[string]string, len(header))
+	for i, column := range header {
+		if i < len(row) {
+			fields[strings.TrimSpace(column)] = strings.TrimSpace(row[i])
+		}
+	}
+	return fields
+}
+
+func externalYear(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid year %q", value)
+	}
+	return year, nil
+}
+
+// externalDate parses the date part of a date or timestamp.
+func externalDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if len(value) > len(dateLayout) {
+		value = value[:len(dateLayout)]
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+func splitNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
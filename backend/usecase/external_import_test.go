@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"strings"
+	"testing"
+	"time"
+)
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func onDay(value string) time.Time {
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+func TestParseLetterboxdExport(t *testing.T) {
+	data := zipFiles(t, map[string]string{
+		"watched.csv": "Date,Name,Year,Letterboxd URI\n2024-02-01,Ronin,1998,x\n",
+		"diary.csv":   "Date,Name,Year,Letterboxd URI,Rating,Rewatch,Tags,Watched Date\n2024-03-05,Heat,1995,x,4.5,Yes,,2024-03-04\n",
+		"ratings.csv": "\xef\xbb\xbfDate,Name,Year,Letterboxd URI,Rating\n2024-01-01,Heat,1995,x,4\n2024-01-02,,1995,x,3\n",
+		"lists/b-side.csv": "Letterboxd list export v7\n" +
+			"Date,Name,Tags,URL,Description\n" +
+			"2024-01-01,Crime nights,,x,Best heists\n" +
+			"\n" +
+			"Position,Name,Year,URL,Description\n" +
+			"2,Ronin,1998,x,\n" +
+			"1,Heat,1995,x,\n",
+		"./lists/a-side.csv": "Position,Name,Year,URL,Description\n1,Thief,1981,x,\n",
+		"profile.csv":        "Date Joined,Username\n2020-01-01,someone\n",
+	})
+
+	export, err := parseLetterboxdExport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Items come file by file: ratings, then diary, then watched
+	wantItems := []externalItem{
+		{source: "ratings.csv", row: 1, kind: externalRating, title: "Heat", year: 1995, rating: 4, date: onDay("2024-01-01")},
+		{source: "ratings.csv", row: 2, kind: externalRating},
+		{source: "diary.csv", row: 1, kind: externalDiary, title: "Heat", year: 1995, rating: 4.5, date: onDay("2024-03-04"), rewatch: true},
+		{source: "watched.csv", row: 1, kind: externalWatched, title: "Ronin", year: 1998, date: onDay("2024-02-01")},
+	}
+	if len(export.items) != len(wantItems) {
+		t.Fatalf("got %d items, want %d", len(export.items), len(wantItems))
+	}
+	for i, want := range wantItems {
+		got := export.items[i]
+		if got.source != want.source || got.row != want.row || got.kind != want.kind {
+			t.Errorf("item %d is %s row %d (%s), want %s row %d (%s)", i, got.source, got.row, got.kind, want.source, want.row, want.kind)
+		}
+		if i == 1 {
+			if got.err == nil || !strings.Contains(got.err.Error(), "Name is required") {
+				t.Errorf("item %d error = %v, want Name is required", i, got.err)
+			}
+			continue
+		}
+		if got.err != nil || got.title != want.title || got.year != want.year || got.rating != want.rating || !got.date.Equal(want.date) || got.rewatch != want.rewatch {
+			t.Errorf("item %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	// Lists come in file name order, their films in position order
+	if len(export.lists) != 2 {
+		t.Fatalf("got %d lists, want 2", len(export.lists))
+	}
+	tests := []struct {
+		name, description string
+		titles            []string
+	}{
+		{name: "a-side", titles: []string{"Thief"}},
+		{name: "Crime nights", description: "Best heists", titles: []string{"Heat", "Ronin"}},
+	}
+	for i, want := range tests {
+		list := export.lists[i]
+		var titles []string
+		for _, entry := range list.entries {
+			titles = append(titles, entry.title)
+		}
+		if list.name != want.name || list.description != want.description || strings.Join(titles, ",") != strings.Join(want.titles, ",") {
+			t.Errorf("list %d = %q (%q) with %q, want %q (%q) with %q", i, list.name, list.description, titles, want.name, want.description, want.titles)
+		}
+	}
+}
+
+func TestParseLetterboxdExportRejectsOtherFiles(t *testing.T) {
+	if _, err := parseLetterboxdExport([]byte("Date,Name\n")); err == nil {
+		t.Error("a CSV file was accepted as a ZIP")
+	}
+	if _, err := parseLetterboxdExport(zipFiles(t, map[string]string{"notes.txt": "hello"})); err == nil {
+		t.Error("a ZIP without Letterboxd files was accepted")
+	}
+}
+
+func TestReadZippedCSVLimit(t *testing.T) {
+	content := "Date,Name\n2024-01-02,Alien\n"
+	limit := int64(len(content))
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, body := range map[string]string{"fits.csv": content, "large.csv": content + "2024-01-03,Heat\n"} {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	// An entry whose header understates its size, as in a zip bomb
+	var deflated bytes.Buffer
+	compressor, _ := flate.NewWriter(&deflated, flate.BestCompression)
+	compressor.Write([]byte(content + "x"))
+	compressor.Close()
+	w, err := archive.CreateRaw(&zip.FileHeader{Name: "lying.csv", Method: zip.Deflate, UncompressedSize64: 1, CompressedSize64: uint64(deflated.Len())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write(deflated.Bytes())
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range reader.File {
+		rows, size, err := readZippedCSV(file, limit)
+		switch file.Name {
+		case "fits.csv":
+			if err != nil || len(rows) != 2 || size != limit {
+				t.Errorf("%s = %d rows of %d bytes, %v; want 2 rows of %d bytes", file.Name, len(rows), size, err, limit)
+			}
+		case "large.csv":
+			if err != errExportTooLarge {
+				t.Errorf("%s error = %v, want errExportTooLarge", file.Name, err)
+			}
+		default:
+			if err == nil {
+				t.Errorf("%s was read past its declared size", file.Name)
+			}
+		}
+	}
+}
+
+func TestLetterboxdItem(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		fields  map[string]string
+		rating  float64
+		problem string
+	}{
+		{name: "a rating", kind: externalRating, fields: map[string]string{"Name": "Heat", "Rating": "3.5"}, rating: 3.5},
+		{name: "a rating is required in ratings", kind: externalRating, fields: map[string]string{"Name": "Heat"}, problem: "Rating is required"},
+		{name: "watched films need no rating", kind: externalWatched, fields: map[string]string{"Name": "Heat"}},
+		{name: "ratings off the half-star scale", kind: externalDiary, fields: map[string]string{"Name": "Heat", "Rating": "3.3"}, problem: `invalid rating "3.3"`},
+		{name: "ratings that are not numbers", kind: externalDiary, fields: map[string]string{"Name": "Heat", "Rating": "good"}, problem: `invalid rating "good"`},
+		{name: "a bad year", kind: externalWatched, fields: map[string]string{"Name": "Heat", "Year": "95s"}, problem: `invalid year "95s"`},
+		{name: "a bad date", kind: externalWatched, fields: map[string]string{"Name": "Heat", "Date": "01/02/2024"}, problem: `invalid date "01/02/2024"`},
+		{name: "every problem is reported", kind: externalWatched, fields: map[string]string{"Year": "x"}, problem: `Name is required; invalid year "x"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := letterboxdItem("file.csv", tt.kind, 1, tt.fields)
+			if tt.problem == "" {
+				if item.err != nil || item.rating != tt.rating {
+					t.Errorf("item = rating %v, error %v; want rating %v", item.rating, item.err, tt.rating)
+				}
+				return
+			}
+			if item.err == nil || item.err.Error() != tt.problem {
+				t.Errorf("error = %v, want %q", item.err, tt.problem)
+			}
+		})
+	}
+}
+
+func TestParseIMDbExport(t *testing.T) {
+	ratings := "Const,Your Rating,Date Rated,Title,URL,Title Type,IMDb Rating,Runtime (mins),Year,Genres,Num Votes,Release Date,Directors\n" +
+		"tt0113277,9,2024-01-02,Heat,x,movie,8.3,170,1995,\"Action, Crime, Drama\",1,1995-12-15,Michael Mann\n" +
+		"tt0141842,10,2024-01-03,The Sopranos,x,tvSeries,9.2,55,1999,Crime,1,1999-01-10,\n" +
+		"tt0122690,11,2024-01-04,Ronin,x,movie,7.2,122,1998,Action,1,1998-09-25,John Frankenheimer\n"
+	watchlist := "Position,Const,Created,Modified,Description,Title,URL,Title Type\n" +
+		"1,tt0083190,2024-05-06,2024-05-06,,Thief,x,movie\n"
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+		items   []externalItem
+		errors  []string
+	}{
+		{
+			name: "ratings are halved onto the five-star scale",
+			data: ratings,
+			items: []externalItem{
+				{source: "ratings.csv", kind: externalRating, imdbID: "tt0113277", title: "Heat", year: 1995, rating: 4.5, date: onDay("2024-01-02"), genres: []string{"Action", "Crime", "Drama"}, crew: []string{"Michael Mann"}},
+				{source: "ratings.csv", kind: externalRating},
+				{source: "ratings.csv", kind: externalRating},
+			},
+			errors: []string{"", "not a film (tvSeries)", `invalid rating "11"`},
+		},
+		{
+			name: "a watchlist",
+			data: "\xef\xbb\xbf" + watchlist,
+			items: []externalItem{
+				{source: "watchlist.csv", kind: externalWatchlist, imdbID: "tt0083190", title: "Thief", date: onDay("2024-05-06")},
+			},
+			errors: []string{""},
+		},
+		{name: "other CSV files", data: "Date,Name\n2024-01-01,Heat\n", wantErr: true},
+		{name: "an empty file", data: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export, err := parseIMDbExport([]byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseIMDbExport error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(export.items) != len(tt.items) {
+				t.Fatalf("got %d items, want %d", len(export.items), len(tt.items))
+			}
+			for i, want := range tt.items {
+				got := export.items[i]
+				if got.source != want.source || got.kind != want.kind || got.row != i+1 {
+					t.Errorf("item %d is %s row %d (%s), want %s row %d (%s)", i, got.source, got.row, got.kind, want.source, i+1, want.kind)
+				}
+				if tt.errors[i] != "" {
+					if got.err == nil || got.err.Error() != tt.errors[i] {
+						t.Errorf("item %d error = %v, want %q", i, got.err, tt.errors[i])
+					}
+					continue
+				}
+				if got.err != nil || got.imdbID != want.imdbID || got.title != want.title || got.year != want.year || got.rating != want.rating || !got.date.Equal(want.date) ||
+					strings.Join(got.genres, ",") != strings.Join(want.genres, ",") || strings.Join(got.crew, ",") != strings.Join(want.crew, ",") {
+					t.Errorf("item %d = %+v, want %+v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExternalDate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: ""},
+		{value: "2024-03-04", want: onDay("2024-03-04")},
+		{value: "2024-03-04T22:15:00Z", want: onDay("2024-03-04")},
+		{value: "04/03/2024", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := externalDate(tt.value)
+		if !got.Equal(tt.want) || (err != nil) != tt.wantErr {
+			t.Errorf("externalDate(%q) = %v, %v; want %v, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
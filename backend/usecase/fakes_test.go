@@ -0,0 +1,741 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// The fakes below keep their records in memory. Each embeds the
+// repository interface it stands in for, so a test that reaches a method
+// the fake does not implement panics instead of silently passing.
+
+type fakeMovieRepo struct {
+	repository.MovieRepository
+	movies map[primitive.ObjectID]*domain.Movie
+}
+
+func newFakeMovieRepo(movies ...*domain.Movie) *fakeMovieRepo {
+	repo := &fakeMovieRepo{movies: map[primitive.ObjectID]*domain.Movie{}}
+	for _, movie := range movies {
+		if movie.ID.IsZero() {
+			movie.ID = primitive.NewObjectID()
+		}
+		repo.movies[movie.ID] = movie
+	}
+	return repo
+}
+
+func (r *fakeMovieRepo) GetByID(ctx context.Context, id string) (*domain.Movie, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+	movie, ok := r.movies[objID]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *movie
+	return &found, nil
+}
+
+func (r *fakeMovieRepo) UpdateRating(ctx context.Context, id string, average float64, count int64) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if movie, ok := r.movies[objID]; ok {
+		movie.AverageRating = average
+		movie.RatingCount = count
+	}
+	return nil
+}
+
+type fakeReviewRepo struct {
+	repository.ReviewRepository
+	reviews []*domain.Review
+}
+
+func (r *fakeReviewRepo) Create(ctx context.Context, review *domain.Review) error {
+	for _, existing := range r.reviews {
+		if existing.UserID == review.UserID && existing.MovieID == review.MovieID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	review.ID = primitive.NewObjectID()
+	stored := *review
+	r.reviews = append(r.reviews, &stored)
+	return nil
+}
+
+func (r *fakeReviewRepo) GetByUserAndMovie(ctx context.Context, userID, movieID string) (*domain.Review, error) {
+	for _, review := range r.reviews {
+		if review.UserID.Hex() == userID && review.MovieID.Hex() == movieID {
+			found := *review
+			return &found, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *fakeReviewRepo) Update(ctx context.Context, id string, review *domain.Review) error {
+	for i, existing := range r.reviews {
+		if existing.ID.Hex() == id {
+			stored := *review
+			r.reviews[i] = &stored
+		}
+	}
+	return nil
+}
+
+func (r *fakeReviewRepo) Delete(ctx context.Context, id string) error {
+	for i, review := range r.reviews {
+		if review.ID.Hex() == id {
+			r.reviews = append(r.reviews[:i], r.reviews[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeReviewRepo) AggregateRating(ctx context.Context, movieID string) (float64, int64, error) {
+	var sum float64
+	var count int64
+	for _, review := range r.reviews {
+		if review.MovieID.Hex() == movieID {
+			sum += review.Rating
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, nil
+	}
+	return sum / float64(count), count, nil
+}
+
+type nopActivities struct{}
+
+func (nopActivities) Record(activityType, userID string, movieID primitive.ObjectID, refID *primitive.ObjectID) {
+}
+
+func (nopActivities) ForgetMovie(movieID string) {}
+
+type nopEvents struct{}
+
+func (nopEvents) Record(movieID primitive.ObjectID, eventType string) {}
+
+func (nopEvents) Run(ctx context.Context) {}
+
+// errorCode is the code of a *domain.Error, or of a *domain.CodedError,
+// anywhere in err's chain.
+func errorCode(err error) string {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	var coded *domain.CodedError
+	if errors.As(err, &coded) {
+		return coded.Detail.Code
+	}
+	return ""
+}
+
+func (r *fakeMovieRepo) GetByIDs(ctx context.Context, ids []string) ([]domain.Movie, error) {
+	var movies []domain.Movie
+	for _, id := range ids {
+		if movie, err := r.GetByID(ctx, id); err == nil {
+			movies = append(movies, *movie)
+		}
+	}
+	return movies, nil
+}
+
+// fakeListRepo holds lists the way the list repository stores them. meddle,
+// when set, runs before each conditional write, to stand in for another
+// request changing the list after it was read.
+type fakeListRepo struct {
+	repository.ListRepository
+	lists  map[string]*domain.MovieList
+	meddle func(list *domain.MovieList)
+}
+
+func newFakeListRepo(lists ...*domain.MovieList) *fakeListRepo {
+	repo := &fakeListRepo{lists: map[string]*domain.MovieList{}}
+	for _, list := range lists {
+		if list.ID.IsZero() {
+			list.ID = primitive.NewObjectID()
+		}
+		repo.lists[list.ID.Hex()] = list
+	}
+	return repo
+}
+
+func (r *fakeListRepo) GetByID(ctx context.Context, id string) (*domain.MovieList, error) {
+	list, ok := r.lists[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *list
+	found.Entries = append([]domain.ListEntry(nil), list.Entries...)
+	return &found, nil
+}
+
+func (r *fakeListRepo) AddEntries(ctx context.Context, id string, entries []domain.ListEntry) (bool, error) {
+	list := r.lists[id]
+	if r.meddle != nil {
+		r.meddle(list)
+	}
+	for _, entry := range list.Entries {
+		for _, added := range entries {
+			if entry.MovieID == added.MovieID {
+				return false, nil
+			}
+		}
+	}
+	list.Entries = append(list.Entries, entries...)
+	return true, nil
+}
+
+func (r *fakeListRepo) ReplaceEntries(ctx context.Context, id string, previous, entries []domain.ListEntry) (bool, error) {
+	list := r.lists[id]
+	if r.meddle != nil {
+		r.meddle(list)
+	}
+	if len(list.Entries) != len(previous) {
+		return false, nil
+	}
+	for i := range previous {
+		if list.Entries[i] != previous[i] {
+			return false, nil
+		}
+	}
+	list.Entries = entries
+	return true, nil
+}
+
+type fakeCollectionRepo struct {
+	repository.CollectionRepository
+	collections map[string]*domain.Collection
+}
+
+func newFakeCollectionRepo(collections ...*domain.Collection) *fakeCollectionRepo {
+	repo := &fakeCollectionRepo{collections: map[string]*domain.Collection{}}
+	for _, collection := range collections {
+		if collection.ID.IsZero() {
+			collection.ID = primitive.NewObjectID()
+		}
+		repo.collections[collection.ID.Hex()] = collection
+	}
+	return repo
+}
+
+func (r *fakeCollectionRepo) GetByID(ctx context.Context, id string) (*domain.Collection, error) {
+	collection, ok := r.collections[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *collection
+	return &found, nil
+}
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users map[string]*domain.User
+}
+
+func newFakeUserRepo(users ...*domain.User) *fakeUserRepo {
+	repo := &fakeUserRepo{users: map[string]*domain.User{}}
+	for _, user := range users {
+		if user.ID.IsZero() {
+			user.ID = primitive.NewObjectID()
+		}
+		repo.users[user.ID.Hex()] = user
+	}
+	return repo
+}
+
+func (r *fakeUserRepo) FindByID(ctx context.Context, id string) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *user
+	return &found, nil
+}
+
+// fakeCommentRepo keeps comments in the order they were created, which is
+// also the order of their IDs.
+type fakeCommentRepo struct {
+	repository.CommentRepository
+	comments []*domain.Comment
+}
+
+func (r *fakeCommentRepo) byID(id string) *domain.Comment {
+	for _, comment := range r.comments {
+		if comment.ID.Hex() == id {
+			return comment
+		}
+	}
+	return nil
+}
+
+func (r *fakeCommentRepo) Create(ctx context.Context, comment *domain.Comment) error {
+	comment.ID = primitive.NewObjectID()
+	stored := *comment
+	r.comments = append(r.comments, &stored)
+	return nil
+}
+
+func (r *fakeCommentRepo) GetByID(ctx context.Context, id string) (*domain.Comment, error) {
+	comment := r.byID(id)
+	if comment == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	found := *comment
+	return &found, nil
+}
+
+func (r *fakeCommentRepo) Tombstone(ctx context.Context, id, deletedBy string) error {
+	if comment := r.byID(id); comment != nil {
+		comment.Body = ""
+		comment.Deleted = true
+		comment.DeletedBy = deletedBy
+		comment.Flagged = false
+		comment.FlagReason = ""
+	}
+	return nil
+}
+
+func (r *fakeCommentRepo) Delete(ctx context.Context, id string) error {
+	for i, comment := range r.comments {
+		if comment.ID.Hex() == id {
+			r.comments = append(r.comments[:i], r.comments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *fakeCommentRepo) HasReplies(ctx context.Context, id string) (bool, error) {
+	for _, comment := range r.comments {
+		if comment.ParentID != nil && comment.ParentID.Hex() == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeCommentRepo) IncrementReplies(ctx context.Context, threadID string, delta int) error {
+	if comment := r.byID(threadID); comment != nil {
+		comment.ReplyCount += int64(delta)
+	}
+	return nil
+}
+
+func (r *fakeCommentRepo) GetThreads(ctx context.Context, movieID, cursor string, limit int) ([]domain.Comment, error) {
+	var threads []domain.Comment
+	for i := len(r.comments) - 1; i >= 0 && len(threads) < limit; i-- {
+		comment := r.comments[i]
+		if comment.MovieID.Hex() != movieID || comment.ThreadID != nil {
+			continue
+		}
+		if cursor != "" && comment.ID.Hex() >= cursor {
+			continue
+		}
+		threads = append(threads, *comment)
+	}
+	return threads, nil
+}
+
+func (r *fakeCommentRepo) GetReplies(ctx context.Context, threadID, cursor string, limit int) ([]domain.Comment, error) {
+	var replies []domain.Comment
+	for _, comment := range r.comments {
+		if len(replies) == limit {
+			break
+		}
+		if comment.ThreadID == nil || comment.ThreadID.Hex() != threadID {
+			continue
+		}
+		if cursor != "" && comment.ID.Hex() <= cursor {
+			continue
+		}
+		replies = append(replies, *comment)
+	}
+	return replies, nil
+}
+
+type fakeRatingModelRepo struct {
+	repository.RatingModelRepository
+	rows []domain.RatingNeighbours
+}
+
+func (r *fakeRatingModelRepo) GetByMovieIDs(ctx context.Context, movieIDs []primitive.ObjectID) ([]domain.RatingNeighbours, error) {
+	var rows []domain.RatingNeighbours
+	for _, row := range r.rows {
+		for _, id := range movieIDs {
+			if row.MovieID == id {
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// fakeTrendingRepo stores lists as given and records what it was asked
+// to score and count; ScoreMovies returns scored.
+type fakeTrendingRepo struct {
+	repository.TrendingRepository
+	lists    map[string]*domain.TrendingList
+	scored   []domain.TrendingMovie
+	halfLife map[time.Duration]time.Duration // window length to half-life asked for
+	counts   []domain.MovieEventCount
+}
+
+func (r *fakeTrendingRepo) AddEventCounts(ctx context.Context, counts []domain.MovieEventCount) error {
+	r.counts = append(r.counts, counts...)
+	return nil
+}
+
+func (r *fakeTrendingRepo) ScoreMovies(ctx context.Context, since, now time.Time, halfLife time.Duration, weights map[string]float64, limit int) ([]domain.TrendingMovie, error) {
+	if r.halfLife == nil {
+		r.halfLife = map[time.Duration]time.Duration{}
+	}
+	r.halfLife[now.Sub(since)] = halfLife
+	return r.scored, nil
+}
+
+func (r *fakeTrendingRepo) SaveList(ctx context.Context, list *domain.TrendingList) error {
+	if r.lists == nil {
+		r.lists = map[string]*domain.TrendingList{}
+	}
+	r.lists[list.Window] = list
+	return nil
+}
+
+func (r *fakeTrendingRepo) GetList(ctx context.Context, window string) (*domain.TrendingList, error) {
+	list, ok := r.lists[window]
+	if !ok {
+		return nil, mongo.ErrNoDocuments
+	}
+	return list, nil
+}
+
+func (r *fakeReviewRepo) GetAllByUserID(ctx context.Context, userID string) ([]domain.Review, error) {
+	var reviews []domain.Review
+	for _, review := range r.reviews {
+		if review.UserID.Hex() == userID {
+			reviews = append(reviews, *review)
+		}
+	}
+	return reviews, nil
+}
+
+type fakeErasureRepo struct {
+	repository.ErasureRepository
+	requests map[primitive.ObjectID]*domain.ErasureRequest
+}
+
+func newFakeErasureRepo(requests ...*domain.ErasureRequest) *fakeErasureRepo {
+	repo := &fakeErasureRepo{requests: map[primitive.ObjectID]*domain.ErasureRequest{}}
+	for _, request := range requests {
+		if request.ID.IsZero() {
+			request.ID = primitive.NewObjectID()
+		}
+		repo.requests[request.ID] = request
+	}
+	return repo
+}
+
+func (r *fakeErasureRepo) GetDue(ctx context.Context, now time.Time) ([]domain.ErasureRequest, error) {
+	var due []domain.ErasureRequest
+	for _, request := range r.requests {
+		if (request.Status == domain.ErasureStatusScheduled || request.Status == domain.ErasureStatusRunning) && !request.ScheduledFor.After(now) {
+			due = append(due, *request)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeErasureRepo) Start(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	request, ok := r.requests[id]
+	if !ok || (request.Status != domain.ErasureStatusScheduled && request.Status != domain.ErasureStatusRunning) {
+		return false, nil
+	}
+	request.Status = domain.ErasureStatusRunning
+	return true, nil
+}
+
+func (r *fakeErasureRepo) RecordReviewedMovies(ctx context.Context, id primitive.ObjectID, movieIDs []primitive.ObjectID) error {
+	request := r.requests[id]
+	for _, movieID := range movieIDs {
+		recorded := false
+		for _, existing := range request.ReviewedMovies {
+			recorded = recorded || existing == movieID
+		}
+		if !recorded {
+			request.ReviewedMovies = append(request.ReviewedMovies, movieID)
+		}
+	}
+	return nil
+}
+
+func (r *fakeErasureRepo) Complete(ctx context.Context, id primitive.ObjectID, at time.Time, erased map[string]int64) error {
+	request := r.requests[id]
+	request.Status = domain.ErasureStatusCompleted
+	request.CompletedAt = &at
+	request.Erased = erased
+	request.ReviewedMovies = nil
+	return nil
+}
+
+type fakeDataExportRepo struct {
+	repository.DataExportRepository
+	exports []domain.DataExport
+}
+
+func (r *fakeDataExportRepo) GetByUserID(ctx context.Context, userID primitive.ObjectID) ([]domain.DataExport, error) {
+	var exports []domain.DataExport
+	for _, export := range r.exports {
+		if export.UserID == userID {
+			exports = append(exports, export)
+		}
+	}
+	return exports, nil
+}
+
+func (r *fakeDataExportRepo) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) (int64, error) {
+	kept := r.exports[:0]
+	for _, export := range r.exports {
+		if export.UserID != userID {
+			kept = append(kept, export)
+		}
+	}
+	deleted := int64(len(r.exports) - len(kept))
+	r.exports = kept
+	return deleted, nil
+}
+
+// fakeUserDataRepo erases a user's reviews from reviews. While failures
+// is above zero, Erase fails after deleting them, to stand in for an
+// erasure cut short.
+type fakeUserDataRepo struct {
+	repository.UserDataRepository
+	reviews  *fakeReviewRepo
+	failures int
+}
+
+func (r *fakeUserDataRepo) Erase(ctx context.Context, userID primitive.ObjectID) (map[string]int64, error) {
+	kept := r.reviews.reviews[:0]
+	for _, review := range r.reviews.reviews {
+		if review.UserID != userID {
+			kept = append(kept, review)
+		}
+	}
+	erased := map[string]int64{"reviews": int64(len(r.reviews.reviews) - len(kept))}
+	r.reviews.reviews = kept
+
+	if r.failures > 0 {
+		r.failures--
+		return nil, errors.New("connection reset")
+	}
+	return erased, nil
+}
+
+type fakeWatchlistRepo struct {
+	repository.WatchlistRepository
+	entries []domain.WatchlistEntry
+}
+
+func (r *fakeWatchlistRepo) Add(ctx context.Context, entry *domain.WatchlistEntry) error {
+	for _, existing := range r.entries {
+		if existing.UserID == entry.UserID && existing.MovieID == entry.MovieID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	entry.ID = primitive.NewObjectID()
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *fakeWatchlistRepo) Remove(ctx context.Context, userID, movieID string) (bool, error) {
+	for i, entry := range r.entries {
+		if entry.UserID.Hex() == userID && entry.MovieID.Hex() == movieID {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type fakeDiaryRepo struct {
+	repository.DiaryRepository
+	entries []domain.DiaryEntry
+}
+
+func (r *fakeDiaryRepo) Create(ctx context.Context, entry *domain.DiaryEntry) error {
+	entry.ID = primitive.NewObjectID()
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *fakeDiaryRepo) GetByID(ctx context.Context, id string) (*domain.DiaryEntry, error) {
+	for _, entry := range r.entries {
+		if entry.ID.Hex() == id {
+			return &entry, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *fakeDiaryRepo) Delete(ctx context.Context, id string) error {
+	for i, entry := range r.entries {
+		if entry.ID.Hex() == id {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeMovieRepo) IncrementLikes(ctx context.Context, id string, delta int) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+	if movie, ok := r.movies[objID]; ok {
+		movie.LikeCount += int64(delta)
+	}
+	return nil
+}
+
+type fakeLikeRepo struct {
+	repository.LikeRepository
+	likes []domain.Like
+}
+
+func (r *fakeLikeRepo) Create(ctx context.Context, like *domain.Like) error {
+	for _, existing := range r.likes {
+		if existing.UserID == like.UserID && existing.MovieID == like.MovieID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	like.ID = primitive.NewObjectID()
+	r.likes = append(r.likes, *like)
+	return nil
+}
+
+func (r *fakeLikeRepo) Delete(ctx context.Context, userID, movieID string) (bool, error) {
+	for i, like := range r.likes {
+		if like.UserID.Hex() == userID && like.MovieID.Hex() == movieID {
+			r.likes = append(r.likes[:i], r.likes[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeReviewRepo) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.Review, error) {
+	var reviews []domain.Review
+	for _, review := range r.reviews {
+		for _, id := range ids {
+			if review.ID == id {
+				reviews = append(reviews, *review)
+			}
+		}
+	}
+	return reviews, nil
+}
+
+func (r *fakeListRepo) GetByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.MovieList, error) {
+	var lists []domain.MovieList
+	for _, id := range ids {
+		if list, ok := r.lists[id.Hex()]; ok {
+			lists = append(lists, *list)
+		}
+	}
+	return lists, nil
+}
+
+func (r *fakeUserRepo) FindByIDs(ctx context.Context, ids []primitive.ObjectID) ([]domain.User, error) {
+	var users []domain.User
+	for _, id := range ids {
+		if user, ok := r.users[id.Hex()]; ok {
+			users = append(users, *user)
+		}
+	}
+	return users, nil
+}
+
+type fakeFollowRepo struct {
+	repository.FollowRepository
+	follows []domain.Follow
+}
+
+func (r *fakeFollowRepo) Create(ctx context.Context, follow *domain.Follow) error {
+	for _, existing := range r.follows {
+		if existing.FollowerID == follow.FollowerID && existing.FolloweeID == follow.FolloweeID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000}}}
+		}
+	}
+	follow.ID = primitive.NewObjectID()
+	r.follows = append(r.follows, *follow)
+	return nil
+}
+
+func (r *fakeFollowRepo) Delete(ctx context.Context, followerID, followeeID string) (bool, error) {
+	for i, follow := range r.follows {
+		if follow.FollowerID.Hex() == followerID && follow.FolloweeID.Hex() == followeeID {
+			r.follows = append(r.follows[:i], r.follows[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeFollowRepo) GetFolloweeIDs(ctx context.Context, userID string) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for _, follow := range r.follows {
+		if follow.FollowerID.Hex() == userID {
+			ids = append(ids, follow.FolloweeID)
+		}
+	}
+	return ids, nil
+}
+
+// fakeActivityRepo keeps activities in the order they were recorded, which
+// is also the order of their IDs.
+type fakeActivityRepo struct {
+	repository.ActivityRepository
+	activities []domain.Activity
+}
+
+func (r *fakeActivityRepo) GetByUserIDs(ctx context.Context, userIDs []primitive.ObjectID, cursor string, limit int) ([]domain.Activity, error) {
+	var found []domain.Activity
+	for i := len(r.activities) - 1; i >= 0 && len(found) < limit; i-- {
+		activity := r.activities[i]
+		if cursor != "" && activity.ID.Hex() >= cursor {
+			continue
+		}
+		for _, userID := range userIDs {
+			if activity.UserID == userID {
+				found = append(found, activity)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeReviewRepo) GetByMovieID(ctx context.Context, movieID string, page, size int) ([]domain.Review, int64, error) {
+	var reviews []domain.Review
+	for _, review := range r.reviews {
+		if review.MovieID.Hex() == movieID {
+			reviews = append(reviews, *review)
+		}
+	}
+	return reviews, int64(len(reviews)), nil
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultFeedPage = 20
+	maxFeedPage     = 100
+)
+
+type FeedUsecase interface {
+	GetFeed(userID, cursor string, limit int) (*domain.CursorResponse, error)
+}
+
+type feedUsecase struct {
+	activityRepo repository.ActivityRepository
+	followRepo   repository.FollowRepository
+	movieRepo    repository.MovieRepository
+	listRepo     repository.ListRepository
+	reviewRepo   repository.ReviewRepository
+	userRepo     repository.UserRepository
+}
+
+func NewFeedUsecase(activityRepo repository.ActivityRepository, followRepo repository.FollowRepository, movieRepo repository.MovieRepository, listRepo repository.ListRepository, reviewRepo repository.ReviewRepository, userRepo repository.UserRepository) FeedUsecase {
+	return &feedUsecase{
+		activityRepo: activityRepo,
+		followRepo:   followRepo,
+		movieRepo:    movieRepo,
+		listRepo:     listRepo,
+		reviewRepo:   reviewRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// GetFeed merges the activity of everyone the user follows, newest first.
+// Items about movies or reviews that no longer exist, or about lists that
+// are not public, are left out, so a page can hold fewer than limit items even when
+// NextCursor says there is more.
+func (uc *feedUsecase) GetFeed(userID, cursor string, limit int) (*domain.CursorResponse, error) {
+	if cursor != "" && !primitive.IsValidObjectID(cursor) {
+		return nil, domain.Validation(domain.CodeInvalidCursor, "Invalid cursor")
+	}
+	if limit <= 0 {
+		limit = defaultFeedPage
+	}
+	if limit > maxFeedPage {
+		limit = maxFeedPage
+	}
+
+	followeeIDs, err := uc.followRepo.GetFolloweeIDs(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(followeeIDs) == 0 {
+		return &domain.CursorResponse{
+			Success: true,
+			Message: "Feed retrieved successfully",
+			Object:  []domain.FeedItem{},
+		}, nil
+	}
+
+	activities, err := uc.activityRepo.GetByUserIDs(context.Background(), followeeIDs, cursor, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	next := ""
+	if len(activities) > limit {
+		activities = activities[:limit]
+		next = activities[limit-1].ID.Hex()
+	}
+
+	items, err := uc.visibleItems(activities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CursorResponse{
+		Success:    true,
+		Message:    "Feed retrieved successfully",
+		Object:     items,
+		NextCursor: next,
+	}, nil
+}
+
+// visibleItems resolves the users, movies, lists and reviews behind each
+// activity and drops the ones the reader should not see.
+func (uc *feedUsecase) visibleItems(activities []domain.Activity) ([]domain.FeedItem, error) {
+	var movieIDs []string
+	var listIDs, reviewIDs, userIDs []primitive.ObjectID
+	for _, activity := range activities {
+		movieIDs = append(movieIDs, activity.MovieID.Hex())
+		userIDs = append(userIDs, activity.UserID)
+		if activity.ListID != nil {
+			listIDs = append(listIDs, *activity.ListID)
+		}
+		if activity.ReviewID != nil {
+			reviewIDs = append(reviewIDs, *activity.ReviewID)
+		}
+	}
+
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	moviesByID := make(map[primitive.ObjectID]domain.Movie, len(movies))
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	publicLists := map[primitive.ObjectID]bool{}
+	if len(listIDs) > 0 {
+		lists, err := uc.listRepo.GetByIDs(context.Background(), listIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, list := range lists {
+			publicLists[list.ID] = list.Visibility == domain.ListVisibilityPublic
+		}
+	}
+
+	liveReviews := map[primitive.ObjectID]bool{}
+	if len(reviewIDs) > 0 {
+		reviews, err := uc.reviewRepo.GetByIDs(context.Background(), reviewIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, review := range reviews {
+			liveReviews[review.ID] = true
+		}
+	}
+
+	users, err := uc.userRepo.FindByIDs(context.Background(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	usersByID := make(map[primitive.ObjectID]domain.User, len(users))
+	for _, user := range users {
+		usersByID[user.ID] = user
+	}
+
+	items := make([]domain.FeedItem, 0, len(activities))
+	for _, activity := range activities {
+		movie, ok := moviesByID[activity.MovieID]
+		if !ok {
+			continue
+		}
+		if activity.ListID != nil && !publicLists[*activity.ListID] {
+			continue
+		}
+		if activity.ReviewID != nil && !liveReviews[*activity.ReviewID] {
+			continue
+		}
+		user := usersByID[activity.UserID]
+
+		items = append(items, domain.FeedItem{
+			Activity: activity,
+			User:     domain.UserSummary{ID: activity.UserID.Hex(), Username: user.Username},
+			Movie:    &movie,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFeedVisibility(t *testing.T) {
+	reader, ada, stranger := &domain.User{Username: "reader"}, &domain.User{Username: "ada"}, &domain.User{Username: "stranger"}
+	users := newFakeUserRepo(reader, ada, stranger)
+	movie := &domain.Movie{Title: "Alien"}
+	movies := newFakeMovieRepo(movie)
+	public := &domain.MovieList{Name: "Favourites", Visibility: domain.ListVisibilityPublic}
+	private := &domain.MovieList{Name: "Guilty pleasures", Visibility: domain.ListVisibilityPrivate}
+	unlisted := &domain.MovieList{Name: "For Bob", Visibility: domain.ListVisibilityUnlisted}
+	lists := newFakeListRepo(public, private, unlisted)
+	review := &domain.Review{ID: primitive.NewObjectID(), UserID: ada.ID, MovieID: movie.ID, Rating: 4}
+	reviews := &fakeReviewRepo{reviews: []*domain.Review{review}}
+	deletedReviewID, deletedMovieID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	activity := func(activityType string, userID, movieID primitive.ObjectID, listID, reviewID *primitive.ObjectID) domain.Activity {
+		return domain.Activity{ID: primitive.NewObjectID(), Type: activityType, UserID: userID, MovieID: movieID, ListID: listID, ReviewID: reviewID}
+	}
+	activities := []domain.Activity{
+		activity(domain.ActivityMovieAdded, ada.ID, movie.ID, nil, nil),
+		activity(domain.ActivityMovieReviewed, ada.ID, movie.ID, nil, &review.ID),
+		activity(domain.ActivityMovieReviewed, ada.ID, movie.ID, nil, &deletedReviewID),
+		activity(domain.ActivityMovieAdded, ada.ID, deletedMovieID, nil, nil),
+		activity(domain.ActivityMovieListed, ada.ID, movie.ID, &public.ID, nil),
+		activity(domain.ActivityMovieListed, ada.ID, movie.ID, &private.ID, nil),
+		activity(domain.ActivityMovieListed, ada.ID, movie.ID, &unlisted.ID, nil),
+		activity(domain.ActivityMovieAdded, stranger.ID, movie.ID, nil, nil),
+	}
+	follows := &fakeFollowRepo{follows: []domain.Follow{{FollowerID: reader.ID, FolloweeID: ada.ID}}}
+	uc := NewFeedUsecase(&fakeActivityRepo{activities: activities}, follows, movies, lists, reviews, users)
+
+	response, err := uc.GetFeed(reader.ID.Hex(), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := response.Object.([]domain.FeedItem)
+	want := []primitive.ObjectID{activities[4].ID, activities[1].ID, activities[0].ID}
+	if len(items) != len(want) {
+		t.Fatalf("feed has %d items, want %d: %v", len(items), len(want), items)
+	}
+	for i, item := range items {
+		if item.Activity.ID != want[i] {
+			t.Errorf("item %d is activity %s, want %s", i, item.Activity.ID.Hex(), want[i].Hex())
+		}
+		if item.User.Username != "ada" || item.Movie == nil || item.Movie.ID != movie.ID {
+			t.Errorf("item %d = %s on %v, want ada on %s", i, item.User.Username, item.Movie, movie.Title)
+		}
+	}
+	if response.NextCursor != "" {
+		t.Errorf("next cursor = %q on the last page", response.NextCursor)
+	}
+
+	// A page cut short by hidden items still points past them
+	response, err = uc.GetFeed(reader.ID.Hex(), "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(response.Object.([]domain.FeedItem)); got != 1 || response.NextCursor != activities[4].ID.Hex() {
+		t.Errorf("first page = %d items, cursor %q; want 1 item, cursor %q", got, response.NextCursor, activities[4].ID.Hex())
+	}
+}
+
+func TestFeedWithoutFollows(t *testing.T) {
+	uc := NewFeedUsecase(&fakeActivityRepo{}, &fakeFollowRepo{}, newFakeMovieRepo(), newFakeListRepo(), &fakeReviewRepo{}, newFakeUserRepo())
+	response, err := uc.GetFeed(primitive.NewObjectID().Hex(), "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := response.Object.([]domain.FeedItem); len(items) != 0 {
+		t.Errorf("feed has %d items, want none", len(items))
+	}
+
+	if _, err := uc.GetFeed(primitive.NewObjectID().Hex(), "yesterday", 0); errorCode(err) != domain.CodeInvalidCursor {
+		t.Errorf("a bad cursor: %v", err)
+	}
+}
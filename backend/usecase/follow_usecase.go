@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FollowUsecase interface {
+	Follow(followeeID, userID string) (*domain.BaseResponse, error)
+	Unfollow(followeeID, userID string) (*domain.BaseResponse, error)
+	GetFollowers(userID string, page, size int) (*domain.PaginatedResponse, error)
+	GetFollowing(userID string, page, size int) (*domain.PaginatedResponse, error)
+}
+
+type followUsecase struct {
+	followRepo repository.FollowRepository
+	userRepo   repository.UserRepository
+}
+
+func NewFollowUsecase(followRepo repository.FollowRepository, userRepo repository.UserRepository) FollowUsecase {
+	return &followUsecase{
+		followRepo: followRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *followUsecase) Follow(followeeID, userID string) (*domain.BaseResponse, error) {
+	if followeeID == userID {
+		return nil, domain.Validation(domain.CodeCannotFollowSelf, "You cannot follow yourself")
+	}
+
+	followerObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	followee, err := uc.userRepo.FindByID(context.Background(), followeeID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeUserNotFound, "User not found"))
+	}
+
+	follow := &domain.Follow{
+		FollowerID: followerObjID,
+		FolloweeID: followee.ID,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := uc.followRepo.Create(context.Background(), follow); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.Conflict(domain.CodeAlreadyFollowing, "You already follow this user")
+		}
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "User followed",
+		Object:  follow,
+	}, nil
+}
+
+func (uc *followUsecase) Unfollow(followeeID, userID string) (*domain.BaseResponse, error) {
+	if !primitive.IsValidObjectID(followeeID) {
+		return nil, domain.NotFound(domain.CodeNotFollowing, "You do not follow this user")
+	}
+
+	removed, err := uc.followRepo.Delete(context.Background(), userID, followeeID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, domain.NotFound(domain.CodeNotFollowing, "You do not follow this user")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "User unfollowed",
+	}, nil
+}
+
+func (uc *followUsecase) GetFollowers(userID string, page, size int) (*domain.PaginatedResponse, error) {
+	if !primitive.IsValidObjectID(userID) {
+		return nil, domain.NotFound(domain.CodeUserNotFound, "User not found")
+	}
+
+	follows, total, err := uc.followRepo.GetFollowers(context.Background(), userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FollowerID)
+	}
+	users, err := uc.userSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Followers retrieved successfully",
+		Object:     users,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+func (uc *followUsecase) GetFollowing(userID string, page, size int) (*domain.PaginatedResponse, error) {
+	if !primitive.IsValidObjectID(userID) {
+		return nil, domain.NotFound(domain.CodeUserNotFound, "User not found")
+	}
+
+	follows, total, err := uc.followRepo.GetFollowing(context.Background(), userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		ids = append(ids, follow.FolloweeID)
+	}
+	users, err := uc.userSummaries(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Following retrieved successfully",
+		Object:     users,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+// userSummaries resolves user IDs to their public profiles, keeping the
+// given order and skipping users that no longer exist.
+func (uc *followUsecase) userSummaries(ids []primitive.ObjectID) ([]domain.UserSummary, error) {
+	users, err := uc.userRepo.FindByIDs(context.Background(), ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]domain.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	summaries := make([]domain.UserSummary, 0, len(ids))
+	for _, id := range ids {
+		if user, ok := byID[id]; ok {
+			summaries = append(summaries, domain.UserSummary{ID: user.ID.Hex(), Username: user.Username})
+		}
+	}
+	return summaries, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFollow(t *testing.T) {
+	ada, bob := &domain.User{Username: "ada"}, &domain.User{Username: "bob"}
+	follows := &fakeFollowRepo{}
+	uc := NewFollowUsecase(follows, newFakeUserRepo(ada, bob))
+
+	tests := []struct {
+		name     string
+		followee string
+		want     string
+	}{
+		{name: "oneself", followee: ada.ID.Hex(), want: domain.CodeCannotFollowSelf},
+		{name: "another user", followee: bob.ID.Hex()},
+		{name: "the same user again", followee: bob.ID.Hex(), want: domain.CodeAlreadyFollowing},
+		{name: "an unknown user", followee: primitive.NewObjectID().Hex(), want: domain.CodeUserNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Follow(tt.followee, ada.ID.Hex())
+			if code := errorCode(err); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+		})
+	}
+	if len(follows.follows) != 1 {
+		t.Errorf("%d follows stored, want 1", len(follows.follows))
+	}
+}
+
+func TestUnfollow(t *testing.T) {
+	ada, bob := &domain.User{Username: "ada"}, &domain.User{Username: "bob"}
+	users := newFakeUserRepo(ada, bob)
+	follows := &fakeFollowRepo{follows: []domain.Follow{{ID: primitive.NewObjectID(), FollowerID: ada.ID, FolloweeID: bob.ID}}}
+	uc := NewFollowUsecase(follows, users)
+
+	// bob does not follow ada, whatever ada does
+	if _, err := uc.Unfollow(ada.ID.Hex(), bob.ID.Hex()); errorCode(err) != domain.CodeNotFollowing {
+		t.Errorf("unfollowing a user not followed: %v", err)
+	}
+	if _, err := uc.Unfollow(bob.ID.Hex(), ada.ID.Hex()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uc.Unfollow(bob.ID.Hex(), ada.ID.Hex()); errorCode(err) != domain.CodeNotFollowing {
+		t.Errorf("unfollowing twice: %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+// importListSeparator separates names in a CSV cell, e.g. "Drama|Crime".
+const importListSeparator = "|"
+
+// importFields are the movie fields an import can fill.
+var importFields = []string{"title", "description", "trailer", "actors", "genres", "crew", "year", "imdbId"}
+
+// importRecord is one row of an import file keyed by column or field name.
+// err is set when the row could not be read at all.
+type importRecord struct {
+	fields map[string]interface{}
+	err    error
+}
+
+// parseImportFile splits an upload into records. It fails only when the
+// file as a whole is unreadable; a bad row is reported on that row.
+func parseImportFile(format string, data []byte) ([]importRecord, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	switch format {
+	case domain.ImportFormatCSV:
+		return parseCSVRecords(data)
+	case domain.ImportFormatJSON:
+		return parseJSONRecords(data)
+	case domain.ImportFormatNDJSON:
+		return parseNDJSONRecords(data)
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+func parseCSVRecords(data []byte) ([]importRecord, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading CSV header: %w", err)
+	}
+
+	var records []importRecord
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				records = append(records, importRecord{err: err})
+				continue
+			}
+			return nil, err
+		}
+
+		fields := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			if i < len(row) {
+				fields[column] = row[i]
+			}
+		}
+		records = append(records, importRecord{fields: fields})
+	}
+	return records, nil
+}
+
+func parseJSONRecords(data []byte) ([]importRecord, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array of movies: %w", err)
+	}
+
+	records := make([]importRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, decodeImportObject(item))
+	}
+	return records, nil
+}
+
+func parseNDJSONRecords(data []byte) ([]importRecord, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var records []importRecord
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		records = append(records, decodeImportObject(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func decodeImportObject(raw []byte) importRecord {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return importRecord{err: fmt.Errorf("expected a JSON object: %w", err)}
+	}
+	return importRecord{fields: fields}
+}
+
+// validateImportMapping rejects mappings for fields a movie does not have.
+func validateImportMapping(mapping map[string]string) error {
+	for field := range mapping {
+		known := false
+		for _, name := range importFields {
+			if field == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return domain.NewCodedError(domain.CodeUnknownField, fmt.Sprintf("unknown field %q in mapping", field), "field", field)
+		}
+	}
+	return nil
+}
+
+// movieRequestFromRecord builds a create request from a record, then
+// normalizes and checks it with ValidateRequest, as POST /movies does.
+func movieRequestFromRecord(fields map[string]interface{}, mapping map[string]string, userID string) (*domain.CreateMovieRequest, []string) {
+	get := func(field string) interface{} {
+		key := field
+		if mapped, ok := mapping[field]; ok {
+			key = mapped
+		}
+		if value, ok := fields[key]; ok {
+			return value
+		}
+		for name, value := range fields {
+			if strings.EqualFold(strings.TrimSpace(name), key) {
+				return value
+			}
+		}
+		return nil
+	}
+
+	req := &domain.CreateMovieRequest{
+		Title:       importString(get("title")),
+		Description: importString(get("description")),
+		Trailer:     importString(get("trailer")),
+		Actors:      importList(get("actors")),
+		Genres:      importList(get("genres")),
+		Crew:        importList(get("crew")),
+		IMDbID:      importString(get("imdbId")),
+		UserID:      userID,
+	}
+
+	var problems []string
+	year, err := importYear(get("year"))
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	req.Year = year
+
+	if req.Trailer != "" {
+		if _, err := domain.ParseTrailerURL(req.Trailer); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	if err := ValidateRequest(req); err != nil {
+		problems = append(problems, validationMessages(err)...)
+	}
+	return req, problems
+}
+
+func importString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+// importList accepts a JSON array or a "|"-separated string.
+func importList(value interface{}) []string {
+	var items []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, importString(item))
+		}
+	case string:
+		items = strings.Split(v, importListSeparator)
+	}
+
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
+
+func importYear(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	case string:
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return 0, nil
+		}
+		if year, err := strconv.Atoi(v); err == nil {
+			return year, nil
+		}
+	}
+	return 0, errors.New("year must be a whole number")
+}
+
+// validationMessages turns the details of a ValidateRequest error into
+// messages naming the JSON field.
+func validationMessages(err error) []string {
+	var details []domain.ErrorDetail
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		details = domainErr.Details
+	}
+	messages := make([]string, 0, len(details))
+	for _, detail := range details {
+		messages = append(messages, detail.Message)
+	}
+	return messages
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+const importTrailer = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+func TestParseImportFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		data    string
+		records int
+		bad     []int // records that could not be read
+		wantErr bool
+	}{
+		{name: "csv", format: domain.ImportFormatCSV, data: "title,year\nHeat,1995\nRonin,1998\n", records: 2},
+		{name: "csv with a byte order mark", format: domain.ImportFormatCSV, data: "\xef\xbb\xbftitle\nHeat\n", records: 1},
+		{name: "csv with a malformed row", format: domain.ImportFormatCSV, data: "title,year\nHe\"at,1995\nRonin,1998\n", records: 2, bad: []int{0}},
+		{name: "csv without a header", format: domain.ImportFormatCSV, data: "", wantErr: true},
+		{name: "json", format: domain.ImportFormatJSON, data: `[{"title":"Heat"},{"title":"Ronin"}]`, records: 2},
+		{name: "json with a row that is not an object", format: domain.ImportFormatJSON, data: `[{"title":"Heat"},"Ronin"]`, records: 2, bad: []int{1}},
+		{name: "json that is not an array", format: domain.ImportFormatJSON, data: `{"title":"Heat"}`, wantErr: true},
+		{name: "ndjson skips blank lines", format: domain.ImportFormatNDJSON, data: "{\"title\":\"Heat\"}\n\n  \n{\"title\":\"Ronin\"}\n", records: 2},
+		{name: "ndjson with a broken line", format: domain.ImportFormatNDJSON, data: "{\"title\":\"Heat\"}\n{\"title\":\n", records: 2, bad: []int{1}},
+		{name: "unknown format", format: "xml", data: "<movies/>", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := parseImportFile(tt.format, []byte(tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseImportFile error = %v, want error %v", err, tt.wantErr)
+			}
+			if len(records) != tt.records {
+				t.Fatalf("got %d records, want %d", len(records), tt.records)
+			}
+			bad := map[int]bool{}
+			for _, i := range tt.bad {
+				bad[i] = true
+			}
+			for i, record := range records {
+				if (record.err != nil) != bad[i] {
+					t.Errorf("record %d error = %v, want error %v", i, record.err, bad[i])
+				}
+				if record.err == nil && record.fields["title"] == nil {
+					t.Errorf("record %d has no title: %v", i, record.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateImportMapping(t *testing.T) {
+	if err := validateImportMapping(map[string]string{"title": "Name", "imdbId": "const"}); err != nil {
+		t.Errorf("known fields: %v", err)
+	}
+	err := validateImportMapping(map[string]string{"title": "Name", "rating": "Stars"})
+	if code := errorCode(err); code != domain.CodeUnknownField {
+		t.Errorf("unknown field code = %q, want %q", code, domain.CodeUnknownField)
+	}
+}
+
+func TestMovieRequestFromRecord(t *testing.T) {
+	valid := func() map[string]interface{} {
+		return map[string]interface{}{
+			"title":       " Heat ",
+			"description": "A heist goes wrong.",
+			"trailer":     importTrailer,
+			"actors":      "Al Pacino| Robert De Niro |",
+			"genres":      []interface{}{"Crime", "Thriller"},
+			"year":        "1995",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		fields   func() map[string]interface{}
+		mapping  map[string]string
+		problems []string // substrings of the expected problems, in order
+		check    func(t *testing.T, req *domain.CreateMovieRequest)
+	}{
+		{
+			name:   "lists and years are read from either source type",
+			fields: valid,
+			check: func(t *testing.T, req *domain.CreateMovieRequest) {
+				if req.Title != "Heat" || req.Year != 1995 || req.UserID != "user" {
+					t.Errorf("title, year, user = %q, %d, %q", req.Title, req.Year, req.UserID)
+				}
+				if strings.Join(req.Actors, ",") != "Al Pacino,Robert De Niro" || strings.Join(req.Genres, ",") != "Crime,Thriller" {
+					t.Errorf("actors, genres = %q, %q", req.Actors, req.Genres)
+				}
+				if req.Crew != nil {
+					t.Errorf("crew = %q, want none", req.Crew)
+				}
+			},
+		},
+		{
+			name: "mapped and differently cased columns are found",
+			fields: func() map[string]interface{} {
+				fields := valid()
+				fields["Name"] = fields["title"]
+				delete(fields, "title")
+				fields[" Year "] = float64(1995)
+				delete(fields, "year")
+				return fields
+			},
+			mapping: map[string]string{"title": "Name"},
+			check: func(t *testing.T, req *domain.CreateMovieRequest) {
+				if req.Title != "Heat" || req.Year != 1995 {
+					t.Errorf("title, year = %q, %d; want Heat, 1995", req.Title, req.Year)
+				}
+			},
+		},
+		{
+			name: "a year that is not a whole number",
+			fields: func() map[string]interface{} {
+				fields := valid()
+				fields["year"] = 1995.5
+				return fields
+			},
+			problems: []string{"year must be a whole number"},
+		},
+		{
+			name: "a trailer from an unsupported host",
+			fields: func() map[string]interface{} {
+				fields := valid()
+				fields["trailer"] = "https://example.com/trailer"
+				return fields
+			},
+			problems: []string{"YouTube or Vimeo"},
+		},
+		{
+			name: "missing required fields fail validation",
+			fields: func() map[string]interface{} {
+				fields := valid()
+				delete(fields, "title")
+				fields["genres"] = ""
+				return fields
+			},
+			problems: []string{"title", "genres"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, problems := movieRequestFromRecord(tt.fields(), tt.mapping, "user")
+			if len(problems) != len(tt.problems) {
+				t.Fatalf("problems = %q, want %d", problems, len(tt.problems))
+			}
+			for i, want := range tt.problems {
+				if !strings.Contains(problems[i], want) {
+					t.Errorf("problem %d = %q, want it to mention %q", i, problems[i], want)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, req)
+			}
+		})
+	}
+}
+
+func TestImportYear(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		want    int
+		wantErr bool
+	}{
+		{value: nil},
+		{value: ""},
+		{value: " 1995 ", want: 1995},
+		{value: float64(2001), want: 2001},
+		{value: 2001.5, wantErr: true},
+		{value: "nineteen", wantErr: true},
+		{value: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := importYear(tt.value)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("importYear(%#v) = %d, %v; want %d, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,589 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// maxImportRowReports caps how many row outcomes a job keeps.
+	maxImportRowReports = 1000
+
+	// importProgressEvery is how many rows are processed between progress saves.
+	importProgressEvery = 50
+)
+
+type ImportUsecase interface {
+	StartImport(req *domain.ImportMoviesRequest) (*domain.BaseResponse, error)
+	GetImportJob(id, userID string) (*domain.BaseResponse, error)
+}
+
+type importUsecase struct {
+	importRepo    repository.ImportJobRepository
+	movieRepo     repository.MovieRepository
+	reviewRepo    repository.ReviewRepository
+	diaryRepo     repository.DiaryRepository
+	watchlistRepo repository.WatchlistRepository
+	listRepo      repository.ListRepository
+	similarity    SimilarityIndexer
+}
+
+func NewImportUsecase(importRepo repository.ImportJobRepository, movieRepo repository.MovieRepository, reviewRepo repository.ReviewRepository, diaryRepo repository.DiaryRepository, watchlistRepo repository.WatchlistRepository, listRepo repository.ListRepository, similarity SimilarityIndexer) ImportUsecase {
+	return &importUsecase{
+		importRepo:    importRepo,
+		movieRepo:     movieRepo,
+		reviewRepo:    reviewRepo,
+		diaryRepo:     diaryRepo,
+		watchlistRepo: watchlistRepo,
+		listRepo:      listRepo,
+		similarity:    similarity,
+	}
+}
+
+// StartImport checks that the file can be read and starts importing it in
+// the background. The returned job can be polled for progress. Letterboxd
+// and IMDb exports are imported into the user's ratings, diary, watchlist
+// and lists as well as the movie collection.
+func (uc *importUsecase) StartImport(req *domain.ImportMoviesRequest) (*domain.BaseResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+	if err := ValidateRequest(req); err != nil {
+		return nil, err
+	}
+
+	switch req.Format {
+	case domain.ImportFormatCSV, domain.ImportFormatJSON, domain.ImportFormatNDJSON:
+	case domain.ImportFormatLetterboxd, domain.ImportFormatIMDb:
+		return uc.startExternalImport(userID, req)
+	default:
+		return nil, domain.Validation(domain.CodeUnsupportedFormat, "Unsupported format", domain.NewErrorDetail(domain.CodeFieldNotOneOf, "format must be csv, json or ndjson", "field", "format", "values", "csv, json, ndjson"))
+	}
+
+	if err := validateImportMapping(req.Mapping); err != nil {
+		return nil, domain.Validation(domain.CodeInvalidMapping, "Invalid mapping", domain.ErrorDetails(err)...)
+	}
+
+	records, err := parseImportFile(req.Format, req.Data)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeFileUnreadable, "Could not read the import file", domain.ErrorDetails(err)...)
+	}
+	if len(records) == 0 {
+		return nil, domain.Validation(domain.CodeImportFileEmpty, "The import file has no rows")
+	}
+
+	job, err := uc.createJob(userID, req, len(records))
+	if err != nil {
+		return nil, err
+	}
+
+	go uc.runImport(*job, records, req.Mapping)
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Import started",
+		Object:  job,
+	}, nil
+}
+
+func (uc *importUsecase) startExternalImport(userID primitive.ObjectID, req *domain.ImportMoviesRequest) (*domain.BaseResponse, error) {
+	var export *externalExport
+	var err error
+	if req.Format == domain.ImportFormatLetterboxd {
+		export, err = parseLetterboxdExport(req.Data)
+	} else {
+		export, err = parseIMDbExport(req.Data)
+	}
+	if err != nil {
+		return nil, domain.Validation(domain.CodeFileUnreadable, "Could not read the import file", domain.ErrorDetails(err)...)
+	}
+
+	total := len(export.items) + len(export.lists)
+	if total == 0 {
+		return nil, domain.Validation(domain.CodeImportFileEmpty, "The import file has no rows")
+	}
+
+	job, err := uc.createJob(userID, req, total)
+	if err != nil {
+		return nil, err
+	}
+
+	go uc.runExternalImport(*job, export)
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Import started",
+		Object:  job,
+	}, nil
+}
+
+func (uc *importUsecase) createJob(userID primitive.ObjectID, req *domain.ImportMoviesRequest, total int) (*domain.ImportJob, error) {
+	job := &domain.ImportJob{
+		UserID:    userID,
+		Format:    req.Format,
+		DryRun:    req.DryRun,
+		Status:    domain.ImportStatusRunning,
+		Total:     total,
+		Rows:      []domain.ImportRowResult{},
+		CreatedAt: time.Now(),
+	}
+	if err := uc.importRepo.Create(context.Background(), job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (uc *importUsecase) GetImportJob(id, userID string) (*domain.BaseResponse, error) {
+	job, err := uc.importRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeImportJobNotFound, "Import job not found"))
+	}
+	if job.UserID.Hex() != userID {
+		return nil, domain.NotFound(domain.CodeImportJobNotFound, "Import job not found")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Import job retrieved successfully",
+		Object:  job,
+	}, nil
+}
+
+// runImport processes every record, saving progress as it goes. It works on
+// its own copy of the job, which nothing else writes to while it runs.
+func (uc *importUsecase) runImport(job domain.ImportJob, records []importRecord, mapping map[string]string) {
+	ctx := context.Background()
+	inFile := map[string]bool{}
+
+	for i, record := range records {
+		result, err := uc.importRow(ctx, &job, i+1, record, mapping, inFile)
+		if err != nil {
+			uc.failJob(&job, result, err)
+			break
+		}
+		uc.recordRow(ctx, &job, result)
+	}
+
+	uc.finishJob(ctx, &job, job.Created > 0)
+}
+
+// recordRow counts a row's outcome and saves progress every few rows.
+func (uc *importUsecase) recordRow(ctx context.Context, job *domain.ImportJob, result domain.ImportRowResult) {
+	switch result.Action {
+	case domain.ImportRowCreate:
+		job.Created++
+	case domain.ImportRowDuplicate:
+		job.Duplicates++
+	case domain.ImportRowInvalid:
+		job.Invalid++
+	}
+	if len(job.Rows) < maxImportRowReports {
+		job.Rows = append(job.Rows, result)
+	} else {
+		job.RowsTruncated = true
+	}
+	job.Processed++
+
+	if job.Processed%importProgressEvery == 0 {
+		if err := uc.importRepo.Save(ctx, job); err != nil {
+			log.Printf("failed to save progress of import job %s: %v", job.ID.Hex(), err)
+		}
+	}
+}
+
+func (uc *importUsecase) failJob(job *domain.ImportJob, result domain.ImportRowResult, err error) {
+	log.Printf("import job %s failed at %s row %d: %v", job.ID.Hex(), result.Source, result.Row, err)
+	job.Status = domain.ImportStatusFailed
+	job.Error = "Internal error at row " + strconv.Itoa(result.Row)
+	if result.Source != "" {
+		job.Error += " of " + result.Source
+	}
+}
+
+// finishJob saves the final state of a job. moviesAdded tells whether the
+// catalogue changed, so similar movies get recomputed.
+func (uc *importUsecase) finishJob(ctx context.Context, job *domain.ImportJob, moviesAdded bool) {
+	if job.Status == domain.ImportStatusRunning {
+		job.Status = domain.ImportStatusCompleted
+	}
+	finished := time.Now()
+	job.FinishedAt = &finished
+	if err := uc.importRepo.Save(ctx, job); err != nil {
+		log.Printf("failed to save import job %s: %v", job.ID.Hex(), err)
+	}
+
+	if !job.DryRun && moviesAdded {
+		uc.similarity.MovieChanged()
+	}
+}
+
+// importRow validates one record and, outside a dry run, creates the movie.
+// A movie already in the collection, or earlier in the same file, with the
+// same title and year is reported as a duplicate and skipped.
+func (uc *importUsecase) importRow(ctx context.Context, job *domain.ImportJob, row int, record importRecord, mapping map[string]string, inFile map[string]bool) (domain.ImportRowResult, error) {
+	if record.err != nil {
+		return domain.ImportRowResult{
+			Row:    row,
+			Action: domain.ImportRowInvalid,
+			Errors: []string{record.err.Error()},
+		}, nil
+	}
+
+	req, problems := movieRequestFromRecord(record.fields, mapping, job.UserID.Hex())
+	result := domain.ImportRowResult{Row: row, Title: req.Title, Year: req.Year}
+	if len(problems) > 0 {
+		result.Action = domain.ImportRowInvalid
+		result.Errors = problems
+		return result, nil
+	}
+
+	key := strings.ToLower(req.Title) + "|" + strconv.Itoa(req.Year)
+	if inFile[key] {
+		result.Action = domain.ImportRowDuplicate
+		result.Errors = []string{"same title and year as an earlier row"}
+		return result, nil
+	}
+	inFile[key] = true
+
+	existing, err := uc.movieRepo.FindByTitleYear(ctx, req.Title, req.Year)
+	if err == nil {
+		result.Action = domain.ImportRowDuplicate
+		result.MovieID = existing.ID.Hex()
+		result.Errors = []string{"already in the collection"}
+		return result, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return result, err
+	}
+
+	result.Action = domain.ImportRowCreate
+	if job.DryRun {
+		return result, nil
+	}
+
+	// The trailer was checked with the rest of the row
+	trailer, _ := domain.ParseTrailerURL(req.Trailer)
+	movie := &domain.Movie{
+		Title:        req.Title,
+		Description:  req.Description,
+		Trailer:      trailer.URL(),
+		TrailerVideo: trailer,
+		Actors:       req.Actors,
+		Genres:       req.Genres,
+		Crew:         req.Crew,
+		Year:         req.Year,
+		IMDbID:       req.IMDbID,
+		UserID:       job.UserID,
+	}
+	if err := uc.movieRepo.Create(ctx, movie); err != nil {
+		return result, err
+	}
+	result.MovieID = movie.ID.Hex()
+	return result, nil
+}
+
+// movieRef is a movie resolved during an import. isNew is set the first
+// time an import adds the movie to the collection.
+type movieRef struct {
+	id    primitive.ObjectID
+	isNew bool
+}
+
+// runExternalImport imports a Letterboxd or IMDb export: its films go
+// into the collection, and ratings, diary entries, watchlist entries and
+// lists are recreated for the user. Entries the user already has are
+// reported as duplicates.
+func (uc *importUsecase) runExternalImport(job domain.ImportJob, export *externalExport) {
+	ctx := context.Background()
+	movies := map[string]primitive.ObjectID{}
+	rated := map[primitive.ObjectID]bool{}
+	moviesAdded := false
+
+	for _, item := range export.items {
+		result, err := uc.importExternalItem(ctx, &job, item, movies, rated)
+		if err != nil {
+			uc.failJob(&job, result, err)
+			break
+		}
+		moviesAdded = moviesAdded || result.NewMovie
+		uc.recordRow(ctx, &job, result)
+	}
+
+	if job.Status == domain.ImportStatusRunning {
+		for i, list := range export.lists {
+			result, added, err := uc.importExternalList(ctx, &job, i+1, list, movies)
+			if err != nil {
+				uc.failJob(&job, result, err)
+				break
+			}
+			moviesAdded = moviesAdded || added
+			uc.recordRow(ctx, &job, result)
+		}
+	}
+
+	// Imported ratings change the movies' averages
+	if !job.DryRun {
+		for movieID := range rated {
+			if err := refreshMovieRating(ctx, uc.reviewRepo, uc.movieRepo, movieID.Hex()); err != nil {
+				log.Printf("failed to refresh rating of movie %s: %v", movieID.Hex(), err)
+			}
+		}
+	}
+
+	uc.finishJob(ctx, &job, moviesAdded)
+}
+
+func (uc *importUsecase) importExternalItem(ctx context.Context, job *domain.ImportJob, item externalItem, movies map[string]primitive.ObjectID, rated map[primitive.ObjectID]bool) (domain.ImportRowResult, error) {
+	result := domain.ImportRowResult{
+		Source: item.source,
+		Row:    item.row,
+		Title:  item.title,
+		Year:   item.year,
+	}
+	if item.err != nil {
+		result.Action = domain.ImportRowInvalid
+		result.Errors = []string{item.err.Error()}
+		return result, nil
+	}
+
+	movie, err := uc.resolveMovie(ctx, job, item, movies)
+	if err != nil {
+		return result, err
+	}
+	result.NewMovie = movie.isNew
+	if !(job.DryRun && movie.isNew) {
+		result.MovieID = movie.id.Hex()
+	}
+
+	userID := job.UserID.Hex()
+	duplicate := ""
+	switch item.kind {
+	case externalRating:
+		_, err := uc.reviewRepo.GetByUserAndMovie(ctx, userID, movie.id.Hex())
+		if err == nil {
+			duplicate = "you have already rated this movie"
+		} else if !errors.Is(err, mongo.ErrNoDocuments) {
+			return result, err
+		}
+	case externalDiary, externalWatched:
+		// Undated watched films count as logged if they are in the diary at all
+		watchedOn := item.date
+		if item.kind == externalWatched {
+			watchedOn = time.Time{}
+		}
+		logged, err := uc.diaryRepo.HasEntry(ctx, userID, movie.id.Hex(), watchedOn)
+		if err != nil {
+			return result, err
+		}
+		if logged {
+			duplicate = "already in your diary"
+		}
+	case externalWatchlist:
+		listed, err := uc.watchlistRepo.Contains(ctx, userID, movie.id.Hex())
+		if err != nil {
+			return result, err
+		}
+		if listed {
+			duplicate = "already on your watchlist"
+		}
+	}
+	if duplicate != "" {
+		result.Action = domain.ImportRowDuplicate
+		result.Errors = []string{duplicate}
+		return result, nil
+	}
+
+	result.Action = domain.ImportRowCreate
+	if job.DryRun {
+		return result, nil
+	}
+
+	now := time.Now()
+	date := item.date
+	if date.IsZero() {
+		date = now.UTC().Truncate(24 * time.Hour)
+	}
+
+	switch item.kind {
+	case externalRating:
+		review := &domain.Review{
+			UserID:    job.UserID,
+			MovieID:   movie.id,
+			Rating:    item.rating,
+			CreatedAt: date,
+			UpdatedAt: now,
+		}
+		if err := uc.reviewRepo.Create(ctx, review); err != nil {
+			return result, err
+		}
+		rated[movie.id] = true
+	case externalDiary, externalWatched:
+		entry := &domain.DiaryEntry{
+			UserID:    job.UserID,
+			MovieID:   movie.id,
+			WatchedOn: date,
+			Rewatch:   item.rewatch,
+			Rating:    item.rating,
+			CreatedAt: now,
+		}
+		if err := uc.diaryRepo.Create(ctx, entry); err != nil {
+			return result, err
+		}
+	case externalWatchlist:
+		entry := &domain.WatchlistEntry{
+			UserID:  job.UserID,
+			MovieID: movie.id,
+			AddedAt: date,
+		}
+		if err := uc.watchlistRepo.Add(ctx, entry); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// importExternalList recreates a Letterboxd list as a private list. It
+// reports whether any of the list's films were new to the collection.
+func (uc *importUsecase) importExternalList(ctx context.Context, job *domain.ImportJob, row int, list externalList, movies map[string]primitive.ObjectID) (domain.ImportRowResult, bool, error) {
+	result := domain.ImportRowResult{
+		Source: list.source,
+		Row:    row,
+		Title:  list.name,
+	}
+	if err := validateListInput(list.name, domain.ListVisibilityPrivate); err != nil {
+		result.Action = domain.ImportRowInvalid
+		result.Errors = []string{err.Error()}
+		return result, false, nil
+	}
+
+	exists, err := uc.listRepo.ExistsByName(ctx, job.UserID.Hex(), list.name)
+	if err != nil {
+		return result, false, err
+	}
+	if exists {
+		result.Action = domain.ImportRowDuplicate
+		result.Errors = []string{"you already have a list with this name"}
+		return result, false, nil
+	}
+
+	now := time.Now()
+	added := false
+	inList := map[primitive.ObjectID]bool{}
+	entries := []domain.ListEntry{}
+	for _, item := range list.entries {
+		if item.err != nil {
+			result.Errors = append(result.Errors, "entry "+strconv.Itoa(item.row)+": "+item.err.Error())
+			continue
+		}
+
+		movie, err := uc.resolveMovie(ctx, job, item, movies)
+		if err != nil {
+			return result, added, err
+		}
+		added = added || movie.isNew
+		if inList[movie.id] {
+			continue
+		}
+		inList[movie.id] = true
+		entries = append(entries, domain.ListEntry{MovieID: movie.id, AddedAt: now})
+	}
+
+	result.Action = domain.ImportRowCreate
+	if job.DryRun {
+		return result, added, nil
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return result, added, err
+	}
+	movieList := &domain.MovieList{
+		UserID:      job.UserID,
+		Name:        list.name,
+		Description: list.description,
+		Visibility:  domain.ListVisibilityPrivate,
+		ShareToken:  token,
+		Entries:     entries,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.listRepo.Create(ctx, movieList); err != nil {
+		return result, added, err
+	}
+	return result, added, nil
+}
+
+// resolveMovie finds the movie an export entry refers to by IMDb ID, then
+// by title and year. A film not in the collection is added with the
+// details the export has; in a dry run it gets a placeholder ID instead.
+// Resolved movies are cached in movies for the rest of the import.
+func (uc *importUsecase) resolveMovie(ctx context.Context, job *domain.ImportJob, item externalItem, movies map[string]primitive.ObjectID) (movieRef, error) {
+	titleKey := "title:" + strings.ToLower(item.title) + "|" + strconv.Itoa(item.year)
+	imdbKey := ""
+	if item.imdbID != "" {
+		imdbKey = "imdb:" + item.imdbID
+		if id, ok := movies[imdbKey]; ok {
+			return movieRef{id: id}, nil
+		}
+	}
+	if id, ok := movies[titleKey]; ok {
+		return movieRef{id: id}, nil
+	}
+
+	remember := func(ref movieRef) (movieRef, error) {
+		movies[titleKey] = ref.id
+		if imdbKey != "" {
+			movies[imdbKey] = ref.id
+		}
+		return ref, nil
+	}
+
+	if item.imdbID != "" {
+		movie, err := uc.movieRepo.FindByIMDbID(ctx, item.imdbID)
+		if err == nil {
+			return remember(movieRef{id: movie.ID})
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return movieRef{}, err
+		}
+	}
+
+	movie, err := uc.movieRepo.FindByTitleYear(ctx, item.title, item.year)
+	if err == nil {
+		return remember(movieRef{id: movie.ID})
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return movieRef{}, err
+	}
+
+	if job.DryRun {
+		return remember(movieRef{id: primitive.NewObjectID(), isNew: true})
+	}
+
+	movie = &domain.Movie{
+		Title:  item.title,
+		Year:   item.year,
+		IMDbID: item.imdbID,
+		Actors: []string{},
+		Genres: item.genres,
+		Crew:   item.crew,
+		UserID: job.UserID,
+	}
+	if movie.Genres == nil {
+		movie.Genres = []string{}
+	}
+	if err := uc.movieRepo.Create(ctx, movie); err != nil {
+		return movieRef{}, err
+	}
+	return remember(movieRef{id: movie.ID, isNew: true})
+}
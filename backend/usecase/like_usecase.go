@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type LikeUsecase interface {
+	LikeMovie(movieID, userID string) (*domain.BaseResponse, error)
+	UnlikeMovie(movieID, userID string) (*domain.BaseResponse, error)
+	GetLikedMovies(userID string, page, size int) (*domain.PaginatedResponse, error)
+}
+
+type likeUsecase struct {
+	likeRepo  repository.LikeRepository
+	movieRepo repository.MovieRepository
+	events    EventWriter
+}
+
+func NewLikeUsecase(likeRepo repository.LikeRepository, movieRepo repository.MovieRepository, events EventWriter) LikeUsecase {
+	return &likeUsecase{
+		likeRepo:  likeRepo,
+		movieRepo: movieRepo,
+		events:    events,
+	}
+}
+
+// LikeMovie records the like and bumps the movie's counter. The unique
+// user+movie index rejects a repeated like with a conflict, leaving the
+// counter unchanged.
+func (uc *likeUsecase) LikeMovie(movieID, userID string) (*domain.BaseResponse, error) {
+	userObjID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	movie, err := uc.movieRepo.GetByID(context.Background(), movieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	like := &domain.Like{
+		UserID:    userObjID,
+		MovieID:   movie.ID,
+		CreatedAt: time.Now(),
+	}
+
+	if err := uc.likeRepo.Create(context.Background(), like); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.Conflict(domain.CodeAlreadyLiked, "You already like this movie")
+		}
+		return nil, err
+	}
+
+	if err := uc.movieRepo.IncrementLikes(context.Background(), movieID, 1); err != nil {
+		return nil, err
+	}
+
+	uc.events.Record(movie.ID, domain.MovieEventLike)
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movie liked",
+		Object:  like,
+	}, nil
+}
+
+func (uc *likeUsecase) UnlikeMovie(movieID, userID string) (*domain.BaseResponse, error) {
+	if !primitive.IsValidObjectID(movieID) {
+		return nil, domain.NotFound(domain.CodeNotLiked, "You do not like this movie")
+	}
+
+	removed, err := uc.likeRepo.Delete(context.Background(), userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, domain.NotFound(domain.CodeNotLiked, "You do not like this movie")
+	}
+
+	if err := uc.movieRepo.IncrementLikes(context.Background(), movieID, -1); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movie unliked",
+	}, nil
+}
+
+// GetLikedMovies returns the movies a user likes, most recently liked first.
+func (uc *likeUsecase) GetLikedMovies(userID string, page, size int) (*domain.PaginatedResponse, error) {
+	likes, total, err := uc.likeRepo.GetByUserID(context.Background(), userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	movieIDs := make([]string, 0, len(likes))
+	for _, like := range likes {
+		movieIDs = append(movieIDs, like.MovieID.Hex())
+	}
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]domain.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	ordered := make([]domain.Movie, 0, len(movies))
+	for _, like := range likes {
+		if movie, ok := byID[like.MovieID]; ok {
+			ordered = append(ordered, movie)
+		}
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Liked movies retrieved successfully",
+		Object:     ordered,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
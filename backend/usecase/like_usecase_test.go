@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLikeCounterStaysInStep(t *testing.T) {
+	movie := &domain.Movie{Title: "Alien"}
+	movies := newFakeMovieRepo(movie)
+	likes := &fakeLikeRepo{}
+	uc := NewLikeUsecase(likes, movies, nopEvents{})
+	ada, bob := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+
+	like := func(userID string) (*domain.BaseResponse, error) { return uc.LikeMovie(movie.ID.Hex(), userID) }
+	unlike := func(userID string) (*domain.BaseResponse, error) { return uc.UnlikeMovie(movie.ID.Hex(), userID) }
+	tests := []struct {
+		name  string
+		do    func(userID string) (*domain.BaseResponse, error)
+		user  string
+		want  string
+		count int64
+	}{
+		{name: "a like", do: like, user: ada, count: 1},
+		{name: "the same like again", do: like, user: ada, want: domain.CodeAlreadyLiked, count: 1},
+		{name: "another user's like", do: like, user: bob, count: 2},
+		{name: "an unlike", do: unlike, user: ada, count: 1},
+		{name: "the same unlike again", do: unlike, user: ada, want: domain.CodeNotLiked, count: 1},
+		{name: "liking again after unliking", do: like, user: ada, count: 2},
+		{name: "the other user's unlike", do: unlike, user: bob, count: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.do(tt.user)
+			if code := errorCode(err); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+			if movie.LikeCount != tt.count || int64(len(likes.likes)) != tt.count {
+				t.Errorf("likeCount = %d with %d likes stored, want %d", movie.LikeCount, len(likes.likes), tt.count)
+			}
+		})
+	}
+}
+
+func TestUnlikeWithoutALike(t *testing.T) {
+	movie := &domain.Movie{Title: "Alien", LikeCount: 3}
+	movies := newFakeMovieRepo(movie)
+	uc := NewLikeUsecase(&fakeLikeRepo{}, movies, nopEvents{})
+
+	for _, movieID := range []string{movie.ID.Hex(), "alien"} {
+		_, err := uc.UnlikeMovie(movieID, primitive.NewObjectID().Hex())
+		if code := errorCode(err); code != domain.CodeNotLiked {
+			t.Errorf("UnlikeMovie(%q) code = %q, want %q", movieID, code, domain.CodeNotLiked)
+		}
+	}
+	if movie.LikeCount != 3 {
+		t.Errorf("likeCount = %d, want 3 left alone", movie.LikeCount)
+	}
+
+	_, err := uc.LikeMovie(primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+	if code := errorCode(err); code != domain.CodeMovieNotFound {
+		t.Errorf("liking an unknown movie code = %q, want %q", code, domain.CodeMovieNotFound)
+	}
+}
@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxListNameLength = 100
+
+type ListUsecase interface {
+	CreateList(req *domain.CreateListRequest) (*domain.BaseResponse, error)
+	GetMyLists(userID string, page, size int) (*domain.PaginatedResponse, error)
+	GetList(id, userID string) (*domain.BaseResponse, error)
+	GetSharedList(token string) (*domain.BaseResponse, error)
+	UpdateList(id, userID string, req *domain.UpdateListRequest) (*domain.BaseResponse, error)
+	DeleteList(id, userID string) (*domain.BaseResponse, error)
+	AddEntries(id, userID string, req *domain.AddListEntriesRequest) (*domain.BaseResponse, error)
+	RemoveEntries(id, userID string, req *domain.RemoveListEntriesRequest) (*domain.BaseResponse, error)
+	UpdateEntry(id, movieID, userID string, req *domain.UpdateListEntryRequest) (*domain.BaseResponse, error)
+	ReorderList(id, userID string, req *domain.ReorderListRequest) (*domain.BaseResponse, error)
+}
+
+type listUsecase struct {
+	listRepo   repository.ListRepository
+	movieRepo  repository.MovieRepository
+	activities ActivityRecorder
+	events     EventWriter
+}
+
+func NewListUsecase(listRepo repository.ListRepository, movieRepo repository.MovieRepository, activities ActivityRecorder, events EventWriter) ListUsecase {
+	return &listUsecase{
+		listRepo:   listRepo,
+		movieRepo:  movieRepo,
+		activities: activities,
+		events:     events,
+	}
+}
+
+func (uc *listUsecase) CreateList(req *domain.CreateListRequest) (*domain.BaseResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	if req.Visibility == "" {
+		req.Visibility = domain.ListVisibilityPrivate
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := validateListInput(name, req.Visibility); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	list := &domain.MovieList{
+		UserID:      userID,
+		Name:        name,
+		Description: req.Description,
+		Visibility:  req.Visibility,
+		ShareToken:  token,
+		Entries:     []domain.ListEntry{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	if err := uc.listRepo.Create(context.Background(), list); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List created successfully",
+		Object:  list,
+	}, nil
+}
+
+func (uc *listUsecase) GetMyLists(userID string, page, size int) (*domain.PaginatedResponse, error) {
+	lists, total, err := uc.listRepo.GetByUserID(context.Background(), userID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Lists retrieved successfully",
+		Object:     lists,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+// GetList returns a list to its owner, or to anyone else if it is public.
+// Unlisted lists are only reachable through their share link.
+func (uc *listUsecase) GetList(id, userID string) (*domain.BaseResponse, error) {
+	list, err := uc.listRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeListNotFound, "List not found"))
+	}
+
+	if list.UserID.Hex() != userID {
+		if list.Visibility != domain.ListVisibilityPublic {
+			return nil, domain.NotFound(domain.CodeListNotFound, "List not found")
+		}
+		list.ShareToken = ""
+	}
+
+	return uc.listDetails(list)
+}
+
+func (uc *listUsecase) GetSharedList(token string) (*domain.BaseResponse, error) {
+	list, err := uc.listRepo.GetByShareToken(context.Background(), token)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeListNotFound, "List not found"))
+	}
+	if list.Visibility == domain.ListVisibilityPrivate {
+		return nil, domain.NotFound(domain.CodeListNotFound, "List not found")
+	}
+
+	list.ShareToken = ""
+	return uc.listDetails(list)
+}
+
+func (uc *listUsecase) UpdateList(id, userID string, req *domain.UpdateListRequest) (*domain.BaseResponse, error) {
+	list, err := uc.getOwnedList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := validateListInput(name, req.Visibility); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	list.Name = name
+	list.Description = req.Description
+	list.Visibility = req.Visibility
+	list.UpdatedAt = time.Now()
+
+	if err := uc.listRepo.Update(context.Background(), id, list); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List updated successfully",
+		Object:  list,
+	}, nil
+}
+
+func (uc *listUsecase) DeleteList(id, userID string) (*domain.BaseResponse, error) {
+	if _, err := uc.getOwnedList(id, userID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.listRepo.Delete(context.Background(), id); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List deleted successfully",
+	}, nil
+}
+
+// AddEntries appends movies to the end of a list. Movies already on the list
+// are skipped, so the call can safely be retried; when another request adds
+// one of them meanwhile, nothing is added and the call is a conflict.
+func (uc *listUsecase) AddEntries(id, userID string, req *domain.AddListEntriesRequest) (*domain.BaseResponse, error) {
+	list, err := uc.getOwnedList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(list.Entries))
+	for _, entry := range list.Entries {
+		present[entry.MovieID.Hex()] = true
+	}
+
+	var movieIDs []string
+	for _, input := range req.Entries {
+		movieIDs = append(movieIDs, input.MovieID)
+	}
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(movies))
+	for _, movie := range movies {
+		known[movie.ID.Hex()] = true
+	}
+
+	var missing []domain.ErrorDetail
+	for _, movieID := range movieIDs {
+		if !known[movieID] {
+			missing = append(missing, domain.NewErrorDetail(domain.CodeUnknownMovie, "movie not found: "+movieID, "id", movieID))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, domain.Validation(domain.CodeMoviesNotFound, "Some movies could not be found", missing...)
+	}
+
+	now := time.Now()
+	var entries []domain.ListEntry
+	for _, input := range req.Entries {
+		if present[input.MovieID] {
+			continue
+		}
+		present[input.MovieID] = true
+
+		movieID, _ := primitive.ObjectIDFromHex(input.MovieID)
+		entries = append(entries, domain.ListEntry{
+			MovieID: movieID,
+			Note:    input.Note,
+			AddedAt: now,
+		})
+	}
+
+	if len(entries) > 0 {
+		added, err := uc.listRepo.AddEntries(context.Background(), id, entries)
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			return nil, errListChanged
+		}
+		list.Entries = append(list.Entries, entries...)
+
+		for _, entry := range entries {
+			uc.activities.Record(domain.ActivityMovieListed, userID, entry.MovieID, &list.ID)
+			uc.events.Record(entry.MovieID, domain.MovieEventListAdd)
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movies added to list",
+		Object:  list,
+	}, nil
+}
+
+func (uc *listUsecase) RemoveEntries(id, userID string, req *domain.RemoveListEntriesRequest) (*domain.BaseResponse, error) {
+	list, err := uc.getOwnedList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, movieID := range req.MovieIDs {
+		if !primitive.IsValidObjectID(movieID) {
+			return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeInvalidID, "invalid movie ID: "+movieID, "id", movieID))
+		}
+	}
+
+	if err := uc.listRepo.RemoveEntries(context.Background(), id, req.MovieIDs); err != nil {
+		return nil, err
+	}
+
+	removed := make(map[string]bool, len(req.MovieIDs))
+	for _, movieID := range req.MovieIDs {
+		removed[movieID] = true
+	}
+	remaining := make([]domain.ListEntry, 0, len(list.Entries))
+	for _, entry := range list.Entries {
+		if !removed[entry.MovieID.Hex()] {
+			remaining = append(remaining, entry)
+		}
+	}
+	list.Entries = remaining
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movies removed from list",
+		Object:  list,
+	}, nil
+}
+
+func (uc *listUsecase) UpdateEntry(id, movieID, userID string, req *domain.UpdateListEntryRequest) (*domain.BaseResponse, error) {
+	if _, err := uc.getOwnedList(id, userID); err != nil {
+		return nil, err
+	}
+
+	if !primitive.IsValidObjectID(movieID) {
+		return nil, domain.NotFound(domain.CodeNotOnList, "Movie is not on this list")
+	}
+
+	found, err := uc.listRepo.UpdateEntryNote(context.Background(), id, movieID, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, domain.NotFound(domain.CodeNotOnList, "Movie is not on this list")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List entry updated successfully",
+	}, nil
+}
+
+// ReorderList applies a new order. The request must name every movie on the
+// list exactly once, and the list must not change before the new order is
+// saved, so an out-of-date client cannot silently drop entries.
+func (uc *listUsecase) ReorderList(id, userID string, req *domain.ReorderListRequest) (*domain.BaseResponse, error) {
+	list, err := uc.getOwnedList(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byMovie := make(map[string]domain.ListEntry, len(list.Entries))
+	for _, entry := range list.Entries {
+		byMovie[entry.MovieID.Hex()] = entry
+	}
+
+	if len(req.MovieIDs) != len(list.Entries) {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeListOrderMismatch, "movieIds must list every movie on the list exactly once"))
+	}
+
+	reordered := make([]domain.ListEntry, 0, len(req.MovieIDs))
+	for _, movieID := range req.MovieIDs {
+		entry, ok := byMovie[movieID]
+		if !ok {
+			return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeListOrderMismatch, "movieIds must list every movie on the list exactly once"))
+		}
+		delete(byMovie, movieID)
+		reordered = append(reordered, entry)
+	}
+
+	replaced, err := uc.listRepo.ReplaceEntries(context.Background(), id, list.Entries, reordered)
+	if err != nil {
+		return nil, err
+	}
+	if !replaced {
+		return nil, errListChanged
+	}
+	list.Entries = reordered
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List reordered successfully",
+		Object:  list,
+	}, nil
+}
+
+// errListChanged is a list edit made on entries another request changed
+// since they were read.
+var errListChanged = domain.Conflict(domain.CodeListChanged, "The list was changed by another request; reload it and try again")
+
+// getOwnedList loads a list and checks that userID owns it.
+func (uc *listUsecase) getOwnedList(id, userID string) (*domain.MovieList, error) {
+	list, err := uc.listRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeListNotFound, "List not found"))
+	}
+
+	if list.UserID.Hex() != userID {
+		return nil, domain.Forbidden(domain.CodeListEditForbidden, "You are not authorized to modify this list")
+	}
+
+	return list, nil
+}
+
+// listDetails resolves the list's movies so readers get everything in one response.
+func (uc *listUsecase) listDetails(list *domain.MovieList) (*domain.BaseResponse, error) {
+	movieIDs := make([]string, 0, len(list.Entries))
+	for _, entry := range list.Entries {
+		movieIDs = append(movieIDs, entry.MovieID.Hex())
+	}
+
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), movieIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[primitive.ObjectID]domain.Movie, len(movies))
+	for _, movie := range movies {
+		byID[movie.ID] = movie
+	}
+
+	ordered := make([]domain.Movie, 0, len(movies))
+	for _, entry := range list.Entries {
+		if movie, ok := byID[entry.MovieID]; ok {
+			ordered = append(ordered, movie)
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "List retrieved successfully",
+		Object: domain.ListDetailsResponse{
+			MovieList: list,
+			Movies:    ordered,
+		},
+	}, nil
+}
+
+func validateListInput(name, visibility string) error {
+	if name == "" {
+		return domain.NewCodedError(domain.CodeFieldEmpty, "name must not be empty", "field", "name")
+	}
+	if len(name) > maxListNameLength {
+		return domain.NewCodedError(domain.CodeFieldTooLong, "name must be at most 100 characters long", "field", "name", "max", "100")
+	}
+
+	switch visibility {
+	case domain.ListVisibilityPrivate, domain.ListVisibilityUnlisted, domain.ListVisibilityPublic:
+		return nil
+	default:
+		return domain.NewCodedError(domain.CodeFieldNotOneOf, "visibility must be private, unlisted or public",
+			"field", "visibility", "values", "private, unlisted, public")
+	}
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
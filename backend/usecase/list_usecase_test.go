@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// listFixture is a list owned by a user, holding the first held of movies.
+func listFixture(movies []*domain.Movie, held int) (*fakeListRepo, *domain.MovieList, string) {
+	owner := primitive.NewObjectID()
+	list := &domain.MovieList{UserID: owner, Name: "Movie night"}
+	for _, movie := range movies[:held] {
+		list.Entries = append(list.Entries, domain.ListEntry{MovieID: movie.ID})
+	}
+	return newFakeListRepo(list), list, owner.Hex()
+}
+
+func listMovies(n int) []*domain.Movie {
+	movies := make([]*domain.Movie, n)
+	for i := range movies {
+		movies[i] = &domain.Movie{ID: primitive.NewObjectID()}
+	}
+	return movies
+}
+
+func entryMovies(list *domain.MovieList, movies []*domain.Movie) []int {
+	index := make(map[primitive.ObjectID]int, len(movies))
+	for i, movie := range movies {
+		index[movie.ID] = i
+	}
+	order := make([]int, len(list.Entries))
+	for i, entry := range list.Entries {
+		order[i] = index[entry.MovieID]
+	}
+	return order
+}
+
+func sameOrder(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestAddEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		held    int
+		add     []int
+		meddle  int // index of a movie another request adds meanwhile, or -1
+		code    string
+		entries []int
+	}{
+		{name: "appends to the end", held: 2, add: []int{3, 2}, meddle: -1, entries: []int{0, 1, 3, 2}},
+		{name: "skips movies already on the list", held: 2, add: []int{1, 2}, meddle: -1, entries: []int{0, 1, 2}},
+		{name: "adding only movies on the list changes nothing", held: 2, add: []int{0, 1}, meddle: -1, entries: []int{0, 1}},
+		{name: "another request adding one of the movies is a conflict", held: 1, add: []int{2, 3}, meddle: 3, code: domain.CodeListChanged, entries: []int{0, 3}},
+		{name: "another request adding a different movie does not clash", held: 1, add: []int{2}, meddle: 3, entries: []int{0, 3, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies := listMovies(4)
+			lists, list, owner := listFixture(movies, tt.held)
+			if tt.meddle >= 0 {
+				lists.meddle = func(list *domain.MovieList) {
+					list.Entries = append(list.Entries, domain.ListEntry{MovieID: movies[tt.meddle].ID})
+					lists.meddle = nil
+				}
+			}
+			uc := NewListUsecase(lists, newFakeMovieRepo(movies...), nopActivities{}, nopEvents{})
+
+			req := &domain.AddListEntriesRequest{}
+			for _, i := range tt.add {
+				req.Entries = append(req.Entries, domain.ListEntryInput{MovieID: movies[i].ID.Hex()})
+			}
+			_, err := uc.AddEntries(list.ID.Hex(), owner, req)
+			if code := errorCode(err); code != tt.code {
+				t.Fatalf("AddEntries code = %q, want %q (err %v)", code, tt.code, err)
+			}
+			if got := entryMovies(list, movies); !sameOrder(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestAddEntriesRejectsUnknownMovies(t *testing.T) {
+	movies := listMovies(2)
+	lists, list, owner := listFixture(movies, 0)
+	uc := NewListUsecase(lists, newFakeMovieRepo(movies...), nopActivities{}, nopEvents{})
+
+	req := &domain.AddListEntriesRequest{Entries: []domain.ListEntryInput{
+		{MovieID: movies[0].ID.Hex()},
+		{MovieID: primitive.NewObjectID().Hex()},
+	}}
+	_, err := uc.AddEntries(list.ID.Hex(), owner, req)
+	if code := errorCode(err); code != domain.CodeMoviesNotFound {
+		t.Fatalf("AddEntries code = %q, want %q", code, domain.CodeMoviesNotFound)
+	}
+	if len(list.Entries) != 0 {
+		t.Errorf("entries = %d, want none added", len(list.Entries))
+	}
+}
+
+func TestReorderList(t *testing.T) {
+	tests := []struct {
+		name    string
+		held    int
+		order   []int
+		meddle  bool
+		code    string
+		entries []int
+	}{
+		{name: "applies the new order", held: 3, order: []int{2, 0, 1}, entries: []int{2, 0, 1}},
+		{name: "the same order is kept", held: 3, order: []int{0, 1, 2}, entries: []int{0, 1, 2}},
+		{name: "an empty list reorders to nothing", held: 0, order: []int{}, entries: []int{}},
+		{name: "a missing movie is a mismatch", held: 3, order: []int{2, 0}, code: domain.CodeValidationFailed, entries: []int{0, 1, 2}},
+		{name: "a movie not on the list is a mismatch", held: 2, order: []int{1, 3}, code: domain.CodeValidationFailed, entries: []int{0, 1}},
+		{name: "a repeated movie is a mismatch", held: 2, order: []int{1, 1}, code: domain.CodeValidationFailed, entries: []int{0, 1}},
+		{name: "a list changed meanwhile is a conflict", held: 3, order: []int{2, 1, 0}, meddle: true, code: domain.CodeListChanged, entries: []int{0, 1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies := listMovies(4)
+			lists, list, owner := listFixture(movies, tt.held)
+			if tt.meddle {
+				lists.meddle = func(list *domain.MovieList) {
+					list.Entries = append(list.Entries, domain.ListEntry{MovieID: movies[3].ID})
+				}
+			}
+			uc := NewListUsecase(lists, newFakeMovieRepo(movies...), nopActivities{}, nopEvents{})
+
+			req := &domain.ReorderListRequest{MovieIDs: []string{}}
+			for _, i := range tt.order {
+				req.MovieIDs = append(req.MovieIDs, movies[i].ID.Hex())
+			}
+			_, err := uc.ReorderList(list.ID.Hex(), owner, req)
+			if code := errorCode(err); code != tt.code {
+				t.Fatalf("ReorderList code = %q, want %q (err %v)", code, tt.code, err)
+			}
+			if got := entryMovies(list, movies); !sameOrder(got, tt.entries) {
+				t.Errorf("entries = %v, want %v", got, tt.entries)
+			}
+		})
+	}
+}
+
+func TestListEditsNeedTheOwner(t *testing.T) {
+	movies := listMovies(2)
+	lists, list, _ := listFixture(movies, 2)
+	uc := NewListUsecase(lists, newFakeMovieRepo(movies...), nopActivities{}, nopEvents{})
+
+	_, err := uc.ReorderList(list.ID.Hex(), primitive.NewObjectID().Hex(), &domain.ReorderListRequest{
+		MovieIDs: []string{movies[1].ID.Hex(), movies[0].ID.Hex()},
+	})
+	if code := errorCode(err); code != domain.CodeListEditForbidden {
+		t.Errorf("ReorderList by another user code = %q, want %q", code, domain.CodeListEditForbidden)
+	}
+}
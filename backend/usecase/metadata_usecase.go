@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/metadata"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+)
+
+// metadataLookupLimit caps the search results a title lookup returns,
+// since each costs a details request.
+const metadataLookupLimit = 5
+
+// ErrMetadataUnavailable is returned when no metadata provider is
+// configured or the provider cannot be reached.
+var ErrMetadataUnavailable error = domain.Unavailable(domain.CodeMetadataUnavailable, "Movie metadata is unavailable, try again later")
+
+// MetadataUsecase looks movies up at a metadata provider, to prefill new
+// movies and enrich existing ones.
+type MetadataUsecase interface {
+	LookupMetadata(title string, year int, imdbID string) (*domain.BaseResponse, error)
+	RefreshMetadata(movieID, userID string, req *domain.RefreshMetadataRequest) (*domain.BaseResponse, error)
+}
+
+type metadataUsecase struct {
+	movieRepo   repository.MovieRepository
+	provider    metadata.Provider
+	posters     PosterUsecase
+	permissions PermissionService
+	similarity  SimilarityIndexer
+}
+
+// NewMetadataUsecase takes a nil provider when none is configured.
+func NewMetadataUsecase(movieRepo repository.MovieRepository, provider metadata.Provider, posters PosterUsecase, permissions PermissionService, similarity SimilarityIndexer) MetadataUsecase {
+	return &metadataUsecase{
+		movieRepo:   movieRepo,
+		provider:    provider,
+		posters:     posters,
+		permissions: permissions,
+		similarity:  similarity,
+	}
+}
+
+// LookupMetadata finds a movie by IMDb ID, or by title and optional year.
+// Each result has full details, ready to prefill a new movie.
+func (uc *metadataUsecase) LookupMetadata(title string, year int, imdbID string) (*domain.BaseResponse, error) {
+	if uc.provider == nil {
+		return nil, ErrMetadataUnavailable
+	}
+	title, imdbID = strings.TrimSpace(title), strings.TrimSpace(imdbID)
+	if title == "" && imdbID == "" {
+		return nil, domain.Validation(domain.CodeInvalidLookup, "Invalid lookup", domain.NewErrorDetail(domain.CodeTitleOrIMDbID, "title or imdbId is required"))
+	}
+
+	ctx := context.Background()
+	results := []domain.MovieMetadata{}
+
+	if imdbID != "" {
+		movie, err := uc.provider.FindByIMDbID(ctx, imdbID)
+		if err != nil && !errors.Is(err, metadata.ErrNotFound) {
+			return nil, providerError(err)
+		}
+		if movie != nil {
+			results = append(results, *movie)
+		}
+	} else {
+		found, err := uc.provider.Search(ctx, title, year)
+		if err != nil {
+			return nil, providerError(err)
+		}
+		if len(found) > metadataLookupLimit {
+			found = found[:metadataLookupLimit]
+		}
+		for _, summary := range found {
+			movie, err := uc.provider.Movie(ctx, summary.SourceID)
+			if errors.Is(err, metadata.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, providerError(err)
+			}
+			results = append(results, *movie)
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Metadata retrieved successfully",
+		Object:  results,
+	}, nil
+}
+
+// RefreshMetadata enriches a movie from the provider entry it was last
+// refreshed from, or else the one matching its IMDb ID, or else its exact
+// title and year. The provider's poster is stored when the movie has no
+// uploaded poster, or with Overwrite.
+func (uc *metadataUsecase) RefreshMetadata(movieID, userID string, req *domain.RefreshMetadataRequest) (*domain.BaseResponse, error) {
+	if uc.provider == nil {
+		return nil, ErrMetadataUnavailable
+	}
+	ctx := context.Background()
+
+	movie, err := uc.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	allowed, err := uc.permissions.CanEditMovie(movie, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.Forbidden(domain.CodeMovieEditForbidden, "You are not authorized to update this movie")
+	}
+
+	found, err := uc.findMetadata(ctx, movie)
+	if errors.Is(err, metadata.ErrNotFound) {
+		return nil, domain.NotFound(domain.CodeMetadataNotFound, "No metadata found for this movie", domain.NewErrorDetail(domain.CodeSetIMDbID, "set the movie's imdbId to pick the right match"))
+	}
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	applyMetadata(movie, found, req.Overwrite)
+	movie.Metadata = &domain.MetadataRef{
+		Source:      found.Source,
+		ID:          found.SourceID,
+		RefreshedAt: time.Now().UTC(),
+	}
+	if err := uc.movieRepo.Update(ctx, movieID, movieDetails(movie)); err != nil {
+		return nil, err
+	}
+	if err := uc.movieRepo.SetMetadata(ctx, movieID, movie.Metadata); err != nil {
+		return nil, err
+	}
+	uc.similarity.MovieChanged()
+
+	if found.PosterURL != "" && (movie.PosterKey == "" || req.Overwrite) {
+		uc.storePoster(ctx, movie, found, userID)
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Metadata refreshed successfully",
+		Object:  movie,
+	}, nil
+}
+
+func (uc *metadataUsecase) findMetadata(ctx context.Context, movie *domain.Movie) (*domain.MovieMetadata, error) {
+	if movie.Metadata != nil {
+		found, err := uc.provider.Movie(ctx, movie.Metadata.ID)
+		if !errors.Is(err, metadata.ErrNotFound) {
+			return found, err
+		}
+	}
+	if movie.IMDbID != "" {
+		found, err := uc.provider.FindByIMDbID(ctx, movie.IMDbID)
+		if !errors.Is(err, metadata.ErrNotFound) {
+			return found, err
+		}
+	}
+
+	results, err := uc.provider.Search(ctx, movie.Title, movie.Year)
+	if err != nil {
+		return nil, err
+	}
+	title := normalizeTitle(movie.Title)
+	for _, result := range results {
+		if normalizeTitle(result.Title) == title && (movie.Year == 0 || result.Year == movie.Year) {
+			return uc.provider.Movie(ctx, result.SourceID)
+		}
+	}
+	return nil, metadata.ErrNotFound
+}
+
+// storePoster downloads the provider's poster and uploads it as the
+// movie's own. Failures are only logged: the details are already saved.
+func (uc *metadataUsecase) storePoster(ctx context.Context, movie *domain.Movie, found *domain.MovieMetadata, userID string) {
+	data, err := uc.provider.Poster(ctx, found)
+	if err != nil {
+		log.Printf("failed to download poster for movie %s: %v", movie.ID.Hex(), err)
+		return
+	}
+
+	response, err := uc.posters.UploadPoster(movie.ID.Hex(), userID, data)
+	if err != nil {
+		log.Printf("failed to store poster for movie %s: %v", movie.ID.Hex(), err)
+		return
+	}
+	if updated, ok := response.Object.(*domain.Movie); ok {
+		movie.PosterKey, movie.Poster = updated.PosterKey, updated.Poster
+	}
+}
+
+// applyMetadata fills in the details a movie lacks from the provider's.
+// With overwrite, the provider's description, trailer, genres and credits
+// replace the movie's own where the provider has them.
+func applyMetadata(movie *domain.Movie, found *domain.MovieMetadata, overwrite bool) {
+	if found.Description != "" && (movie.Description == "" || overwrite) {
+		movie.Description = found.Description
+	}
+	if movie.Year == 0 {
+		movie.Year = found.Year
+	}
+	if movie.IMDbID == "" {
+		movie.IMDbID = found.IMDbID
+	}
+	if found.Trailer != "" && (movie.Trailer == "" || overwrite) {
+		if trailer, err := domain.ParseTrailerURL(found.Trailer); err == nil {
+			movie.Trailer, movie.TrailerVideo = trailer.URL(), trailer
+		}
+	}
+
+	lists := []struct {
+		names *[]string
+		found []string
+	}{
+		{&movie.Genres, found.Genres},
+		{&movie.Actors, found.Actors},
+		{&movie.Crew, found.Crew},
+	}
+	for _, list := range lists {
+		if overwrite && len(list.found) > 0 {
+			*list.names = list.found
+		} else {
+			*list.names = mergeNames(*list.names, list.found)
+		}
+	}
+}
+
+// providerError reports a provider failure as ErrMetadataUnavailable,
+// keeping the cause in the message.
+func providerError(err error) error {
+	return fmt.Errorf("%w: %v", ErrMetadataUnavailable, err)
+}
@@ -2,53 +2,103 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/i18n"
 	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 type MovieUsecase interface {
 	CreateMovie(req *domain.CreateMovieRequest) (*domain.BaseResponse, error)
-	GetMovies(page, size int) (*domain.PaginatedResponse, error)  // This was missing
+	GetMovies(page, size int, sortBy string) (*domain.PaginatedResponse, error)
 	GetMovieByID(id string) (*domain.BaseResponse, error)
 	SearchMovies(title string, page, size int) (*domain.PaginatedResponse, error)
 	UpdateMovie(id, userID string, req *domain.UpdateMovieRequest) (*domain.BaseResponse, error)
 	DeleteMovie(id, userID string) (*domain.BaseResponse, error)
+	ResolveRedirect(id string) (string, error)
 }
 
 type movieUsecase struct {
-	movieRepo repository.MovieRepository
+	movieRepo   repository.MovieRepository
+	listRepo    repository.ListRepository
+	references  repository.MovieReferenceRepository
+	permissions PermissionService
+	activities  ActivityRecorder
+	similarity  SimilarityIndexer
+	events      EventWriter
+	redirects   repository.MovieRedirectRepository
+	posters     PosterUsecase
+	locales     *i18n.Locales
 }
 
-func NewMovieUsecase(movieRepo repository.MovieRepository) MovieUsecase {
-	return &movieUsecase{movieRepo: movieRepo}
+func NewMovieUsecase(movieRepo repository.MovieRepository, listRepo repository.ListRepository, references repository.MovieReferenceRepository, permissions PermissionService, activities ActivityRecorder, similarity SimilarityIndexer, events EventWriter, redirects repository.MovieRedirectRepository, posters PosterUsecase, locales *i18n.Locales) MovieUsecase {
+	return &movieUsecase{
+		movieRepo:   movieRepo,
+		listRepo:    listRepo,
+		references:  references,
+		redirects:   redirects,
+		posters:     posters,
+		locales:     locales,
+		permissions: permissions,
+		activities:  activities,
+		similarity:  similarity,
+		events:      events,
+	}
 }
 
 func (uc *movieUsecase) CreateMovie(req *domain.CreateMovieRequest) (*domain.BaseResponse, error) {
 	// Convert userID to ObjectID
 	userID, err := primitive.ObjectIDFromHex(req.UserID)
 	if err != nil {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "Invalid user ID",
-			Errors:  []string{err.Error()},
-		}, nil
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	trailer, err := domain.ParseTrailerURL(req.Trailer)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidTrailer, "Invalid trailer URL", domain.ErrorDetails(err)...)
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = uc.locales.Default
+	}
+	if problems := uc.checkLocales(locale, req.Translations); len(problems) > 0 {
+		return nil, domain.Validation(domain.CodeInvalidTranslation, "Invalid translations", problems...)
 	}
 
 	movie := &domain.Movie{
-		Title:       req.Title,
-		Description: req.Description,
-		Poster:      req.Poster,
-		Trailer:     req.Trailer,
-		Actors:      req.Actors,
-		Genres:      req.Genres,
-		UserID:      userID,
+		Title:        req.Title,
+		Description:  req.Description,
+		Trailer:      trailer.URL(),
+		TrailerVideo: trailer,
+		Actors:       req.Actors,
+		Genres:       req.Genres,
+		Crew:         req.Crew,
+		Year:         req.Year,
+		IMDbID:       req.IMDbID,
+		UserID:       userID,
+		Locale:       locale,
+		Translations: req.Translations,
+	}
+
+	if req.CollectionID != "" {
+		collectionID, err := uc.checkCollectionAccess(req.CollectionID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		movie.CollectionID = collectionID
 	}
 
 	if err := uc.movieRepo.Create(context.Background(), movie); err != nil {
 		return nil, err
 	}
 
+	uc.activities.Record(domain.ActivityMovieAdded, req.UserID, movie.ID, nil)
+	uc.similarity.MovieChanged()
+
 	return &domain.BaseResponse{
 		Success: true,
 		Message: "Movie created successfully",
@@ -75,12 +125,11 @@ func (uc *movieUsecase) SearchMovies(title string, page, size int) (*domain.Pagi
 func (uc *movieUsecase) GetMovieByID(id string) (*domain.BaseResponse, error) {
 	movie, err := uc.movieRepo.GetByID(context.Background(), id)
 	if err != nil {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "Movie not found",
-		}, nil
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
 	}
 
+	uc.events.Record(movie.ID, domain.MovieEventView)
+
 	return &domain.BaseResponse{
 		Success: true,
 		Message: "Movie retrieved successfully",
@@ -92,35 +141,97 @@ func (uc *movieUsecase) UpdateMovie(id, userID string, req *domain.UpdateMovieRe
 	// Verify movie exists and belongs to user
 	movie, err := uc.movieRepo.GetByID(context.Background(), id)
 	if err != nil {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "Movie not found",
-		}, nil
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
 	}
 
-	// Check ownership
-	if movie.UserID.Hex() != userID {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "You are not authorized to update this movie",
-		}, nil
+	// Check the user may edit it, directly or through a shared collection
+	allowed, err := uc.permissions.CanEditMovie(movie, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.Forbidden(domain.CodeMovieEditForbidden, "You are not authorized to update this movie")
+	}
+
+	trailer, err := domain.ParseTrailerURL(req.Trailer)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidTrailer, "Invalid trailer URL", domain.ErrorDetails(err)...)
+	}
+	if req.LeaveCollection && req.CollectionID != "" {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.NewErrorDetail(domain.CodeFieldInvalid,
+			"leaveCollection cannot be combined with collectionId", "field", "leaveCollection"))
+	}
+
+	locale, translations := req.Locale, req.Translations
+	if locale == "" {
+		locale = movie.Locale
+	}
+	if locale == "" {
+		locale = uc.locales.Default
+	}
+	if translations == nil {
+		translations = movie.Translations
+	}
+	if problems := uc.checkLocales(locale, translations); len(problems) > 0 {
+		return nil, domain.Validation(domain.CodeInvalidTranslation, "Invalid translations", problems...)
 	}
 
 	// Update movie fields
 	updatedMovie := &domain.Movie{
-		Title:       req.Title,
-		Description: req.Description,
-		Poster:      req.Poster,
-		Trailer:     req.Trailer,
-		Actors:      req.Actors,
-		Genres:      req.Genres,
-		UserID:      movie.UserID, 
+		Title:        req.Title,
+		Description:  req.Description,
+		Trailer:      trailer.URL(),
+		TrailerVideo: trailer,
+		Locale:       locale,
+		Translations: translations,
+		Actors:       req.Actors,
+		Genres:       req.Genres,
+		Crew:         req.Crew,
+		Year:         req.Year,
+		IMDbID:       req.IMDbID,
+	}
+
+	// Filing the movie elsewhere takes the right to add to the new
+	// collection and, if it is in one, to remove from the current one
+	collectionID := movie.CollectionID
+	switch {
+	case req.LeaveCollection:
+		collectionID = primitive.NilObjectID
+	case req.CollectionID != "" && req.CollectionID != movie.CollectionID.Hex():
+		if collectionID, err = uc.checkCollectionAccess(req.CollectionID, userID); err != nil {
+			return nil, err
+		}
+	}
+	moveCollection := collectionID != movie.CollectionID
+	if moveCollection && !movie.CollectionID.IsZero() {
+		allowed, err := uc.permissions.CanRemoveFromCollection(movie, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, domain.Forbidden(domain.CodeCollectionRemoveForbidden, "You are not authorized to remove movies from this collection")
+		}
 	}
 
 	err = uc.movieRepo.Update(context.Background(), id, updatedMovie)
 	if err != nil {
 		return nil, err
 	}
+	if moveCollection {
+		if err := uc.movieRepo.SetCollection(context.Background(), id, collectionID); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.activities.Record(domain.ActivityMovieUpdated, userID, movie.ID, nil)
+	uc.similarity.MovieChanged()
+
+	// Read the movie back for the fields an update leaves alone, such as
+	// its ID and counters
+	updatedMovie, err = uc.movieRepo.GetByID(context.Background(), id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
 
 	return &domain.BaseResponse{
 		Success: true,
@@ -132,31 +243,48 @@ func (uc *movieUsecase) UpdateMovie(id, userID string, req *domain.UpdateMovieRe
 func (uc *movieUsecase) DeleteMovie(id, userID string) (*domain.BaseResponse, error) {
 	movie, err := uc.movieRepo.GetByID(context.Background(), id)
 	if err != nil {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "Movie not found",
-		}, nil
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
 	}
 
-	if movie.UserID.Hex() != userID {
-		return &domain.BaseResponse{
-			Success: false,
-			Message: "You are not authorized to delete this movie",
-		}, nil
+	allowed, err := uc.permissions.CanDeleteMovie(movie, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.Forbidden(domain.CodeMovieDeleteForbidden, "You are not authorized to delete this movie")
 	}
 
+	// Reviews, likes, comments and the like go first, so a delete that
+	// fails part way leaves the movie in place to delete again
+	if _, err := uc.references.DeleteAll(context.Background(), movie.ID); err != nil {
+		return nil, err
+	}
 	err = uc.movieRepo.Delete(context.Background(), id)
 	if err != nil {
 		return nil, err
 	}
 
+	// Drop the movie from every list so no list points at a deleted movie
+	if err := uc.listRepo.RemoveMovieFromAll(context.Background(), id); err != nil {
+		return nil, err
+	}
+	uc.activities.ForgetMovie(id)
+	uc.similarity.MovieChanged()
+	if movie.PosterKey != "" {
+		uc.posters.DeletePoster(movie.PosterKey)
+	}
+
 	return &domain.BaseResponse{
 		Success: true,
 		Message: "Movie deleted successfully",
 	}, nil
 }
-func (uc *movieUsecase) GetMovies(page, size int) (*domain.PaginatedResponse, error) {
-	movies, total, err := uc.movieRepo.GetAll(context.Background(), page, size)
+func (uc *movieUsecase) GetMovies(page, size int, sortBy string) (*domain.PaginatedResponse, error) {
+	if sortBy != "" && sortBy != domain.MovieSortPopular {
+		return nil, domain.Validation(domain.CodeInvalidSort, "Invalid sort order", domain.NewErrorDetail(domain.CodeFieldNotOneOf, "sort must be popular", "field", "sort", "values", "popular"))
+	}
+
+	movies, total, err := uc.movieRepo.GetAll(context.Background(), page, size, sortBy)
 	if err != nil {
 		return nil, err
 	}
@@ -169,4 +297,56 @@ func (uc *movieUsecase) GetMovies(page, size int) (*domain.PaginatedResponse, er
 		PageSize:   size,
 		TotalSize:  total,
 	}, nil
-}
\ No newline at end of file
+}
+
+// ResolveRedirect returns the ID a merged-away movie now lives under, or ""
+// when id was never merged.
+func (uc *movieUsecase) ResolveRedirect(id string) (string, error) {
+	redirect, err := uc.redirects.GetByID(context.Background(), id)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, primitive.ErrInvalidHex) {
+			return "", nil
+		}
+		return "", err
+	}
+	return redirect.MovieID.Hex(), nil
+}
+
+// checkCollectionAccess verifies the user may file movies in the collection.
+func (uc *movieUsecase) checkCollectionAccess(collectionID, userID string) (primitive.ObjectID, error) {
+	allowed, err := uc.permissions.CanAddToCollection(collectionID, userID)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	if !allowed {
+		return primitive.NilObjectID, domain.Forbidden(domain.CodeCollectionAddForbidden, "You are not authorized to add movies to this collection")
+	}
+
+	objID, _ := primitive.ObjectIDFromHex(collectionID)
+	return objID, nil
+}
+
+// checkLocales verifies a movie's locale and translations use supported
+// locales, with at most one text per locale.
+func (uc *movieUsecase) checkLocales(locale string, translations []domain.MovieTranslation) []domain.ErrorDetail {
+	var problems []domain.ErrorDetail
+	if !uc.locales.IsSupported(locale) {
+		supported := strings.Join(uc.locales.Supported, ", ")
+		problems = append(problems, domain.NewErrorDetail(domain.CodeLocaleUnsupported, "locale must be one of "+supported,
+			"field", "locale", "locale", locale, "values", supported))
+	}
+
+	seen := map[string]bool{locale: true}
+	for _, translation := range translations {
+		switch {
+		case !uc.locales.IsSupported(translation.Locale):
+			problems = append(problems, domain.NewErrorDetail(domain.CodeLocaleUnsupported, "translation locale "+translation.Locale+" is not supported",
+				"field", "translations", "locale", translation.Locale, "values", strings.Join(uc.locales.Supported, ", ")))
+		case seen[translation.Locale]:
+			problems = append(problems, domain.NewErrorDetail(domain.CodeTranslationDuplicate, "more than one title for locale "+translation.Locale,
+				"locale", translation.Locale))
+		}
+		seen[translation.Locale] = true
+	}
+	return problems
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PermissionService decides what a user may do with a movie. A movie's
+// creator always has full control; when the movie belongs to a shared
+// collection, the collection's members get access according to their role.
+type PermissionService interface {
+	CanEditMovie(movie *domain.Movie, userID string) (bool, error)
+	CanDeleteMovie(movie *domain.Movie, userID string) (bool, error)
+	CanAddToCollection(collectionID, userID string) (bool, error)
+	CanRemoveFromCollection(movie *domain.Movie, userID string) (bool, error)
+}
+
+type permissionService struct {
+	collectionRepo repository.CollectionRepository
+}
+
+func NewPermissionService(collectionRepo repository.CollectionRepository) PermissionService {
+	return &permissionService{collectionRepo: collectionRepo}
+}
+
+// CanEditMovie allows the creator plus the collection's owner and editors.
+func (s *permissionService) CanEditMovie(movie *domain.Movie, userID string) (bool, error) {
+	if movie.UserID.Hex() == userID {
+		return true, nil
+	}
+	if movie.CollectionID.IsZero() {
+		return false, nil
+	}
+
+	role, err := s.roleIn(movie.CollectionID.Hex(), userID)
+	if err != nil {
+		return false, err
+	}
+	return role == domain.CollectionRoleOwner || role == domain.CollectionRoleEditor, nil
+}
+
+// CanDeleteMovie allows the creator and the collection's owner. Editors may
+// change shared movies but not remove other members' entries.
+func (s *permissionService) CanDeleteMovie(movie *domain.Movie, userID string) (bool, error) {
+	if movie.UserID.Hex() == userID {
+		return true, nil
+	}
+	if movie.CollectionID.IsZero() {
+		return false, nil
+	}
+
+	role, err := s.roleIn(movie.CollectionID.Hex(), userID)
+	if err != nil {
+		return false, err
+	}
+	return role == domain.CollectionRoleOwner, nil
+}
+
+// CanAddToCollection allows the collection's owner and editors to file movies in it.
+func (s *permissionService) CanAddToCollection(collectionID, userID string) (bool, error) {
+	role, err := s.roleIn(collectionID, userID)
+	if err != nil {
+		return false, err
+	}
+	return role == domain.CollectionRoleOwner || role == domain.CollectionRoleEditor, nil
+}
+
+// CanRemoveFromCollection allows the creator and the collection's owner to
+// take a movie out of its collection, whether into another one or none.
+// Editors may file movies in a collection but not take them out.
+func (s *permissionService) CanRemoveFromCollection(movie *domain.Movie, userID string) (bool, error) {
+	if movie.UserID.Hex() == userID {
+		return true, nil
+	}
+	if movie.CollectionID.IsZero() {
+		return false, nil
+	}
+
+	role, err := s.roleIn(movie.CollectionID.Hex(), userID)
+	if err != nil {
+		return false, err
+	}
+	return role == domain.CollectionRoleOwner, nil
+}
+
+// roleIn returns the user's role in a collection, or "" if they are not a
+// member or the collection does not exist.
+func (s *permissionService) roleIn(collectionID, userID string) (string, error) {
+	if !primitive.IsValidObjectID(collectionID) {
+		return "", nil
+	}
+
+	collection, err := s.collectionRepo.GetByID(context.Background(), collectionID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return memberRole(collection, userID), nil
+}
+
+func memberRole(collection *domain.Collection, userID string) string {
+	for _, member := range collection.Members {
+		if member.UserID.Hex() == userID {
+			return member.Role
+		}
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRoleRank(t *testing.T) {
+	ordered := []string{"", domain.CollectionRoleViewer, domain.CollectionRoleEditor, domain.CollectionRoleOwner}
+	for i := 1; i < len(ordered); i++ {
+		if roleRank(ordered[i]) <= roleRank(ordered[i-1]) {
+			t.Errorf("roleRank(%q) = %d, want above roleRank(%q) = %d", ordered[i], roleRank(ordered[i]), ordered[i-1], roleRank(ordered[i-1]))
+		}
+	}
+	if roleRank("admin") != roleRank("") {
+		t.Errorf("roleRank of an unknown role = %d, want %d", roleRank("admin"), roleRank(""))
+	}
+}
+
+func TestValidateMemberRole(t *testing.T) {
+	tests := []struct {
+		role  string
+		valid bool
+	}{
+		{role: domain.CollectionRoleEditor, valid: true},
+		{role: domain.CollectionRoleViewer, valid: true},
+		{role: domain.CollectionRoleOwner},
+		{role: ""},
+		{role: "Editor"},
+	}
+
+	for _, tt := range tests {
+		if err := validateMemberRole(tt.role); (err == nil) != tt.valid {
+			t.Errorf("validateMemberRole(%q) = %v, want valid %v", tt.role, err, tt.valid)
+		}
+	}
+}
+
+func TestPermissionService(t *testing.T) {
+	creator := primitive.NewObjectID()
+	owner := primitive.NewObjectID()
+	editor := primitive.NewObjectID()
+	viewer := primitive.NewObjectID()
+	stranger := primitive.NewObjectID()
+
+	shared := &domain.Collection{
+		OwnerID: owner,
+		Members: []domain.CollectionMember{
+			{UserID: owner, Role: domain.CollectionRoleOwner},
+			{UserID: editor, Role: domain.CollectionRoleEditor},
+			{UserID: viewer, Role: domain.CollectionRoleViewer},
+		},
+	}
+	service := NewPermissionService(newFakeCollectionRepo(shared))
+
+	inCollection := &domain.Movie{UserID: creator, CollectionID: shared.ID}
+	personal := &domain.Movie{UserID: creator}
+	inMissingCollection := &domain.Movie{UserID: creator, CollectionID: primitive.NewObjectID()}
+
+	tests := []struct {
+		name      string
+		movie     *domain.Movie
+		user      primitive.ObjectID
+		canEdit   bool
+		canDelete bool
+		canRemove bool
+	}{
+		{name: "creator of a shared movie", movie: inCollection, user: creator, canEdit: true, canDelete: true, canRemove: true},
+		{name: "collection owner", movie: inCollection, user: owner, canEdit: true, canDelete: true, canRemove: true},
+		{name: "collection editor", movie: inCollection, user: editor, canEdit: true},
+		{name: "collection viewer", movie: inCollection, user: viewer},
+		{name: "non-member", movie: inCollection, user: stranger},
+		{name: "creator of a personal movie", movie: personal, user: creator, canEdit: true, canDelete: true, canRemove: true},
+		{name: "collection owner on a personal movie", movie: personal, user: owner},
+		{name: "creator when the collection is gone", movie: inMissingCollection, user: creator, canEdit: true, canDelete: true, canRemove: true},
+		{name: "someone else when the collection is gone", movie: inMissingCollection, user: owner},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canEdit, err := service.CanEditMovie(tt.movie, tt.user.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			canDelete, err := service.CanDeleteMovie(tt.movie, tt.user.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			canRemove, err := service.CanRemoveFromCollection(tt.movie, tt.user.Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if canEdit != tt.canEdit || canDelete != tt.canDelete || canRemove != tt.canRemove {
+				t.Errorf("edit, delete, remove = %v, %v, %v; want %v, %v, %v", canEdit, canDelete, canRemove, tt.canEdit, tt.canDelete, tt.canRemove)
+			}
+		})
+	}
+
+	canAdd := map[primitive.ObjectID]bool{owner: true, editor: true, viewer: false, stranger: false}
+	for user, want := range canAdd {
+		got, err := service.CanAddToCollection(shared.ID.Hex(), user.Hex())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("CanAddToCollection(%s) = %v, want %v", memberRole(shared, user.Hex()), got, want)
+		}
+	}
+	if ok, err := service.CanAddToCollection("not-an-id", owner.Hex()); ok || err != nil {
+		t.Errorf("CanAddToCollection with an invalid ID = %v, %v; want false, nil", ok, err)
+	}
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+
+	// Registered for image.Decode
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// maxPosterPixels bounds the decoded size of an upload, so a small file
+	// cannot claim a huge canvas.
+	maxPosterPixels = 40_000_000
+
+	posterThumbnailQuality = 85
+)
+
+// posterTypes are the accepted upload types and the extension each is
+// stored with.
+var posterTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+var errUnsupportedPoster error = domain.NewCodedError(domain.CodePosterUnsupported, "poster must be a JPEG, PNG or GIF image")
+
+// decodePoster checks an upload's type from its content rather than what
+// the client claims, and decodes it.
+func decodePoster(data []byte) (image.Image, string, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := posterTypes[contentType]; !ok {
+		return nil, "", errUnsupportedPoster
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errUnsupportedPoster
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPosterPixels {
+		return nil, "", domain.NewCodedError(domain.CodePosterTooLarge, "poster dimensions are too large")
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", errUnsupportedPoster
+	}
+	return img, contentType, nil
+}
+
+// posterThumbnail scales img down to width, keeping its aspect ratio, and
+// encodes it as JPEG over a white background. Images narrower than width
+// keep their size.
+func posterThumbnail(img *image.RGBA, width int) ([]byte, error) {
+	bounds := img.Bounds()
+	if width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	var buf bytes.Buffer
+	err := jpeg.Encode(&buf, downscale(img, width, height), &jpeg.Options{Quality: posterThumbnailQuality})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// toRGBA copies img into an RGBA image whose pixels can be read directly.
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// downscale shrinks src to width×height by averaging the source pixels
+// each target pixel covers, flattening transparency onto white.
+func downscale(src *image.RGBA, width, height int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcH/height, (y+1)*srcH/height
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcW/width, (x+1)*srcW/width
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += int(p[0])
+					g += int(p[1])
+					b += int(p[2])
+					a += int(p[3])
+					n++
+				}
+			}
+
+			// Colours are premultiplied, so white shows through by 255-alpha
+			white := 255 - a/n
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r/n + white)
+			dst.Pix[i+1] = uint8(g/n + white)
+			dst.Pix[i+2] = uint8(b/n + white)
+			dst.Pix[i+3] = 255
+		}
+	}
+	return dst
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"image"
+	"log"
+	"regexp"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"github.com/AfomiaTadesse/Afomia_M/backend/storage"
+)
+
+// A poster URL names the upload's directory and one of its files.
+var (
+	posterDirPattern  = regexp.MustCompile(`^[0-9a-f]{24}-[0-9a-f]{32}$`)
+	posterFilePattern = regexp.MustCompile(`^(original\.(jpg|png|gif)|(small|medium|large)\.jpg)$`)
+)
+
+type PosterUsecase interface {
+	UploadPoster(movieID, userID string, data []byte) (*domain.BaseResponse, error)
+	OpenPoster(dir, file string) (*storage.Blob, error)
+	DeletePoster(key string)
+}
+
+type posterUsecase struct {
+	movieRepo   repository.MovieRepository
+	blobs       storage.BlobStore
+	permissions PermissionService
+}
+
+func NewPosterUsecase(movieRepo repository.MovieRepository, blobs storage.BlobStore, permissions PermissionService) PosterUsecase {
+	return &posterUsecase{
+		movieRepo:   movieRepo,
+		blobs:       blobs,
+		permissions: permissions,
+	}
+}
+
+// UploadPoster stores an image as the movie's poster along with its
+// thumbnails, then removes the poster it replaces. Every upload gets new
+// keys, so served posters can be cached indefinitely.
+func (uc *posterUsecase) UploadPoster(movieID, userID string, data []byte) (*domain.BaseResponse, error) {
+	ctx := context.Background()
+
+	movie, err := uc.movieRepo.GetByID(ctx, movieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	allowed, err := uc.permissions.CanEditMovie(movie, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.Forbidden(domain.CodeMovieEditForbidden, "You are not authorized to update this movie")
+	}
+
+	img, contentType, err := decodePoster(data)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidPoster, "Invalid poster image", domain.ErrorDetails(err)...)
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+	key := "posters/" + movie.ID.Hex() + "-" + token + "/original" + posterTypes[contentType]
+
+	if err := uc.storePoster(ctx, key, contentType, data, img); err != nil {
+		uc.DeletePoster(key)
+		return nil, err
+	}
+	if err := uc.movieRepo.SetPoster(ctx, movieID, key); err != nil {
+		uc.DeletePoster(key)
+		return nil, err
+	}
+	if movie.PosterKey != "" {
+		uc.DeletePoster(movie.PosterKey)
+	}
+
+	movie.PosterKey = key
+	movie.Poster = ""
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Poster uploaded successfully",
+		Object:  movie,
+	}, nil
+}
+
+// storePoster writes the original image and a thumbnail of each size.
+func (uc *posterUsecase) storePoster(ctx context.Context, key, contentType string, data []byte, img image.Image) error {
+	if err := uc.blobs.Put(ctx, key, contentType, data); err != nil {
+		return err
+	}
+
+	rgba := toRGBA(img)
+	for size, width := range domain.PosterSizes {
+		thumbnail, err := posterThumbnail(rgba, width)
+		if err != nil {
+			return err
+		}
+		if err := uc.blobs.Put(ctx, domain.PosterThumbnailKey(key, size), "image/jpeg", thumbnail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenPoster opens one file of an uploaded poster. It returns
+// storage.ErrBlobNotFound for names that cannot be a poster.
+func (uc *posterUsecase) OpenPoster(dir, file string) (*storage.Blob, error) {
+	if !posterDirPattern.MatchString(dir) || !posterFilePattern.MatchString(file) {
+		return nil, storage.ErrBlobNotFound
+	}
+	return uc.blobs.Get(context.Background(), "posters/"+dir+"/"+file)
+}
+
+// DeletePoster removes a poster's original and thumbnails. Failures are
+// only logged, leaving at worst an unreferenced blob behind.
+func (uc *posterUsecase) DeletePoster(key string) {
+	keys := []string{key}
+	for size := range domain.PosterSizes {
+		keys = append(keys, domain.PosterThumbnailKey(key, size))
+	}
+	for _, key := range keys {
+		if err := uc.blobs.Delete(context.Background(), key); err != nil && !errors.Is(err, storage.ErrBlobNotFound) {
+			log.Printf("failed to delete poster blob %s: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// ratingNeighbourCount is how many neighbours are kept per movie.
+	ratingNeighbourCount = 50
+
+	// maxRatingsPerUser bounds the pairwise work done for very active users.
+	maxRatingsPerUser = 500
+)
+
+// RatingModelBuilder builds the item-item collaborative filtering model
+// from everyone's ratings. It is meant to run as an offline batch job.
+type RatingModelBuilder interface {
+	Build(ctx context.Context) (int, error)
+}
+
+type ratingModelBuilder struct {
+	reviewRepo repository.ReviewRepository
+	modelRepo  repository.RatingModelRepository
+	minSupport int
+}
+
+// NewRatingModelBuilder creates a builder that ignores movie pairs rated
+// by fewer than minSupport users in common.
+func NewRatingModelBuilder(reviewRepo repository.ReviewRepository, modelRepo repository.RatingModelRepository, minSupport int) RatingModelBuilder {
+	return &ratingModelBuilder{
+		reviewRepo: reviewRepo,
+		modelRepo:  modelRepo,
+		minSupport: minSupport,
+	}
+}
+
+// Build recomputes the model and stores it, returning how many movies have
+// neighbours.
+func (b *ratingModelBuilder) Build(ctx context.Context) (int, error) {
+	started := time.Now()
+
+	ratings, err := b.reviewRepo.GetAllRatings(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	model := buildRatingModel(ratings, b.minSupport, ratingNeighbourCount, started)
+	if err := b.modelRepo.ReplaceAll(ctx, model, started); err != nil {
+		return 0, err
+	}
+	return len(model), nil
+}
+
+type moviePair struct {
+	a, b primitive.ObjectID
+}
+
+// buildRatingModel computes adjusted cosine similarity between movies:
+// each user's ratings are centred on that user's mean first, so a harsh
+// and a generous rater agree when they rank movies the same way.
+func buildRatingModel(ratings []domain.Review, minSupport, k int, computedAt time.Time) []domain.RatingNeighbours {
+	byUser := map[primitive.ObjectID][]domain.Review{}
+	for _, rating := range ratings {
+		byUser[rating.UserID] = append(byUser[rating.UserID], rating)
+	}
+
+	dots := map[moviePair]float64{}
+	support := map[moviePair]int{}
+	norms := map[primitive.ObjectID]float64{}
+
+	for _, userRatings := range byUser {
+		if len(userRatings) < 2 {
+			continue
+		}
+		if len(userRatings) > maxRatingsPerUser {
+			userRatings = userRatings[:maxRatingsPerUser]
+		}
+
+		var mean float64
+		for _, rating := range userRatings {
+			mean += rating.Rating
+		}
+		mean /= float64(len(userRatings))
+
+		centred := make([]float64, len(userRatings))
+		for i, rating := range userRatings {
+			centred[i] = rating.Rating - mean
+			norms[rating.MovieID] += centred[i] * centred[i]
+		}
+
+		for i := range userRatings {
+			for j := i + 1; j < len(userRatings); j++ {
+				pair := moviePair{a: userRatings[i].MovieID, b: userRatings[j].MovieID}
+				if bytes.Compare(pair.a[:], pair.b[:]) > 0 {
+					pair.a, pair.b = pair.b, pair.a
+				}
+				dots[pair] += centred[i] * centred[j]
+				support[pair]++
+			}
+		}
+	}
+
+	neighbours := map[primitive.ObjectID][]domain.RatingNeighbour{}
+	for pair, dot := range dots {
+		if support[pair] < minSupport {
+			continue
+		}
+		norm := math.Sqrt(norms[pair.a] * norms[pair.b])
+		if norm == 0 {
+			continue
+		}
+		score := dot / norm
+		if score <= 0 {
+			continue
+		}
+
+		neighbours[pair.a] = append(neighbours[pair.a], domain.RatingNeighbour{MovieID: pair.b, Score: score, Support: support[pair]})
+		neighbours[pair.b] = append(neighbours[pair.b], domain.RatingNeighbour{MovieID: pair.a, Score: score, Support: support[pair]})
+	}
+
+	model := make([]domain.RatingNeighbours, 0, len(neighbours))
+	for movieID, row := range neighbours {
+		sort.Slice(row, func(i, j int) bool {
+			if row[i].Score != row[j].Score {
+				return row[i].Score > row[j].Score
+			}
+			return row[i].Support > row[j].Support
+		})
+		if len(row) > k {
+			row = row[:k]
+		}
+		model = append(model, domain.RatingNeighbours{
+			MovieID:    movieID,
+			Neighbours: row,
+			ComputedAt: computedAt,
+		})
+	}
+	return model
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// rated is one user's ratings, by movie index.
+type rated map[int]float64
+
+func ratingsOf(movies []primitive.ObjectID, users ...rated) []domain.Review {
+	var ratings []domain.Review
+	for _, user := range users {
+		userID := primitive.NewObjectID()
+		for movie, rating := range user {
+			ratings = append(ratings, domain.Review{UserID: userID, MovieID: movies[movie], Rating: rating})
+		}
+	}
+	return ratings
+}
+
+func objectIDs(n int) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, n)
+	for i := range ids {
+		ids[i] = primitive.NewObjectID()
+	}
+	return ids
+}
+
+func TestBuildRatingModel(t *testing.T) {
+	type neighbour struct {
+		movie   int // -1 for either of two equally scored movies
+		score   float64
+		support int
+	}
+	// Centred on each user's mean, the first two users rate movies 0 and 1
+	// alike and movie 2 the other way. The third rates movie 3 as well,
+	// which brings it close to 0 and 1.
+	agree := []rated{{0: 5, 1: 5, 2: 2}, {0: 4, 1: 4, 2: 1}}
+	tests := []struct {
+		name       string
+		users      []rated
+		minSupport int
+		k          int
+		want       map[int][]neighbour
+	}{
+		{
+			name:       "movies rated alike are neighbours and opposites are not",
+			users:      agree,
+			minSupport: 1,
+			k:          10,
+			want:       map[int][]neighbour{0: {{1, 1, 2}}, 1: {{0, 1, 2}}},
+		},
+		{
+			name:       "a single rating tells nothing",
+			users:      append([]rated{{0: 1}, {1: 5}}, agree...),
+			minSupport: 1,
+			k:          10,
+			want:       map[int][]neighbour{0: {{1, 1, 2}}, 1: {{0, 1, 2}}},
+		},
+		{
+			name:       "pairs below the minimum support are dropped",
+			users:      agree,
+			minSupport: 3,
+			k:          10,
+			want:       map[int][]neighbour{},
+		},
+		{
+			name:       "a user who rates everything the same adds nothing",
+			users:      []rated{{0: 3, 1: 3, 2: 3}},
+			minSupport: 1,
+			k:          10,
+			want:       map[int][]neighbour{},
+		},
+		{
+			name:       "neighbours are ordered best first",
+			users:      []rated{{0: 5, 1: 5, 2: 3, 3: 1}, {0: 5, 1: 5, 2: 5, 3: 1}},
+			minSupport: 1,
+			k:          10,
+			want: map[int][]neighbour{
+				0: {{1, 1, 2}, {2, 0.25 / math.Sqrt(3.25*1.25), 2}},
+				1: {{0, 1, 2}, {2, 0.25 / math.Sqrt(3.25*1.25), 2}},
+				2: {{-1, 0.25 / math.Sqrt(3.25*1.25), 2}, {-1, 0.25 / math.Sqrt(3.25*1.25), 2}},
+			},
+		},
+		{
+			name:       "only the best k are kept",
+			users:      []rated{{0: 5, 1: 5, 2: 3, 3: 1}, {0: 5, 1: 5, 2: 5, 3: 1}},
+			minSupport: 1,
+			k:          1,
+			want: map[int][]neighbour{
+				0: {{1, 1, 2}},
+				1: {{0, 1, 2}},
+				2: {{-1, 0.25 / math.Sqrt(3.25*1.25), 2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movies := objectIDs(4)
+			index := map[primitive.ObjectID]int{}
+			for i, id := range movies {
+				index[id] = i
+			}
+			computedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			model := buildRatingModel(ratingsOf(movies, tt.users...), tt.minSupport, tt.k, computedAt)
+			if len(model) != len(tt.want) {
+				t.Fatalf("model has %d rows, want %d", len(model), len(tt.want))
+			}
+			for _, row := range model {
+				movie := index[row.MovieID]
+				want, ok := tt.want[movie]
+				if !ok {
+					t.Errorf("unexpected row for movie %d", movie)
+					continue
+				}
+				if !row.ComputedAt.Equal(computedAt) {
+					t.Errorf("movie %d computed at %v, want %v", movie, row.ComputedAt, computedAt)
+				}
+				if len(row.Neighbours) != len(want) {
+					t.Errorf("movie %d has %d neighbours, want %d", movie, len(row.Neighbours), len(want))
+					continue
+				}
+				for i, got := range row.Neighbours {
+					if math.Abs(got.Score-want[i].score) > 1e-9 || got.Support != want[i].support {
+						t.Errorf("movie %d neighbour %d = %v (support %d), want %v (support %d)", movie, i, got.Score, got.Support, want[i].score, want[i].support)
+					}
+					if want[i].movie >= 0 && index[got.MovieID] != want[i].movie {
+						t.Errorf("movie %d neighbour %d is movie %d, want %d", movie, i, index[got.MovieID], want[i].movie)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCollaborativeCandidates(t *testing.T) {
+	movies := objectIDs(5)
+	// The user rates movie 0 a point over their mean and movie 1 a point
+	// under it.
+	user := primitive.NewObjectID()
+	ratings := []domain.Review{
+		{UserID: user, MovieID: movies[0], Rating: 5},
+		{UserID: user, MovieID: movies[1], Rating: 3},
+	}
+	models := &fakeRatingModelRepo{rows: []domain.RatingNeighbours{
+		{MovieID: movies[0], Neighbours: []domain.RatingNeighbour{{MovieID: movies[2], Score: 0.8}, {MovieID: movies[4], Score: 0.9}}},
+		{MovieID: movies[1], Neighbours: []domain.RatingNeighbour{{MovieID: movies[2], Score: 0.2}, {MovieID: movies[3], Score: 0.5}}},
+	}}
+	uc := &recommendationUsecase{modelRepo: models}
+
+	candidates, err := uc.collaborativeCandidates(ratings, map[primitive.ObjectID]bool{movies[4]: true}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Movie 2 is predicted 4 + (0.8*1 + 0.2*-1) / (0.8+0.2). Movie 3 is
+	// only near a movie the user disliked, and movie 4 was already seen.
+	if len(candidates) != 1 || candidates[0].movieID != movies[2] {
+		t.Fatalf("got %d candidates, want movie 2 alone", len(candidates))
+	}
+	got := candidates[0]
+	if math.Abs(got.score-4.6) > 1e-9 || got.source != domain.RecommendationCollaborative {
+		t.Errorf("candidate = %v from %q, want 4.6 from %q", got.score, got.source, domain.RecommendationCollaborative)
+	}
+	if len(got.because) != 1 || got.because[0].movieID != movies[0] {
+		t.Errorf("candidate is explained by %d movies, want movie 0 alone", len(got.because))
+	}
+
+	if candidates, err := uc.collaborativeCandidates(nil, nil, 10); err != nil || len(candidates) != 0 {
+		t.Errorf("with no ratings got %d candidates (err %v), want none", len(candidates), err)
+	}
+}
+
+func TestLikedSeeds(t *testing.T) {
+	movies := objectIDs(4)
+	ratings := []domain.Review{
+		{MovieID: movies[0], Rating: likedRating},
+		{MovieID: movies[1], Rating: 2},
+		{MovieID: movies[2], Rating: 5},
+	}
+	likes := []domain.Like{{MovieID: movies[3]}, {MovieID: movies[2]}}
+
+	seeds := likedSeeds(ratings, likes)
+	want := []primitive.ObjectID{movies[2], movies[0], movies[3]}
+	if len(seeds) != len(want) {
+		t.Fatalf("got %d seeds, want %d", len(seeds), len(want))
+	}
+	for i := range want {
+		if seeds[i] != want[i] {
+			t.Errorf("seed %d = %s, want %s", i, seeds[i].Hex(), want[i].Hex())
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultSimilarLimit        = 10
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 50
+
+	// likedRating is the rating at or above which a movie counts as liked
+	// when seeding content-based suggestions.
+	likedRating = 3.5
+
+	// maxLikeSeeds caps how many liked movies seed content-based suggestions.
+	maxLikeSeeds = 100
+
+	// maxExplanations caps the "because you liked" movies per suggestion.
+	maxExplanations = 3
+)
+
+type RecommendationUsecase interface {
+	GetSimilarMovies(movieID string, limit int) (*domain.BaseResponse, error)
+	GetRecommendations(userID string, limit int) (*domain.BaseResponse, error)
+}
+
+type recommendationUsecase struct {
+	similarRepo repository.SimilarityRepository
+	modelRepo   repository.RatingModelRepository
+	movieRepo   repository.MovieRepository
+	reviewRepo  repository.ReviewRepository
+	diaryRepo   repository.DiaryRepository
+	likeRepo    repository.LikeRepository
+}
+
+func NewRecommendationUsecase(similarRepo repository.SimilarityRepository, modelRepo repository.RatingModelRepository, movieRepo repository.MovieRepository, reviewRepo repository.ReviewRepository, diaryRepo repository.DiaryRepository, likeRepo repository.LikeRepository) RecommendationUsecase {
+	return &recommendationUsecase{
+		similarRepo: similarRepo,
+		modelRepo:   modelRepo,
+		movieRepo:   movieRepo,
+		reviewRepo:  reviewRepo,
+		diaryRepo:   diaryRepo,
+		likeRepo:    likeRepo,
+	}
+}
+
+// candidate is a movie being considered for a user, with the movies they
+// liked that led to it.
+type candidate struct {
+	movieID primitive.ObjectID
+	score   float64
+	source  string
+	because []weightedMovie
+}
+
+type weightedMovie struct {
+	movieID primitive.ObjectID
+	weight  float64
+}
+
+// GetSimilarMovies serves a movie's precomputed neighbours. A movie added
+// since the last rebuild has none yet and gets an empty list.
+func (uc *recommendationUsecase) GetSimilarMovies(movieID string, limit int) (*domain.BaseResponse, error) {
+	if limit <= 0 {
+		limit = defaultSimilarLimit
+	}
+	if limit > similarNeighbourCount {
+		limit = similarNeighbourCount
+	}
+
+	if _, err := uc.movieRepo.GetByID(context.Background(), movieID); err != nil {
+		return nil, domain.NotFound(domain.CodeMovieNotFound, "Movie not found")
+	}
+
+	results := []domain.ScoredMovie{}
+
+	similar, err := uc.similarRepo.GetByMovieID(context.Background(), movieID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if similar != nil {
+		neighbours := similar.Neighbours
+		if len(neighbours) > limit {
+			neighbours = neighbours[:limit]
+		}
+
+		ids := make([]string, 0, len(neighbours))
+		for _, neighbour := range neighbours {
+			ids = append(ids, neighbour.MovieID.Hex())
+		}
+		movies, err := uc.movieRepo.GetByIDs(context.Background(), ids)
+		if err != nil {
+			return nil, err
+		}
+		moviesByID := make(map[string]domain.Movie, len(movies))
+		for _, movie := range movies {
+			moviesByID[movie.ID.Hex()] = movie
+		}
+
+		// Keep the ranking order and skip movies deleted since the rebuild
+		for _, neighbour := range neighbours {
+			if movie, ok := moviesByID[neighbour.MovieID.Hex()]; ok {
+				results = append(results, domain.ScoredMovie{Movie: movie, Score: neighbour.Score})
+			}
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Similar movies retrieved successfully",
+		Object:  results,
+	}, nil
+}
+
+// GetRecommendations suggests movies the user has not rated, logged or
+// liked. Suggestions come from the collaborative filtering model first;
+// when it cannot fill the list, for example for a user with few ratings or
+// a model built from little data, content-based neighbours of the movies
+// they liked make up the rest.
+func (uc *recommendationUsecase) GetRecommendations(userID string, limit int) (*domain.BaseResponse, error) {
+	if !primitive.IsValidObjectID(userID) {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID")
+	}
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit > maxRecommendationLimit {
+		limit = maxRecommendationLimit
+	}
+
+	ratings, err := uc.reviewRepo.GetAllByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+	diary, err := uc.diaryRepo.GetAllByUserID(context.Background(), userID, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	likes, _, err := uc.likeRepo.GetByUserID(context.Background(), userID, 1, maxLikeSeeds)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[primitive.ObjectID]bool{}
+	for _, rating := range ratings {
+		seen[rating.MovieID] = true
+	}
+	for _, entry := range diary {
+		seen[entry.MovieID] = true
+	}
+	for _, like := range likes {
+		seen[like.MovieID] = true
+	}
+
+	candidates, err := uc.collaborativeCandidates(ratings, seen, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) < limit {
+		exclude := make(map[primitive.ObjectID]bool, len(seen)+len(candidates))
+		for movieID := range seen {
+			exclude[movieID] = true
+		}
+		for _, c := range candidates {
+			exclude[c.movieID] = true
+		}
+
+		content, err := uc.contentCandidates(likedSeeds(ratings, likes), exclude, limit-len(candidates))
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, content...)
+	}
+
+	recommendations, err := uc.resolveCandidates(candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Recommendations retrieved successfully",
+		Object:  recommendations,
+	}, nil
+}
+
+// collaborativeCandidates predicts the user's rating for the neighbours of
+// the movies they rated, as their mean rating plus the similarity-weighted
+// average of how far each rated movie was from that mean. Only movies
+// predicted above the user's mean are suggested.
+func (uc *recommendationUsecase) collaborativeCandidates(ratings []domain.Review, seen map[primitive.ObjectID]bool, limit int) ([]candidate, error) {
+	if len(ratings) == 0 {
+		return nil, nil
+	}
+
+	var mean float64
+	ratedIDs := make([]primitive.ObjectID, 0, len(ratings))
+	ratingByMovie := make(map[primitive.ObjectID]float64, len(ratings))
+	for _, rating := range ratings {
+		mean += rating.Rating
+		ratedIDs = append(ratedIDs, rating.MovieID)
+		ratingByMovie[rating.MovieID] = rating.Rating
+	}
+	mean /= float64(len(ratings))
+
+	rows, err := uc.modelRepo.GetByMovieIDs(context.Background(), ratedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	numerators := map[primitive.ObjectID]float64{}
+	denominators := map[primitive.ObjectID]float64{}
+	because := map[primitive.ObjectID][]weightedMovie{}
+	for _, row := range rows {
+		deviation := ratingByMovie[row.MovieID] - mean
+		for _, neighbour := range row.Neighbours {
+			if seen[neighbour.MovieID] {
+				continue
+			}
+			numerators[neighbour.MovieID] += neighbour.Score * deviation
+			denominators[neighbour.MovieID] += neighbour.Score
+			if deviation > 0 {
+				because[neighbour.MovieID] = append(because[neighbour.MovieID], weightedMovie{movieID: row.MovieID, weight: neighbour.Score * deviation})
+			}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(numerators))
+	for movieID, numerator := range numerators {
+		if numerator <= 0 {
+			continue
+		}
+		predicted := mean + numerator/denominators[movieID]
+		if predicted > 5 {
+			predicted = 5
+		}
+		candidates = append(candidates, candidate{
+			movieID: movieID,
+			score:   predicted,
+			source:  domain.RecommendationCollaborative,
+			because: because[movieID],
+		})
+	}
+
+	return topCandidates(candidates, limit), nil
+}
+
+// contentCandidates sums the content similarity of unseen movies to the
+// movies the user liked.
+func (uc *recommendationUsecase) contentCandidates(seeds []primitive.ObjectID, exclude map[primitive.ObjectID]bool, limit int) ([]candidate, error) {
+	if len(seeds) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	rows, err := uc.similarRepo.GetByMovieIDs(context.Background(), seeds)
+	if err != nil {
+		return nil, err
+	}
+
+	byMovie := map[primitive.ObjectID]*candidate{}
+	for _, row := range rows {
+		for _, neighbour := range row.Neighbours {
+			if exclude[neighbour.MovieID] {
+				continue
+			}
+			c, ok := byMovie[neighbour.MovieID]
+			if !ok {
+				c = &candidate{movieID: neighbour.MovieID, source: domain.RecommendationContent}
+				byMovie[neighbour.MovieID] = c
+			}
+			c.score += neighbour.Score
+			c.because = append(c.because, weightedMovie{movieID: row.MovieID, weight: neighbour.Score})
+		}
+	}
+
+	candidates := make([]candidate, 0, len(byMovie))
+	for _, c := range byMovie {
+		candidates = append(candidates, *c)
+	}
+	return topCandidates(candidates, limit), nil
+}
+
+// resolveCandidates loads the suggested movies and the movies that explain
+// them, dropping suggestions whose movie has since been deleted.
+func (uc *recommendationUsecase) resolveCandidates(candidates []candidate) ([]domain.Recommendation, error) {
+	var ids []string
+	for _, c := range candidates {
+		ids = append(ids, c.movieID.Hex())
+		for _, reason := range c.because {
+			ids = append(ids, reason.movieID.Hex())
+		}
+	}
+
+	movies, err := uc.movieRepo.GetByIDs(context.Background(), ids)
+	if err != nil {
+		return nil, err
+	}
+	moviesByID := make(map[primitive.ObjectID]domain.Movie, len(movies))
+	for _, movie := range movies {
+		moviesByID[movie.ID] = movie
+	}
+
+	recommendations := make([]domain.Recommendation, 0, len(candidates))
+	for _, c := range candidates {
+		movie, ok := moviesByID[c.movieID]
+		if !ok {
+			continue
+		}
+
+		liked := []domain.MovieSummary{}
+		for _, reason := range c.because {
+			if seed, ok := moviesByID[reason.movieID]; ok {
+				liked = append(liked, domain.MovieSummary{ID: seed.ID.Hex(), Title: seed.Title})
+			}
+		}
+
+		recommendations = append(recommendations, domain.Recommendation{
+			Movie:           movie,
+			Score:           c.score,
+			Source:          c.source,
+			Explanation:     explainRecommendation(liked),
+			BecauseYouLiked: liked,
+		})
+	}
+	return recommendations, nil
+}
+
+// topCandidates orders candidates best first, keeps at most limit, and
+// trims each one's reasons to the strongest few.
+func topCandidates(candidates []candidate, limit int) []candidate {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].score != candidates[j].score {
+			return candidates[i].score > candidates[j].score
+		}
+		return candidates[i].movieID.Hex() < candidates[j].movieID.Hex()
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	for i := range candidates {
+		because := candidates[i].because
+		sort.Slice(because, func(a, b int) bool {
+			return because[a].weight > because[b].weight
+		})
+		if len(because) > maxExplanations {
+			candidates[i].because = because[:maxExplanations]
+		}
+	}
+	return candidates
+}
+
+// likedSeeds returns the movies the user rated well, best first, followed
+// by the movies they liked.
+func likedSeeds(ratings []domain.Review, likes []domain.Like) []primitive.ObjectID {
+	rated := make([]domain.Review, 0, len(ratings))
+	for _, rating := range ratings {
+		if rating.Rating >= likedRating {
+			rated = append(rated, rating)
+		}
+	}
+	sort.SliceStable(rated, func(i, j int) bool {
+		return rated[i].Rating > rated[j].Rating
+	})
+
+	added := map[primitive.ObjectID]bool{}
+	seeds := make([]primitive.ObjectID, 0, len(rated)+len(likes))
+	for _, rating := range rated {
+		added[rating.MovieID] = true
+		seeds = append(seeds, rating.MovieID)
+	}
+	for _, like := range likes {
+		if !added[like.MovieID] {
+			added[like.MovieID] = true
+			seeds = append(seeds, like.MovieID)
+		}
+	}
+	return seeds
+}
+
+// explainRecommendation phrases "Because you liked A, B and C".
+func explainRecommendation(liked []domain.MovieSummary) string {
+	if len(liked) == 0 {
+		return ""
+	}
+
+	titles := make([]string, len(liked))
+	for i, movie := range liked {
+		titles[i] = movie.Title
+	}
+	if len(titles) == 1 {
+		return "Because you liked " + titles[0]
+	}
+	return "Because you liked " + strings.Join(titles[:len(titles)-1], ", ") + " and " + titles[len(titles)-1]
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ReviewUsecase interface {
+	CreateReview(req *domain.CreateReviewRequest) (*domain.BaseResponse, error)
+	UpdateReview(movieID, userID string, req *domain.UpdateReviewRequest) (*domain.BaseResponse, error)
+	DeleteReview(movieID, userID string) (*domain.BaseResponse, error)
+	GetMovieReviews(movieID string, page, size int) (*domain.PaginatedResponse, error)
+}
+
+type reviewUsecase struct {
+	reviewRepo repository.ReviewRepository
+	movieRepo  repository.MovieRepository
+	activities ActivityRecorder
+	events     EventWriter
+}
+
+func NewReviewUsecase(reviewRepo repository.ReviewRepository, movieRepo repository.MovieRepository, activities ActivityRecorder, events EventWriter) ReviewUsecase {
+	return &reviewUsecase{
+		reviewRepo: reviewRepo,
+		movieRepo:  movieRepo,
+		activities: activities,
+		events:     events,
+	}
+}
+
+func (uc *reviewUsecase) CreateReview(req *domain.CreateReviewRequest) (*domain.BaseResponse, error) {
+	if err := validateRating(req.Rating); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	movie, err := uc.movieRepo.GetByID(context.Background(), req.MovieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	now := time.Now()
+	review := &domain.Review{
+		UserID:    userID,
+		MovieID:   movie.ID,
+		Rating:    req.Rating,
+		Text:      req.Text,
+		Spoiler:   req.Spoiler,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.reviewRepo.Create(context.Background(), review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.Conflict(domain.CodeAlreadyReviewed, "You have already reviewed this movie")
+		}
+		return nil, err
+	}
+
+	if err := refreshMovieRating(context.Background(), uc.reviewRepo, uc.movieRepo, req.MovieID); err != nil {
+		return nil, err
+	}
+
+	uc.activities.Record(domain.ActivityMovieReviewed, req.UserID, movie.ID, &review.ID)
+	uc.events.Record(movie.ID, domain.MovieEventReview)
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Review created successfully",
+		Object:  review,
+	}, nil
+}
+
+func (uc *reviewUsecase) UpdateReview(movieID, userID string, req *domain.UpdateReviewRequest) (*domain.BaseResponse, error) {
+	if err := validateRating(req.Rating); err != nil {
+		return nil, domain.Validation(domain.CodeValidationFailed, "Validation failed", domain.ErrorDetails(err)...)
+	}
+
+	// Users can only reach their own review, so ownership is implied by the lookup
+	review, err := uc.reviewRepo.GetByUserAndMovie(context.Background(), userID, movieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeReviewNotFound, "Review not found"))
+	}
+
+	review.Rating = req.Rating
+	review.Text = req.Text
+	review.Spoiler = req.Spoiler
+	review.UpdatedAt = time.Now()
+
+	if err := uc.reviewRepo.Update(context.Background(), review.ID.Hex(), review); err != nil {
+		return nil, err
+	}
+
+	if err := refreshMovieRating(context.Background(), uc.reviewRepo, uc.movieRepo, movieID); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Review updated successfully",
+		Object:  review,
+	}, nil
+}
+
+func (uc *reviewUsecase) DeleteReview(movieID, userID string) (*domain.BaseResponse, error) {
+	review, err := uc.reviewRepo.GetByUserAndMovie(context.Background(), userID, movieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeReviewNotFound, "Review not found"))
+	}
+
+	if err := uc.reviewRepo.Delete(context.Background(), review.ID.Hex()); err != nil {
+		return nil, err
+	}
+
+	if err := refreshMovieRating(context.Background(), uc.reviewRepo, uc.movieRepo, movieID); err != nil {
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Review deleted successfully",
+	}, nil
+}
+
+func (uc *reviewUsecase) GetMovieReviews(movieID string, page, size int) (*domain.PaginatedResponse, error) {
+	if !primitive.IsValidObjectID(movieID) {
+		return nil, domain.NotFound(domain.CodeMovieNotFound, "Movie not found")
+	}
+	if _, err := uc.movieRepo.GetByID(context.Background(), movieID); err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	reviews, total, err := uc.reviewRepo.GetByMovieID(context.Background(), movieID, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Reviews retrieved successfully",
+		Object:     reviews,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
+
+// refreshMovieRating recomputes the movie's aggregate from its reviews rather
+// than adjusting it incrementally, so errors never accumulate. Two
+// concurrent recomputes can still save out of order and leave a stale
+// aggregate, which the next review write corrects. Everything that adds or
+// removes reviews refreshes the movies it touched this way.
+func refreshMovieRating(ctx context.Context, reviewRepo repository.ReviewRepository, movieRepo repository.MovieRepository, movieID string) error {
+	average, count, err := reviewRepo.AggregateRating(ctx, movieID)
+	if err != nil {
+		return err
+	}
+	return movieRepo.UpdateRating(ctx, movieID, average, count)
+}
+
+func validateRating(rating float64) error {
+	if rating < 0.5 || rating > 5 {
+		return domain.NewCodedError(domain.CodeRatingOutOfRange, "rating must be between 0.5 and 5")
+	}
+	if math.Mod(rating*2, 1) != 0 {
+		return domain.NewCodedError(domain.CodeRatingHalfStep, "rating must be in half-star steps")
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestValidateRating(t *testing.T) {
+	tests := []struct {
+		rating float64
+		code   string
+	}{
+		{rating: 0.5},
+		{rating: 3},
+		{rating: 4.5},
+		{rating: 5},
+		{rating: 0, code: domain.CodeRatingOutOfRange},
+		{rating: 0.4, code: domain.CodeRatingOutOfRange},
+		{rating: 5.5, code: domain.CodeRatingOutOfRange},
+		{rating: -1, code: domain.CodeRatingOutOfRange},
+		{rating: 3.25, code: domain.CodeRatingHalfStep},
+		{rating: 4.1, code: domain.CodeRatingHalfStep},
+	}
+
+	for _, tt := range tests {
+		err := validateRating(tt.rating)
+		if code := errorCode(err); code != tt.code {
+			t.Errorf("validateRating(%v) code = %q, want %q", tt.rating, code, tt.code)
+		}
+	}
+}
+
+func TestReviewsKeepMovieRatingInStep(t *testing.T) {
+	type step struct {
+		user   int
+		rating float64
+		delete bool
+	}
+	tests := []struct {
+		name    string
+		steps   []step
+		average float64
+		count   int64
+	}{
+		{
+			name:    "one review",
+			steps:   []step{{user: 0, rating: 4}},
+			average: 4,
+			count:   1,
+		},
+		{
+			name:    "reviews from several users",
+			steps:   []step{{user: 0, rating: 4}, {user: 1, rating: 2.5}, {user: 2, rating: 5}},
+			average: 11.5 / 3,
+			count:   3,
+		},
+		{
+			name:    "a changed rating replaces the old one",
+			steps:   []step{{user: 0, rating: 1}, {user: 1, rating: 3}, {user: 0, rating: 5}},
+			average: 4,
+			count:   2,
+		},
+		{
+			name:    "a deleted review no longer counts",
+			steps:   []step{{user: 0, rating: 1}, {user: 1, rating: 3}, {user: 0, delete: true}},
+			average: 3,
+			count:   1,
+		},
+		{
+			name:    "deleting the last review clears the rating",
+			steps:   []step{{user: 0, rating: 2}, {user: 0, delete: true}},
+			average: 0,
+			count:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movie := &domain.Movie{Title: "Heat"}
+			movieRepo := newFakeMovieRepo(movie)
+			uc := NewReviewUsecase(&fakeReviewRepo{}, movieRepo, nopActivities{}, nopEvents{})
+			users := []string{primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()}
+			movieID := movie.ID.Hex()
+
+			reviewed := map[int]bool{}
+			for _, s := range tt.steps {
+				var err error
+				switch {
+				case s.delete:
+					_, err = uc.DeleteReview(movieID, users[s.user])
+					reviewed[s.user] = false
+				case reviewed[s.user]:
+					_, err = uc.UpdateReview(movieID, users[s.user], &domain.UpdateReviewRequest{Rating: s.rating})
+				default:
+					_, err = uc.CreateReview(&domain.CreateReviewRequest{Rating: s.rating, UserID: users[s.user], MovieID: movieID})
+					reviewed[s.user] = true
+				}
+				if err != nil {
+					t.Fatalf("step %+v: %v", s, err)
+				}
+			}
+
+			if movie.AverageRating != tt.average || movie.RatingCount != tt.count {
+				t.Errorf("rating = %v over %d reviews, want %v over %d", movie.AverageRating, movie.RatingCount, tt.average, tt.count)
+			}
+		})
+	}
+}
+
+func TestCreateReviewTwiceIsAConflict(t *testing.T) {
+	movie := &domain.Movie{Title: "Heat"}
+	uc := NewReviewUsecase(&fakeReviewRepo{}, newFakeMovieRepo(movie), nopActivities{}, nopEvents{})
+	req := &domain.CreateReviewRequest{Rating: 4, UserID: primitive.NewObjectID().Hex(), MovieID: movie.ID.Hex()}
+
+	if _, err := uc.CreateReview(req); err != nil {
+		t.Fatalf("first review: %v", err)
+	}
+	_, err := uc.CreateReview(req)
+	if code := errorCode(err); code != domain.CodeAlreadyReviewed {
+		t.Errorf("second review code = %q, want %q", code, domain.CodeAlreadyReviewed)
+	}
+}
+
+func TestGetMovieReviews(t *testing.T) {
+	movie := &domain.Movie{Title: "Heat"}
+	movies := newFakeMovieRepo(movie)
+	reviews := &fakeReviewRepo{reviews: []*domain.Review{{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), MovieID: movie.ID, Rating: 4}}}
+	uc := NewReviewUsecase(reviews, movies, nopActivities{}, nopEvents{})
+
+	response, err := uc.GetMovieReviews(movie.ID.Hex(), 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.TotalSize != 1 {
+		t.Errorf("%d reviews, want 1", response.TotalSize)
+	}
+
+	for _, movieID := range []string{primitive.NewObjectID().Hex(), "heat"} {
+		_, err := uc.GetMovieReviews(movieID, 1, 10)
+		if code := errorCode(err); code != domain.CodeMovieNotFound {
+			t.Errorf("GetMovieReviews(%q) code = %q, want %q", movieID, code, domain.CodeMovieNotFound)
+		}
+	}
+}
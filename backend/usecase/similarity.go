@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+const (
+	// similarNeighbourCount is how many neighbours are kept per movie.
+	similarNeighbourCount = 20
+
+	// maxCandidatePostings stops very common terms (a popular genre, say)
+	// from making every movie a candidate for every other. Such terms still
+	// count towards the score of candidates found through rarer terms.
+	maxCandidatePostings = 1000
+)
+
+// similarityField is one facet movies are compared on. Each facet becomes
+// a TF-IDF vector and contributes its cosine similarity times weight.
+type similarityField struct {
+	weight float64
+	terms  func(movie *domain.Movie) map[string]float64
+}
+
+var similarityFields = []similarityField{
+	{weight: 0.35, terms: func(movie *domain.Movie) map[string]float64 { return nameTerms(movie.Genres) }},
+	{weight: 0.25, terms: func(movie *domain.Movie) map[string]float64 { return nameTerms(movie.Actors) }},
+	{weight: 0.15, terms: func(movie *domain.Movie) map[string]float64 { return nameTerms(movie.Crew) }},
+	{weight: 0.25, terms: func(movie *domain.Movie) map[string]float64 {
+		return keywordTerms(movie.Title + " " + movie.Description)
+	}},
+}
+
+// stopWords are left out of description keywords.
+var stopWords = map[string]bool{
+	"and": true, "the": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "his": true, "her": true, "their": true, "they": true, "them": true,
+	"into": true, "who": true, "when": true, "where": true, "while": true, "after": true,
+	"before": true, "about": true, "but": true, "are": true, "was": true, "were": true,
+	"has": true, "have": true, "had": true, "its": true, "one": true, "all": true,
+	"out": true, "not": true, "him": true, "she": true, "what": true, "which": true,
+}
+
+// rankSimilarMovies computes the nearest neighbours of every movie in the
+// catalogue, keeping at most k per movie.
+func rankSimilarMovies(movies []domain.Movie, k int, computedAt time.Time) []domain.SimilarMovies {
+	vectors := make([][]map[string]float64, len(movies))
+	for i := range movies {
+		vectors[i] = make([]map[string]float64, len(similarityFields))
+	}
+
+	postings := make([]map[string][]int, len(similarityFields))
+	for f, field := range similarityFields {
+		postings[f] = map[string][]int{}
+		for i := range movies {
+			vectors[i][f] = field.terms(&movies[i])
+			for term := range vectors[i][f] {
+				postings[f][term] = append(postings[f][term], i)
+			}
+		}
+
+		// Weight by inverse document frequency, then normalize so the dot
+		// product of two vectors is their cosine similarity.
+		n := float64(len(movies))
+		for i := range movies {
+			var norm float64
+			for term, tf := range vectors[i][f] {
+				weight := tf * math.Log(1+n/float64(len(postings[f][term])))
+				vectors[i][f][term] = weight
+				norm += weight * weight
+			}
+			norm = math.Sqrt(norm)
+			for term := range vectors[i][f] {
+				vectors[i][f][term] /= norm
+			}
+		}
+	}
+
+	results := make([]domain.SimilarMovies, 0, len(movies))
+	for i := range movies {
+		candidates := map[int]bool{}
+		for f := range similarityFields {
+			for term := range vectors[i][f] {
+				if len(postings[f][term]) > maxCandidatePostings {
+					continue
+				}
+				for _, j := range postings[f][term] {
+					if j != i {
+						candidates[j] = true
+					}
+				}
+			}
+		}
+
+		neighbours := make([]domain.SimilarMovie, 0, len(candidates))
+		for j := range candidates {
+			var score float64
+			for f, field := range similarityFields {
+				score += field.weight * dot(vectors[i][f], vectors[j][f])
+			}
+			if score > 0 {
+				neighbours = append(neighbours, domain.SimilarMovie{MovieID: movies[j].ID, Score: score})
+			}
+		}
+
+		sort.Slice(neighbours, func(a, b int) bool {
+			if neighbours[a].Score != neighbours[b].Score {
+				return neighbours[a].Score > neighbours[b].Score
+			}
+			return neighbours[a].MovieID.Hex() < neighbours[b].MovieID.Hex()
+		})
+		if len(neighbours) > k {
+			neighbours = neighbours[:k]
+		}
+
+		results = append(results, domain.SimilarMovies{
+			MovieID:    movies[i].ID,
+			Neighbours: neighbours,
+			ComputedAt: computedAt,
+		})
+	}
+	return results
+}
+
+func dot(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	var sum float64
+	for term, weight := range a {
+		sum += weight * b[term]
+	}
+	return sum
+}
+
+// nameTerms turns a list of names (genres, people) into a set of terms.
+func nameTerms(names []string) map[string]float64 {
+	terms := make(map[string]float64, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			terms[name] = 1
+		}
+	}
+	return terms
+}
+
+// keywordTerms counts the meaningful words in text, damping repeats.
+func keywordTerms(text string) map[string]float64 {
+	counts := map[string]int{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		counts[word]++
+	}
+
+	terms := make(map[string]float64, len(counts))
+	for word, count := range counts {
+		terms[word] = 1 + math.Log(float64(count))
+	}
+	return terms
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+)
+
+// SimilarityIndexer keeps the precomputed similar-movie lists up to date.
+// Movie changes only mark the index stale; the rebuild happens in Run, off
+// the request path.
+type SimilarityIndexer interface {
+	MovieChanged()
+	Run(ctx context.Context)
+}
+
+type similarityIndexer struct {
+	movieRepo   repository.MovieRepository
+	similarRepo repository.SimilarityRepository
+	interval    time.Duration
+	stale       atomic.Bool
+}
+
+func NewSimilarityIndexer(movieRepo repository.MovieRepository, similarRepo repository.SimilarityRepository, interval time.Duration) SimilarityIndexer {
+	return &similarityIndexer{
+		movieRepo:   movieRepo,
+		similarRepo: similarRepo,
+		interval:    interval,
+	}
+}
+
+// MovieChanged marks the index stale after a movie is added, edited or removed.
+func (ix *similarityIndexer) MovieChanged() {
+	ix.stale.Store(true)
+}
+
+// Run rebuilds the index at startup and then, every interval, if any movie
+// changed since the last rebuild. It returns when ctx is cancelled.
+func (ix *similarityIndexer) Run(ctx context.Context) {
+	ix.stale.Store(true)
+
+	ticker := time.NewTicker(ix.interval)
+	defer ticker.Stop()
+
+	for {
+		if ix.stale.Swap(false) {
+			if err := ix.rebuild(ctx); err != nil {
+				log.Printf("failed to rebuild similar movies: %v", err)
+				ix.stale.Store(true)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (ix *similarityIndexer) rebuild(ctx context.Context) error {
+	started := time.Now()
+
+	movies, err := ix.movieRepo.GetCatalogue(ctx)
+	if err != nil {
+		return err
+	}
+
+	computed := rankSimilarMovies(movies, similarNeighbourCount, started)
+	if err := ix.similarRepo.ReplaceAll(ctx, computed, started); err != nil {
+		return err
+	}
+
+	log.Printf("rebuilt similar movies for %d movies in %s", len(movies), time.Since(started).Round(time.Millisecond))
+	return nil
+}
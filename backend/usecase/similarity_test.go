@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func sameTerms(a, b map[string]float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for term, weight := range a {
+		if other, ok := b[term]; !ok || math.Abs(other-weight) > 1e-9 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKeywordTerms(t *testing.T) {
+	tests := []struct {
+		text string
+		want map[string]float64
+	}{
+		{text: "", want: map[string]float64{}},
+		{text: "Heat", want: map[string]float64{"heat": 1}},
+		{text: "The heist, the HEIST!", want: map[string]float64{"heist": 1 + math.Log(2)}},
+		{text: "A cop and a thief", want: map[string]float64{"cop": 1, "thief": 1}},
+		{text: "Blade Runner 2049", want: map[string]float64{"blade": 1, "runner": 1, "2049": 1}},
+		{text: "Amélie's café", want: map[string]float64{"amélie": 1, "café": 1}},
+	}
+
+	for _, tt := range tests {
+		if got := keywordTerms(tt.text); !sameTerms(got, tt.want) {
+			t.Errorf("keywordTerms(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNameTerms(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  map[string]float64
+	}{
+		{names: nil, want: map[string]float64{}},
+		{names: []string{"Crime", " Thriller "}, want: map[string]float64{"crime": 1, "thriller": 1}},
+		{names: []string{"Al Pacino", "al pacino", "  "}, want: map[string]float64{"al pacino": 1}},
+	}
+
+	for _, tt := range tests {
+		if got := nameTerms(tt.names); !sameTerms(got, tt.want) {
+			t.Errorf("nameTerms(%q) = %v, want %v", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestDot(t *testing.T) {
+	a := map[string]float64{"crime": 0.6, "drama": 0.8}
+	b := map[string]float64{"crime": 0.5, "heist": 0.5, "noir": 0.5}
+	if got := dot(a, b); math.Abs(got-0.3) > 1e-9 {
+		t.Errorf("dot = %v, want 0.3", got)
+	}
+	if got := dot(a, b); got != dot(b, a) {
+		t.Errorf("dot is not symmetric: %v and %v", got, dot(b, a))
+	}
+	if got := dot(a, map[string]float64{}); got != 0 {
+		t.Errorf("dot with an empty vector = %v, want 0", got)
+	}
+}
+
+func TestRankSimilarMovies(t *testing.T) {
+	// Movies are named by their index; IDs ascend with the index, so ties
+	// break in index order.
+	catalogue := func(movies ...domain.Movie) []domain.Movie {
+		for i := range movies {
+			movies[i].ID = primitive.NewObjectID()
+		}
+		return movies
+	}
+	heat := domain.Movie{Genres: []string{"Crime", "Thriller"}, Actors: []string{"Al Pacino", "Robert De Niro"}}
+	ronin := domain.Movie{Genres: []string{"Crime", "Thriller"}, Actors: []string{"Robert De Niro"}}
+	scarface := domain.Movie{Genres: []string{"Crime", "Drama"}, Actors: []string{"Al Pacino"}}
+	comedy := domain.Movie{Genres: []string{"Comedy"}, Actors: []string{"Bill Murray"}}
+
+	tests := []struct {
+		name   string
+		movies []domain.Movie
+		k      int
+		want   [][]int // neighbours of each movie, best first
+	}{
+		{
+			name:   "closer movies rank first and unrelated ones are left out",
+			movies: catalogue(heat, ronin, scarface, comedy),
+			k:      10,
+			want:   [][]int{{1, 2}, {0, 2}, {0, 1}, {}},
+		},
+		{
+			name:   "only the best k are kept",
+			movies: catalogue(heat, ronin, scarface, comedy),
+			k:      1,
+			want:   [][]int{{1}, {0}, {0}, {}},
+		},
+		{
+			name:   "equal scores break on movie ID",
+			movies: catalogue(heat, heat, heat),
+			k:      10,
+			want:   [][]int{{1, 2}, {0, 2}, {0, 1}},
+		},
+		{
+			name:   "a lone movie has no neighbours",
+			movies: catalogue(heat),
+			k:      10,
+			want:   [][]int{{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			computedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+			index := map[primitive.ObjectID]int{}
+			for i, movie := range tt.movies {
+				index[movie.ID] = i
+			}
+
+			results := rankSimilarMovies(tt.movies, tt.k, computedAt)
+			if len(results) != len(tt.movies) {
+				t.Fatalf("got %d results, want one per movie", len(results))
+			}
+			for i, result := range results {
+				if result.MovieID != tt.movies[i].ID || !result.ComputedAt.Equal(computedAt) {
+					t.Errorf("result %d is for movie %s at %v", i, result.MovieID.Hex(), result.ComputedAt)
+				}
+				got := make([]int, len(result.Neighbours))
+				for n, neighbour := range result.Neighbours {
+					got[n] = index[neighbour.MovieID]
+					if neighbour.Score <= 0 || neighbour.Score > 1+1e-9 {
+						t.Errorf("movie %d: neighbour %d scores %v, want a score in (0, 1]", i, got[n], neighbour.Score)
+					}
+				}
+				if !sameOrder(got, tt.want[i]) {
+					t.Errorf("movie %d neighbours = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRankSimilarMoviesWeighsFields(t *testing.T) {
+	// Identical movies score the sum of the weights of the fields they
+	// have, whatever the terms in them.
+	movie := domain.Movie{Title: "Heat", Genres: []string{"Crime"}, Actors: []string{"Al Pacino"}}
+	twin := movie
+	movie.ID, twin.ID = primitive.NewObjectID(), primitive.NewObjectID()
+
+	results := rankSimilarMovies([]domain.Movie{movie, twin}, 10, time.Now())
+	want := 0.35 + 0.25 + 0.25
+	if got := results[0].Neighbours[0].Score; math.Abs(got-want) > 1e-9 {
+		t.Errorf("score of identical movies = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 100
+
+	// trendingListSize is how many movies are ranked per window.
+	trendingListSize = 500
+)
+
+// trendingWindow is how far back a window looks and how quickly older
+// activity loses weight within it.
+type trendingWindow struct {
+	length   time.Duration
+	halfLife time.Duration
+}
+
+var trendingWindows = map[string]trendingWindow{
+	domain.TrendingDay:   {length: 24 * time.Hour, halfLife: 6 * time.Hour},
+	domain.TrendingWeek:  {length: 7 * 24 * time.Hour, halfLife: 36 * time.Hour},
+	domain.TrendingMonth: {length: 30 * 24 * time.Hour, halfLife: 7 * 24 * time.Hour},
+}
+
+// trendingWeights rates how much each kind of engagement counts; a view
+// is cheap, while reviewing or listing a movie takes real interest.
+var trendingWeights = map[string]float64{
+	domain.MovieEventView:    1,
+	domain.MovieEventLike:    3,
+	domain.MovieEventListAdd: 4,
+	domain.MovieEventReview:  5,
+}
+
+type TrendingUsecase interface {
+	GetTrending(window string, limit int) (*domain.BaseResponse, error)
+}
+
+type trendingUsecase struct {
+	trendingRepo repository.TrendingRepository
+	movieRepo    repository.MovieRepository
+}
+
+func NewTrendingUsecase(trendingRepo repository.TrendingRepository, movieRepo repository.MovieRepository) TrendingUsecase {
+	return &trendingUsecase{
+		trendingRepo: trendingRepo,
+		movieRepo:    movieRepo,
+	}
+}
+
+// GetTrending serves the ranking last computed by TrendingAggregator.
+func (uc *trendingUsecase) GetTrending(window string, limit int) (*domain.BaseResponse, error) {
+	if window == "" {
+		window = domain.TrendingDay
+	}
+	if _, ok := trendingWindows[window]; !ok {
+		return nil, domain.Validation(domain.CodeInvalidWindow, "Invalid window", domain.NewErrorDetail(domain.CodeFieldNotOneOf, "window must be day, week or month", "field", "window", "values", "day, week, month"))
+	}
+	if limit <= 0 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
+	}
+
+	results := []domain.ScoredMovie{}
+
+	list, err := uc.trendingRepo.GetList(context.Background(), window)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if list != nil {
+		ranked := list.Movies
+		if len(ranked) > limit {
+			ranked = ranked[:limit]
+		}
+
+		ids := make([]string, 0, len(ranked))
+		for _, trending := range ranked {
+			ids = append(ids, trending.MovieID.Hex())
+		}
+		movies, err := uc.movieRepo.GetByIDs(context.Background(), ids)
+		if err != nil {
+			return nil, err
+		}
+		moviesByID := make(map[string]domain.Movie, len(movies))
+		for _, movie := range movies {
+			moviesByID[movie.ID.Hex()] = movie
+		}
+
+		for _, trending := range ranked {
+			if movie, ok := moviesByID[trending.MovieID.Hex()]; ok {
+				results = append(results, domain.ScoredMovie{Movie: movie, Score: trending.Score})
+			}
+		}
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Trending movies retrieved successfully",
+		Object:  results,
+	}, nil
+}
+
+// TrendingAggregator periodically recomputes the trending rankings from
+// the hourly event counters, so requests only read a stored list.
+type TrendingAggregator interface {
+	Run(ctx context.Context)
+}
+
+type trendingAggregator struct {
+	trendingRepo repository.TrendingRepository
+	interval     time.Duration
+}
+
+func NewTrendingAggregator(trendingRepo repository.TrendingRepository, interval time.Duration) TrendingAggregator {
+	return &trendingAggregator{
+		trendingRepo: trendingRepo,
+		interval:     interval,
+	}
+}
+
+// Run recomputes every window at startup and then every interval. It
+// returns when ctx is cancelled.
+func (a *trendingAggregator) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		for window := range trendingWindows {
+			if err := a.refresh(ctx, window); err != nil {
+				log.Printf("failed to refresh %s trending movies: %v", window, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *trendingAggregator) refresh(ctx context.Context, window string) error {
+	now := time.Now()
+	params := trendingWindows[window]
+
+	movies, err := a.trendingRepo.ScoreMovies(ctx, now.Add(-params.length), now, params.halfLife, trendingWeights, trendingListSize)
+	if err != nil {
+		return err
+	}
+
+	return a.trendingRepo.SaveList(ctx, &domain.TrendingList{
+		Window:     window,
+		Movies:     movies,
+		ComputedAt: now,
+	})
+}
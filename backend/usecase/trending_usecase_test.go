@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetTrending(t *testing.T) {
+	movies := listMovies(3)
+	deleted := primitive.NewObjectID()
+	ranked := []domain.TrendingMovie{
+		{MovieID: movies[2].ID, Score: 9},
+		{MovieID: deleted, Score: 8},
+		{MovieID: movies[0].ID, Score: 7},
+		{MovieID: movies[1].ID, Score: 6},
+	}
+
+	tests := []struct {
+		name   string
+		window string
+		limit  int
+		code   string
+		want   []int
+	}{
+		{name: "the day window is the default", window: "", limit: 10, want: []int{2, 0, 1}},
+		{name: "movies deleted since are skipped", window: domain.TrendingDay, limit: 10, want: []int{2, 0, 1}},
+		{name: "the limit counts ranked movies", window: domain.TrendingDay, limit: 2, want: []int{2}},
+		{name: "a window not computed yet is empty", window: domain.TrendingWeek, limit: 10, want: []int{}},
+		{name: "an unknown window is rejected", window: "year", limit: 10, code: domain.CodeInvalidWindow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trending := &fakeTrendingRepo{lists: map[string]*domain.TrendingList{
+				domain.TrendingDay: {Window: domain.TrendingDay, Movies: ranked},
+			}}
+			uc := NewTrendingUsecase(trending, newFakeMovieRepo(movies...))
+
+			response, err := uc.GetTrending(tt.window, tt.limit)
+			if code := errorCode(err); code != tt.code {
+				t.Fatalf("GetTrending code = %q, want %q", code, tt.code)
+			}
+			if err != nil {
+				return
+			}
+
+			index := map[primitive.ObjectID]int{}
+			for i, movie := range movies {
+				index[movie.ID] = i
+			}
+			results := response.Object.([]domain.ScoredMovie)
+			got := make([]int, len(results))
+			for i, result := range results {
+				got[i] = index[result.Movie.ID]
+			}
+			if !sameOrder(got, tt.want) {
+				t.Errorf("trending = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrendingWindowsFadeOut(t *testing.T) {
+	// Activity at the far edge of a window should count for little next to
+	// fresh activity, or the ranking would barely move within the window.
+	for name, window := range trendingWindows {
+		edge := math.Pow(0.5, float64(window.length)/float64(window.halfLife))
+		if edge > 0.1 {
+			t.Errorf("%s window: activity %v old still counts %.2f", name, window.length, edge)
+		}
+		if window.halfLife >= window.length {
+			t.Errorf("%s window: half-life %v is not shorter than the window", name, window.halfLife)
+		}
+	}
+}
+
+func TestTrendingAggregatorRefresh(t *testing.T) {
+	scored := []domain.TrendingMovie{{MovieID: primitive.NewObjectID(), Score: 3}}
+	trending := &fakeTrendingRepo{scored: scored}
+	aggregator := &trendingAggregator{trendingRepo: trending, interval: time.Hour}
+
+	for window, params := range trendingWindows {
+		if err := aggregator.refresh(context.Background(), window); err != nil {
+			t.Fatalf("refresh(%s): %v", window, err)
+		}
+		if got := trending.halfLife[params.length]; got != params.halfLife {
+			t.Errorf("%s window scored over %v with half-life %v, want %v", window, params.length, got, params.halfLife)
+		}
+		list := trending.lists[window]
+		if list == nil || list.Window != window || len(list.Movies) != 1 || list.ComputedAt.IsZero() {
+			t.Errorf("%s window saved %+v, want the scored movies", window, list)
+		}
+	}
+}
+
+func TestEventWriterCountsByHour(t *testing.T) {
+	trending := &fakeTrendingRepo{}
+	writer := NewEventWriter(trending, time.Hour).(*bufferedEventWriter)
+
+	movie := primitive.NewObjectID()
+	hour := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	for _, event := range []movieEvent{
+		{movieID: movie, eventType: domain.MovieEventView, at: hour.Add(5 * time.Minute)},
+		{movieID: movie, eventType: domain.MovieEventView, at: hour.Add(59 * time.Minute)},
+		{movieID: movie, eventType: domain.MovieEventLike, at: hour.Add(10 * time.Minute)},
+		{movieID: movie, eventType: domain.MovieEventView, at: hour.Add(61 * time.Minute)},
+	} {
+		writer.events <- event
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		writer.Run(ctx)
+		close(done)
+	}()
+	// Run takes an event off the buffer and counts it before it looks at
+	// ctx again, so once the buffer is empty every event is counted.
+	for len(writer.events) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	want := map[eventKey]int64{
+		{movieID: movie, eventType: domain.MovieEventView, hour: hour}:                2,
+		{movieID: movie, eventType: domain.MovieEventLike, hour: hour}:                1,
+		{movieID: movie, eventType: domain.MovieEventView, hour: hour.Add(time.Hour)}: 1,
+	}
+
+	if len(trending.counts) != len(want) {
+		t.Fatalf("wrote %d counters, want %d", len(trending.counts), len(want))
+	}
+	for _, count := range trending.counts {
+		key := eventKey{movieID: count.MovieID, eventType: count.Type, hour: count.Hour}
+		if count.Count != want[key] {
+			t.Errorf("%s at %v counted %d, want %d", count.Type, count.Hour, count.Count, want[key])
+		}
+	}
+}
+
+func TestEventWriterReportsDropsOnFlush(t *testing.T) {
+	writer := NewEventWriter(&fakeTrendingRepo{}, time.Hour).(*bufferedEventWriter)
+	movie := primitive.NewObjectID()
+	for i := 0; i < eventBufferSize+3; i++ {
+		writer.Record(movie, domain.MovieEventView)
+	}
+	if dropped := writer.dropped.Load(); dropped != 3 {
+		t.Fatalf("dropped %d events, want 3", dropped)
+	}
+
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+	writer.flush(context.Background(), map[eventKey]int64{})
+
+	if lines := strings.Count(logged.String(), "\n"); lines != 1 || !strings.Contains(logged.String(), "dropped 3 movie events") {
+		t.Errorf("flush logged %q, want one summary of 3 drops", logged.String())
+	}
+	if dropped := writer.dropped.Load(); dropped != 0 {
+		t.Errorf("%d drops left after the flush, want 0", dropped)
+	}
+}
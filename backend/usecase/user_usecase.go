@@ -2,8 +2,6 @@ package usecase
 
 import (
 	"context"
-	"errors"
-	"regexp"
 	"time"
 
 	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
@@ -15,6 +13,7 @@ import (
 type UserUsecase interface {
 	Signup(user *domain.SignupRequest) (*domain.AuthResponse, error)
 	Login(user *domain.LoginRequest) (*domain.AuthResponse, error)
+	GetUser(id string) (*domain.BaseResponse, error)
 }
 type userUsecase struct {
 	userRepo       repository.UserRepository
@@ -31,27 +30,17 @@ func NewUserUsecase(userRepo repository.UserRepository, jwtSecret string, timeou
 
 func (uc *userUsecase) Signup(userReq *domain.SignupRequest) (*domain.AuthResponse, error) {
     // Validate input
-    if err := validateSignupInput(userReq); err != nil {
-        return &domain.AuthResponse{
-            Success: false,
-            Message: "Validation failed",
-            Errors:  []string{err.Error()},
-        }, nil
+    if err := ValidateRequest(userReq); err != nil {
+        return nil, err
     }
 
     // Check if email or username already exists
     if _, err := uc.userRepo.FindByEmail(context.Background(), userReq.Email); err == nil {
-        return &domain.AuthResponse{
-            Success: false,
-            Message: "Email already exists",
-        }, nil
+        return nil, domain.Conflict(domain.CodeEmailTaken, "Email already exists")
     }
 
     if _, err := uc.userRepo.FindByUsername(context.Background(), userReq.Username); err == nil {
-        return &domain.AuthResponse{
-            Success: false,
-            Message: "Username already exists",
-        }, nil
+        return nil, domain.Conflict(domain.CodeUsernameTaken, "Username already exists")
     }
 
     // Hash password
@@ -77,33 +66,6 @@ func (uc *userUsecase) Signup(userReq *domain.SignupRequest) (*domain.AuthRespon
     }, nil
 }
 
-func validateSignupInput(user *domain.SignupRequest) error {
-    // Email validation
-    emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
-    if !emailRegex.MatchString(user.Email) {
-        return errors.New("invalid email format")
-    }
-
-    if matched, _ := regexp.MatchString(`^[a-zA-Z0-9]+$`, user.Username); !matched {
-        return errors.New("username must be alphanumeric only")
-    }
-
-    if len(user.Password) < 8 {
-        return errors.New("password must be at least 8 characters long")
-    }
-    if matched, _ := regexp.MatchString(`[A-Z]`, user.Password); !matched {
-        return errors.New("password must contain at least one uppercase letter")
-    }
-    if matched, _ := regexp.MatchString(`[a-z]`, user.Password); !matched {
-        return errors.New("password must contain at least one lowercase letter")
-    }
-    if matched, _ := regexp.MatchString(`[!@#$%^&*(),.?":{}|<>]`, user.Password); !matched {
-        return errors.New("password must contain at least one special character")
-    }
-
-    return nil
-}
-
 func (uc *userUsecase) Login(req *domain.LoginRequest) (*domain.AuthResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), uc.contextTimeout)
 	defer cancel()
@@ -111,19 +73,13 @@ func (uc *userUsecase) Login(req *domain.LoginRequest) (*domain.AuthResponse, er
 	// Find user by email
 	user, err := uc.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil {
-		return &domain.AuthResponse{
-			Success: false,
-			Message: "Invalid email or password",
-		}, nil
+		return nil, domain.Unauthorized(domain.CodeInvalidCredentials, "Invalid email or password")
 	}
 
 	// Compare password with hashed password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return &domain.AuthResponse{
-			Success: false,
-			Message: "Invalid email or password",
-		}, nil
+		return nil, domain.Unauthorized(domain.CodeInvalidCredentials, "Invalid email or password")
 	}
 
 	// Generate JWT token
@@ -139,6 +95,24 @@ func (uc *userUsecase) Login(req *domain.LoginRequest) (*domain.AuthResponse, er
 	}, nil
 }
 
+// GetUser returns a user's account. Its email is only for the user
+// themselves to see; callers showing it to others must leave it out.
+func (uc *userUsecase) GetUser(id string) (*domain.BaseResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), uc.contextTimeout)
+	defer cancel()
+
+	user, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeUserNotFound, "User not found"))
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "User retrieved successfully",
+		Object:  user,
+	}, nil
+}
+
 func (uc *userUsecase) generateJWTToken(user *domain.User) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":  user.ID.Hex(),
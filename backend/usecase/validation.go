@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// passwordSpecials are the characters a password needs one of.
+const passwordSpecials = `!@#$%^&*(),.?":{}|<>`
+
+// requestRules are the binding rules request DTOs use beyond the ones the
+// validator has built in.
+var requestRules = map[string]validator.Func{
+	"hasupper": func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), unicode.IsUpper) >= 0
+	},
+	"haslower": func(fl validator.FieldLevel) bool {
+		return strings.IndexFunc(fl.Field().String(), unicode.IsLower) >= 0
+	},
+	"hasspecial": func(fl validator.FieldLevel) bool {
+		return strings.ContainsAny(fl.Field().String(), passwordSpecials)
+	},
+	"halfstep": func(fl validator.FieldLevel) bool {
+		return math.Mod(fl.Field().Float()*2, 1) == 0
+	},
+}
+
+// init registers requestRules with gin's validator, and has it name
+// fields by their JSON names so that errors point into the request body.
+func init() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	engine.RegisterTagNameFunc(jsonFieldName)
+	for tag, rule := range requestRules {
+		if err := engine.RegisterValidation(tag, rule); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// ValidateRequest normalizes a request and checks it against its binding
+// rules, reporting every field that breaks one at once.
+func ValidateRequest(req interface{}) error {
+	NormalizeRequest(req)
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return domain.Validation(domain.CodeValidationFailed, "Validation failed", ValidationDetails(err)...)
+	}
+	return nil
+}
+
+// NormalizeRequest trims the whitespace around every string in a request,
+// including those in nested structs and slices. Fields tagged
+// normalize:"-", such as passwords, are kept as sent.
+func NormalizeRequest(req interface{}) {
+	normalizeValue(reflect.ValueOf(req))
+}
+
+func normalizeValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			normalizeValue(v.Elem())
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(strings.TrimSpace(v.String()))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if field.IsExported() && field.Tag.Get("normalize") != "-" {
+				normalizeValue(v.Field(i))
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			normalizeValue(v.Index(i))
+		}
+	}
+}
+
+// ValidationDetails describes why a request failed to bind: one detail
+// per failed binding rule, pointing at the JSON field it applies to, or a
+// single detail for a body that is not valid JSON.
+func ValidationDetails(err error) []domain.ErrorDetail {
+	var fieldErrors validator.ValidationErrors
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &fieldErrors):
+		details := make([]domain.ErrorDetail, 0, len(fieldErrors))
+		for _, fieldError := range fieldErrors {
+			details = append(details, fieldDetail(fieldError))
+		}
+		return details
+	case errors.As(err, &typeError):
+		return []domain.ErrorDetail{domain.NewErrorDetail(domain.CodeFieldWrongType,
+			typeError.Field+" must be a "+typeError.Type.String(),
+			"field", typeError.Field, "type", typeError.Type.String())}
+	case errors.As(err, &syntaxError), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return []domain.ErrorDetail{domain.NewErrorDetail(domain.CodeMalformedBody, "request body is not valid JSON")}
+	}
+	return domain.ErrorDetails(err)
+}
+
+func fieldDetail(fieldError validator.FieldError) domain.ErrorDetail {
+	field := fieldPath(fieldError.Namespace())
+	param := fieldError.Param()
+
+	switch fieldError.Tag() {
+	case "required":
+		return domain.NewErrorDetail(domain.CodeFieldRequired, field+" is required", "field", field)
+	case "gte", "min":
+		switch fieldError.Kind() {
+		case reflect.String:
+			return domain.NewErrorDetail(domain.CodeFieldTooShort, field+" must be at least "+param+" characters long", "field", field, "min", param)
+		case reflect.Slice, reflect.Map:
+			return domain.NewErrorDetail(domain.CodeFieldTooFew, field+" must have at least "+param+" items", "field", field, "min", param)
+		}
+		return domain.NewErrorDetail(domain.CodeFieldTooSmall, field+" must be at least "+param, "field", field, "min", param)
+	case "lte", "max":
+		switch fieldError.Kind() {
+		case reflect.String:
+			return domain.NewErrorDetail(domain.CodeFieldTooLong, field+" must be at most "+param+" characters long", "field", field, "max", param)
+		case reflect.Slice, reflect.Map:
+			return domain.NewErrorDetail(domain.CodeFieldTooMany, field+" must have at most "+param+" items", "field", field, "max", param)
+		}
+		return domain.NewErrorDetail(domain.CodeFieldTooLarge, field+" must be at most "+param, "field", field, "max", param)
+	case "oneof":
+		values := strings.Join(strings.Fields(param), ", ")
+		return domain.NewErrorDetail(domain.CodeFieldNotOneOf, field+" must be one of "+values, "field", field, "values", values)
+	case "unique":
+		return domain.NewErrorDetail(domain.CodeFieldNotUnique, field+" must not contain duplicates", "field", field)
+	case "url", "http_url":
+		return domain.NewErrorDetail(domain.CodeFieldNotURL, field+" must be a URL", "field", field)
+	case "email":
+		return domain.NewErrorDetail(domain.CodeInvalidEmail, "invalid email format", "field", field)
+	case "mongodb":
+		return domain.NewErrorDetail(domain.CodeInvalidID, field+" must be a valid ID", "field", field)
+	case "alphanum":
+		return domain.NewErrorDetail(domain.CodeFieldNotAlphanumeric, field+" must contain only letters and digits", "field", field)
+	case "hasupper":
+		return domain.NewErrorDetail(domain.CodePasswordMissingUppercase, field+" must contain at least one uppercase letter", "field", field)
+	case "haslower":
+		return domain.NewErrorDetail(domain.CodePasswordMissingLowercase, field+" must contain at least one lowercase letter", "field", field)
+	case "hasspecial":
+		return domain.NewErrorDetail(domain.CodePasswordMissingSpecial, field+" must contain at least one special character", "field", field)
+	case "halfstep":
+		return domain.NewErrorDetail(domain.CodeRatingHalfStep, field+" must be in half-star steps", "field", field)
+	}
+	return domain.NewErrorDetail(domain.CodeFieldInvalid, field+" is invalid", "field", field)
+}
+
+// fieldPath turns a validator namespace such as
+// "CreateMovieRequest.translations[0].title" into "translations[0].title".
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+// jsonFieldName names a struct field as it appears in JSON. Fields that
+// JSON leaves out are named after the Go field.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return strings.ToLower(field.Name[:1]) + field.Name[1:]
+	}
+	return name
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+)
+
+type sampleNote struct {
+	Text string `json:"text" binding:"required,max=10"`
+}
+
+type sampleRequest struct {
+	Name     string       `json:"name" binding:"required,max=5"`
+	Password string       `json:"password" binding:"omitempty,min=8,hasupper,haslower,hasspecial" normalize:"-"`
+	Tags     []string     `json:"tags" binding:"max=2,unique,dive,max=4"`
+	Rating   float64      `json:"rating" binding:"omitempty,gte=0.5,lte=5,halfstep"`
+	Kind     string       `json:"kind" binding:"omitempty,oneof=film short"`
+	Link     string       `json:"link" binding:"omitempty,url"`
+	Notes    []sampleNote `json:"notes" binding:"dive"`
+	Owner    *sampleNote  `json:"owner"`
+	UserID   string       `json:"-" binding:"omitempty,mongodb"`
+}
+
+func TestValidateRequest(t *testing.T) {
+	valid := func() *sampleRequest {
+		return &sampleRequest{Name: "Heat", Password: "Secret!pw", Tags: []string{"noir"}, Rating: 4.5, Kind: "film", Link: "https://example.com"}
+	}
+
+	tests := []struct {
+		name    string
+		req     func() *sampleRequest
+		details []string // "code pointer" per invalid field
+	}{
+		{name: "a valid request", req: valid},
+		{
+			name: "whitespace is trimmed before checking",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Name = "  Heat  "
+				req.Tags = []string{" noir "}
+				return req
+			},
+		},
+		{
+			name: "a blank required field",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Name = "   "
+				return req
+			},
+			details: []string{"field_required /name"},
+		},
+		{
+			name: "every broken field is reported",
+			req: func() *sampleRequest {
+				return &sampleRequest{Name: "Heat and more", Tags: []string{"a", "a"}, Rating: 5.5, Kind: "series", Link: "not a link"}
+			},
+			details: []string{"field_too_long /name", "field_not_unique /tags", "field_too_large /rating", "field_not_one_of /kind", "field_not_url /link"},
+		},
+		{
+			name: "too many items",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Tags = []string{"a", "b", "c"}
+				return req
+			},
+			details: []string{"field_too_many /tags"},
+		},
+		{
+			name: "a short password",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Password = "Sh0rt!"
+				return req
+			},
+			details: []string{"field_too_short /password"},
+		},
+		{
+			name: "a password without capitals",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Password = "secret!pw"
+				return req
+			},
+			details: []string{"password_missing_uppercase /password"},
+		},
+		{
+			name: "a password without lowercase letters",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Password = "SECRET!PW"
+				return req
+			},
+			details: []string{"password_missing_lowercase /password"},
+		},
+		{
+			name: "a password without special characters",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Password = "SecretPw1"
+				return req
+			},
+			details: []string{"password_missing_special /password"},
+		},
+		{
+			name: "ratings in half-star steps",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Rating = 3.3
+				return req
+			},
+			details: []string{domain.CodeRatingHalfStep + " /rating"},
+		},
+		{
+			name: "nested fields point into the body",
+			req: func() *sampleRequest {
+				req := valid()
+				req.Tags = []string{"noir", "crime"}
+				req.Notes = []sampleNote{{Text: "ok"}, {Text: " "}}
+				return req
+			},
+			details: []string{"field_too_long /tags/1", "field_required /notes/1/text"},
+		},
+		{
+			name: "fields left out of JSON are named after the Go field",
+			req: func() *sampleRequest {
+				req := valid()
+				req.UserID = "me"
+				return req
+			},
+			details: []string{"invalid_id /userID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRequest(tt.req())
+			if len(tt.details) == 0 {
+				if err != nil {
+					t.Fatalf("ValidateRequest: %v", err)
+				}
+				return
+			}
+			if code := errorCode(err); code != domain.CodeValidationFailed {
+				t.Fatalf("ValidateRequest code = %q, want %q", code, domain.CodeValidationFailed)
+			}
+
+			var domainErr *domain.Error
+			errors.As(err, &domainErr)
+			var got []string
+			for _, detail := range domainErr.Details {
+				got = append(got, detail.Code+" "+detail.Pointer)
+			}
+			if strings.Join(got, "; ") != strings.Join(tt.details, "; ") {
+				t.Errorf("details = %q\nwant %q", got, tt.details)
+			}
+		})
+	}
+}
+
+func TestNormalizeRequest(t *testing.T) {
+	req := &sampleRequest{
+		Name:     "\t Heat \n",
+		Password: "  spaced out  ",
+		Tags:     []string{" noir", "crime "},
+		Notes:    []sampleNote{{Text: " first "}},
+		Owner:    &sampleNote{Text: " me "},
+	}
+	NormalizeRequest(req)
+
+	if req.Name != "Heat" || req.Tags[0] != "noir" || req.Tags[1] != "crime" || req.Notes[0].Text != "first" || req.Owner.Text != "me" {
+		t.Errorf("normalized = %+v (owner %+v), want every string trimmed", *req, *req.Owner)
+	}
+	if req.Password != "  spaced out  " {
+		t.Errorf("password = %q, want it kept as sent", req.Password)
+	}
+
+	// Nil pointers and values that cannot be set are fine
+	NormalizeRequest(&sampleRequest{})
+	NormalizeRequest(sampleRequest{Name: " Heat "})
+}
+
+func TestValidationDetailsForBodies(t *testing.T) {
+	tests := []struct {
+		body string
+		code string
+	}{
+		{body: `{"name": `, code: domain.CodeMalformedBody},
+		{body: `{"name" "Heat"}`, code: domain.CodeMalformedBody},
+		{body: `{"rating": "five"}`, code: domain.CodeFieldWrongType},
+	}
+
+	for _, tt := range tests {
+		var req sampleRequest
+		err := json.Unmarshal([]byte(tt.body), &req)
+		details := ValidationDetails(err)
+		if len(details) != 1 || details[0].Code != tt.code {
+			t.Errorf("details for %s = %+v, want one %q", tt.body, details, tt.code)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"github.com/AfomiaTadesse/Afomia_M/backend/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WatchlistUsecase interface {
+	AddToWatchlist(req *domain.AddToWatchlistRequest) (*domain.BaseResponse, error)
+	RemoveFromWatchlist(userID, movieID string) (*domain.BaseResponse, error)
+	GetWatchlist(userID, from, to string, page, size int) (*domain.PaginatedResponse, error)
+}
+
+type watchlistUsecase struct {
+	watchlistRepo repository.WatchlistRepository
+	movieRepo     repository.MovieRepository
+}
+
+func NewWatchlistUsecase(watchlistRepo repository.WatchlistRepository, movieRepo repository.MovieRepository) WatchlistUsecase {
+	return &watchlistUsecase{
+		watchlistRepo: watchlistRepo,
+		movieRepo:     movieRepo,
+	}
+}
+
+func (uc *watchlistUsecase) AddToWatchlist(req *domain.AddToWatchlistRequest) (*domain.BaseResponse, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidUserID, "Invalid user ID", domain.ErrorDetails(err)...)
+	}
+
+	movie, err := uc.movieRepo.GetByID(context.Background(), req.MovieID)
+	if err != nil {
+		return nil, lookupError(err, domain.NotFound(domain.CodeMovieNotFound, "Movie not found"))
+	}
+
+	entry := &domain.WatchlistEntry{
+		UserID:  userID,
+		MovieID: movie.ID,
+		AddedAt: time.Now(),
+	}
+
+	if err := uc.watchlistRepo.Add(context.Background(), entry); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.Conflict(domain.CodeAlreadyOnWatchlist, "Movie is already on your watchlist")
+		}
+		return nil, err
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movie added to watchlist",
+		Object:  entry,
+	}, nil
+}
+
+func (uc *watchlistUsecase) RemoveFromWatchlist(userID, movieID string) (*domain.BaseResponse, error) {
+	if !primitive.IsValidObjectID(movieID) {
+		return nil, domain.NotFound(domain.CodeNotOnWatchlist, "Movie is not on your watchlist")
+	}
+
+	removed, err := uc.watchlistRepo.Remove(context.Background(), userID, movieID)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, domain.NotFound(domain.CodeNotOnWatchlist, "Movie is not on your watchlist")
+	}
+
+	return &domain.BaseResponse{
+		Success: true,
+		Message: "Movie removed from watchlist",
+	}, nil
+}
+
+func (uc *watchlistUsecase) GetWatchlist(userID, from, to string, page, size int) (*domain.PaginatedResponse, error) {
+	fromDate, toDate, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, domain.Validation(domain.CodeInvalidDateFilter, "Invalid date filter", domain.ErrorDetails(err)...)
+	}
+
+	entries, total, err := uc.watchlistRepo.GetByUserID(context.Background(), userID, fromDate, toDate, page, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PaginatedResponse{
+		Success:    true,
+		Message:    "Watchlist retrieved successfully",
+		Object:     entries,
+		PageNumber: page,
+		PageSize:   size,
+		TotalSize:  total,
+	}, nil
+}
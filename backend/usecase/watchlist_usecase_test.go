@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/AfomiaTadesse/Afomia_M/backend/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAddToWatchlist(t *testing.T) {
+	movie := &domain.Movie{Title: "Alien"}
+	watchlist := &fakeWatchlistRepo{}
+	uc := NewWatchlistUsecase(watchlist, newFakeMovieRepo(movie))
+	userID := primitive.NewObjectID().Hex()
+
+	if _, err := uc.AddToWatchlist(&domain.AddToWatchlistRequest{UserID: userID, MovieID: movie.ID.Hex()}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := uc.AddToWatchlist(&domain.AddToWatchlistRequest{UserID: userID, MovieID: movie.ID.Hex()})
+	if code := errorCode(err); code != domain.CodeAlreadyOnWatchlist {
+		t.Errorf("adding twice code = %q, want %q", code, domain.CodeAlreadyOnWatchlist)
+	}
+	if len(watchlist.entries) != 1 {
+		t.Errorf("%d watchlist entries, want 1", len(watchlist.entries))
+	}
+
+	// Another user may add the same movie
+	if _, err := uc.AddToWatchlist(&domain.AddToWatchlistRequest{UserID: primitive.NewObjectID().Hex(), MovieID: movie.ID.Hex()}); err != nil {
+		t.Errorf("another user adding the movie: %v", err)
+	}
+
+	_, err = uc.AddToWatchlist(&domain.AddToWatchlistRequest{UserID: userID, MovieID: primitive.NewObjectID().Hex()})
+	if code := errorCode(err); code != domain.CodeMovieNotFound {
+		t.Errorf("adding an unknown movie code = %q, want %q", code, domain.CodeMovieNotFound)
+	}
+}
+
+func TestRemoveFromWatchlist(t *testing.T) {
+	movie := &domain.Movie{Title: "Alien"}
+	movies := newFakeMovieRepo(movie)
+	owner, other := primitive.NewObjectID(), primitive.NewObjectID()
+	watchlist := &fakeWatchlistRepo{entries: []domain.WatchlistEntry{{ID: primitive.NewObjectID(), UserID: owner, MovieID: movie.ID}}}
+	uc := NewWatchlistUsecase(watchlist, movies)
+
+	tests := []struct {
+		name    string
+		userID  string
+		movieID string
+		want    string
+	}{
+		{name: "another user's entry", userID: other.Hex(), movieID: movie.ID.Hex(), want: domain.CodeNotOnWatchlist},
+		{name: "an invalid movie ID", userID: owner.Hex(), movieID: "alien", want: domain.CodeNotOnWatchlist},
+		{name: "the owner's entry", userID: owner.Hex(), movieID: movie.ID.Hex()},
+		{name: "an entry already removed", userID: owner.Hex(), movieID: movie.ID.Hex(), want: domain.CodeNotOnWatchlist},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.RemoveFromWatchlist(tt.userID, tt.movieID)
+			if code := errorCode(err); code != tt.want {
+				t.Errorf("code = %q, want %q", code, tt.want)
+			}
+		})
+	}
+	if len(watchlist.entries) != 0 {
+		t.Errorf("%d watchlist entries left, want 0", len(watchlist.entries))
+	}
+}